@@ -0,0 +1,126 @@
+package engosdl
+
+// Animation clip playback modes.
+const (
+	// AnimationLoop plays the clip from the first frame to the last one and
+	// it starts again from the first frame.
+	AnimationLoop int = 0
+	// AnimationPingPong plays the clip forward and backward.
+	AnimationPingPong int = 1
+	// AnimationOnce plays the clip only once and it stops at the last frame.
+	AnimationOnce int = 2
+)
+
+// AnimationEventFinished is the event triggered by an animator when a clip
+// played once reaches its end.
+const AnimationEventFinished string = "finished"
+
+// AnimationFrame represents a frame in an animation clip. Index is the sprite
+// sheet frame to be displayed. If Region is not empty, it is the name of the
+// atlas region to be displayed instead, and if Rect is not nil, it is the
+// region of the sprite image to be displayed. Duration is given in seconds.
+// Event is triggered when the frame is displayed, empty event is not
+// triggered.
+type AnimationFrame struct {
+	Index    int     `json:"index"`
+	Region   string  `json:"region"`
+	Rect     *Rect   `json:"rect"`
+	Duration float64 `json:"duration"`
+	Event    string  `json:"event"`
+}
+
+// NewAnimationFrame creates a new animation frame instance.
+func NewAnimationFrame(index int, duration float64) *AnimationFrame {
+	return &AnimationFrame{
+		Index:    index,
+		Region:   "",
+		Rect:     nil,
+		Duration: duration,
+		Event:    "",
+	}
+}
+
+// NewAnimationFrameWithRect creates a new animation frame instance that
+// displays the given region of the sprite image.
+func NewAnimationFrameWithRect(rect *Rect, duration float64) *AnimationFrame {
+	frame := NewAnimationFrame(0, duration)
+	frame.Rect = rect
+	return frame
+}
+
+// NewAnimationFrameWithRegion creates a new animation frame instance that
+// displays the atlas region with the given name.
+func NewAnimationFrameWithRegion(region string, duration float64) *AnimationFrame {
+	frame := NewAnimationFrame(0, duration)
+	frame.Region = region
+	return frame
+}
+
+// SetEvent sets the event triggered when the frame is displayed.
+func (f *AnimationFrame) SetEvent(event string) *AnimationFrame {
+	f.Event = event
+	return f
+}
+
+// AnimationClip represents a named sequence of frames and how they are
+// played.
+type AnimationClip struct {
+	Name   string            `json:"name"`
+	Mode   int               `json:"mode"`
+	Frames []*AnimationFrame `json:"frames"`
+}
+
+// NewAnimationClip creates a new animation clip instance.
+func NewAnimationClip(name string, mode int, frames ...*AnimationFrame) *AnimationClip {
+	return &AnimationClip{
+		Name:   name,
+		Mode:   mode,
+		Frames: frames,
+	}
+}
+
+// NewAnimationClipFromRange creates a new animation clip instance for the
+// sprite sheet frames from first to last, both included, all of them with
+// the same duration.
+func NewAnimationClipFromRange(name string, mode int, first int, last int, duration float64) *AnimationClip {
+	clip := NewAnimationClip(name, mode)
+	step := 1
+	if last < first {
+		step = -1
+	}
+	for index := first; index != last+step; index += step {
+		clip.Frames = append(clip.Frames, NewAnimationFrame(index, duration))
+	}
+	return clip
+}
+
+// NewAnimationClipFromAtlas creates a new animation clip instance for all
+// atlas regions with a name starting with the given prefix, sorted by name,
+// all of them with the same duration.
+func NewAnimationClipFromAtlas(name string, mode int, atlas ITextureAtlas, prefix string, duration float64) *AnimationClip {
+	clip := NewAnimationClip(name, mode)
+	for _, frame := range atlas.GetFramesWithPrefix(prefix) {
+		clip.Frames = append(clip.Frames, NewAnimationFrameWithRegion(frame.Name, duration))
+	}
+	return clip
+}
+
+// UnmarshalAnimationClip returns an animation clip from the given data.
+func UnmarshalAnimationClip(data map[string]interface{}) *AnimationClip {
+	clip := NewAnimationClip(data["name"].(string), int(data["mode"].(float64)))
+	for _, f := range data["frames"].([]interface{}) {
+		obj := f.(map[string]interface{})
+		frame := NewAnimationFrame(int(obj["index"].(float64)), obj["duration"].(float64))
+		if region, ok := obj["region"].(string); ok {
+			frame.Region = region
+		}
+		if rect, ok := obj["rect"].(map[string]interface{}); ok {
+			frame.Rect = NewRect(rect["X"].(float64), rect["Y"].(float64), rect["W"].(float64), rect["H"].(float64))
+		}
+		if event, ok := obj["event"].(string); ok {
+			frame.Event = event
+		}
+		clip.Frames = append(clip.Frames, frame)
+	}
+	return clip
+}
@@ -0,0 +1,87 @@
+package engosdl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+// runAnimator plays the given clip for the given number of frames. It
+// returns the sprite index displayed after every update and all animator
+// events triggered.
+func runAnimator(t *testing.T, clip *engosdl.AnimationClip, speed float64, frames int) (*components.Animator, []int, []string) {
+	var animator *components.Animator
+	indexes := []int{}
+	events := []string{}
+	scene := engosdl.NewScene("test-animator-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity := engosdl.NewEntity("player")
+		sprite := components.NewSprite("player/sprite", []string{}, 3, engosdl.FormatBMP)
+		animator = components.NewAnimator("player/animator", []*engosdl.AnimationClip{clip}, clip.Name)
+		animator.SetSprite(sprite)
+		animator.SetSpeed(speed)
+		entity.AddComponent(animator)
+		listener := engosdl.NewComponent("player/listener")
+		listener.AddDelegateToRegister(nil, nil, &components.Animator{}, func(params ...interface{}) bool {
+			events = append(events, params[2].(string))
+			return true
+		})
+		listener.SetCustomOnUpdate(func(engosdl.IComponent) {
+			indexes = append(indexes, sprite.GetSpriteIndex())
+		})
+		entity.AddComponent(listener)
+		scene.AddEntity(entity)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, frames)
+	engine.DoCleanup()
+	return animator, indexes, events
+}
+
+func TestAnimator_Modes(t *testing.T) {
+	cases := []struct {
+		name  string
+		mode  int
+		speed float64
+		exp   []int
+	}{
+		{"loop", engosdl.AnimationLoop, 1, []int{0, 0, 1, 1, 2, 2, 0, 0, 1, 1}},
+		{"ping-pong", engosdl.AnimationPingPong, 1, []int{0, 0, 1, 1, 2, 2, 1, 1, 0, 0}},
+		{"once", engosdl.AnimationOnce, 1, []int{0, 0, 1, 1, 2, 2, 2, 2, 2, 2}},
+		{"fast loop", engosdl.AnimationLoop, 2, []int{0, 1, 2, 0, 1, 2, 0, 1, 2, 0}},
+	}
+	for _, c := range cases {
+		clip := engosdl.NewAnimationClipFromRange("walk", c.mode, 0, 2, 0.07)
+		_, got, _ := runAnimator(t, clip, c.speed, 11)
+		if !reflect.DeepEqual(c.exp, got) {
+			t.Errorf("error playing %s clip\nexp: %v\ngot: %v\n", c.name, c.exp, got)
+		}
+	}
+}
+
+func TestAnimator_Events(t *testing.T) {
+	clip := engosdl.NewAnimationClip("attack", engosdl.AnimationOnce,
+		engosdl.NewAnimationFrame(2, 0.07),
+		engosdl.NewAnimationFrame(0, 0.07).SetEvent("hit"),
+		engosdl.NewAnimationFrameWithRect(engosdl.NewRect(0, 0, 8, 8), 0.07))
+	animator, got, events := runAnimator(t, clip, 1, 11)
+	if exp := []int{2, 2, 0, 0, 0, 0, 0, 0, 0, 0}; !reflect.DeepEqual(exp, got) {
+		t.Errorf("error playing clip\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if exp := []string{"hit", engosdl.AnimationEventFinished}; !reflect.DeepEqual(exp, events) {
+		t.Errorf("error triggering events\nexp: %v\ngot: %v\n", exp, events)
+	}
+	if animator.IsPlaying() || animator.GetFrameIndex() != 2 {
+		t.Errorf("error stopping clip\nexp: %v %d\ngot: %v %d\n", false, 2, animator.IsPlaying(), animator.GetFrameIndex())
+	}
+	if camera := animator.GetSprite().GetCamera(); camera == nil || camera.W != 8 {
+		t.Errorf("error displaying frame region\nexp: %v\ngot: %v\n", engosdl.NewRect(0, 0, 8, 8), camera)
+	}
+	if err := animator.Play("run"); err == nil {
+		t.Errorf("error playing unknown clip")
+	}
+}
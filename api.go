@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
-	"github.com/veandco/go-sdl2/sdl"
 )
 
 // Logger is the system logger to be used by the application.
@@ -19,6 +18,10 @@ var gameEngine *Engine
 // components registered in the application.
 var componentManager *ComponentManager
 
+// prefabManager is the prefab manager in charge of tracking all prefabs
+// registered in the application.
+var prefabManager *PrefabManager
+
 func init() {
 	file, err := os.Create("engosdl.log")
 	if err != nil {
@@ -30,14 +33,26 @@ func init() {
 	Logger.Info().Msg("start engosdl")
 	Logger.Info().Msg("create component manager")
 	componentManager = NewComponentManager("component-manager")
+	Logger.Info().Msg("create prefab manager")
+	prefabManager = NewPrefabManager("prefab-manager")
 }
 
-// Frames per second constants.
+// Frames per second constants. Engine renders up to _fps frames per second,
+// while updates run at a fixed rate given by _fixedDeltaTime.
 const (
-	_fps   uint32 = 30
+	_fps   uint32 = 60
 	_delay uint32 = 1000 / _fps
+	// _fixedDeltaTime is the default update step in seconds.
+	_fixedDeltaTime float64 = 1.0 / 30.0
+	// _maxFrameTime is the maximum elapsed time in seconds processed in a
+	// single frame.
+	_maxFrameTime float64 = 0.25
 )
 
+// _broadphaseCellSize is the default cell size used by the spatial hash
+// broadphase.
+const _broadphaseCellSize float64 = 64
+
 // Graphics format constants.
 const (
 	// FormatBMP identifies sprites in BMP format.
@@ -140,8 +155,21 @@ func GetGameManager() IGameManager {
 	return nil
 }
 
+// GetLoadManager returns the engine load manager.
+func GetLoadManager() ILoadManager {
+	if engine := GetEngine(); engine != nil {
+		return engine.GetLoadManager()
+	}
+	return nil
+}
+
+// GetPrefabManager returns the prefab manager.
+func GetPrefabManager() *PrefabManager {
+	return prefabManager
+}
+
 // GetRenderer returns the engine renderer.
-func GetRenderer() *sdl.Renderer {
+func GetRenderer() IRenderer {
 	if engine := GetEngine(); engine != nil {
 		return engine.GetRenderer()
 	}
@@ -172,6 +200,52 @@ func GetSoundManager() ISoundManager {
 	return nil
 }
 
+// GetTimeManager returns the engine time manager.
+func GetTimeManager() ITimeManager {
+	if engine := GetEngine(); engine != nil {
+		return engine.GetTimeManager()
+	}
+	return nil
+}
+
+// GetTweenManager returns the engine tween manager.
+func GetTweenManager() ITweenManager {
+	if engine := GetEngine(); engine != nil {
+		return engine.GetTweenManager()
+	}
+	return nil
+}
+
+// GetDeltaTime returns the time in seconds for the running update step. It
+// should be used to express any movement or timing in units per second.
+func GetDeltaTime() float64 {
+	if timeManager := GetTimeManager(); timeManager != nil {
+		return timeManager.GetDeltaTime()
+	}
+	return 0
+}
+
+// GetAlpha returns the interpolation factor between the previous update step
+// and the last one. It should be used when rendering, so movement looks
+// smooth at any frame rate. It is 1 if there is not any time manager, so the
+// last update step is rendered.
+func GetAlpha() float64 {
+	if timeManager := GetTimeManager(); timeManager != nil {
+		return timeManager.GetAlpha()
+	}
+	return 1
+}
+
+// ReportError logs the given error and triggers the error delegate, so the
+// application can handle it, like using a fallback texture, instead of
+// crashing.
+func ReportError(err error) {
+	Logger.Error().Err(err).Msg("engine error")
+	if delegateManager := GetDelegateManager(); delegateManager != nil && delegateManager.GetErrorDelegate() != nil {
+		delegateManager.TriggerDelegate(delegateManager.GetErrorDelegate(), true, err)
+	}
+}
+
 // EntitiesInCollision identifies entities being passed in a collision
 // notification.
 func EntitiesInCollision(entity IEntity, params ...interface{}) (IEntity, IEntity, error) {
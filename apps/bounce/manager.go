@@ -78,19 +78,19 @@ func (h *GameManager) createBox(name string, position *engosdl.Vector, speed *en
 
 func (h *GameManager) createScene() func(engine *engosdl.Engine, scene engosdl.IScene) bool {
 	return func(engine *engosdl.Engine, scene engosdl.IScene) bool {
-		box1 := h.createBox("box1", engosdl.NewVector(50, 50), engosdl.NewVector(10, 10))
-		box2 := h.createBox("box2", engosdl.NewVector(50, 350), engosdl.NewVector(9, -9))
-		box7 := h.createBox("box7", engosdl.NewVector(50, 200), engosdl.NewVector(8, -8))
-		box3 := h.createBox("box3", engosdl.NewVector(750, 50), engosdl.NewVector(-7, 7))
-		box4 := h.createBox("box4", engosdl.NewVector(750, 350), engosdl.NewVector(-6, 6))
-		box8 := h.createBox("box8", engosdl.NewVector(750, 200), engosdl.NewVector(-5, 5))
-		box5 := h.createBox("box5", engosdl.NewVector(400, 50), engosdl.NewVector(-4, 4))
-		box6 := h.createBox("box6", engosdl.NewVector(400, 350), engosdl.NewVector(3, -3))
-		box9 := h.createBox("box9", engosdl.NewVector(400, 200), engosdl.NewVector(2, -2))
+		box1 := h.createBox("box1", engosdl.NewVector(50, 50), engosdl.NewVector(300, 300))
+		box2 := h.createBox("box2", engosdl.NewVector(50, 350), engosdl.NewVector(270, -270))
+		box7 := h.createBox("box7", engosdl.NewVector(50, 200), engosdl.NewVector(240, -240))
+		box3 := h.createBox("box3", engosdl.NewVector(750, 50), engosdl.NewVector(-210, 210))
+		box4 := h.createBox("box4", engosdl.NewVector(750, 350), engosdl.NewVector(-180, 180))
+		box8 := h.createBox("box8", engosdl.NewVector(750, 200), engosdl.NewVector(-150, 150))
+		box5 := h.createBox("box5", engosdl.NewVector(400, 50), engosdl.NewVector(-120, 120))
+		box6 := h.createBox("box6", engosdl.NewVector(400, 350), engosdl.NewVector(90, -90))
+		box9 := h.createBox("box9", engosdl.NewVector(400, 200), engosdl.NewVector(60, -60))
 
 		boxes := []engosdl.IEntity{box1, box2, box3, box4, box5, box6, box7, box8, box9}
 		counter := engosdl.NewEntity("counter")
-		counter.AddComponent(components.NewTimer("counter/timer", 100, -1))
+		counter.AddComponent(components.NewTimer("counter/timer", 3.3, -1))
 		counterDisplay := engosdl.NewComponent("counter/display")
 		counterDisplay.AddDelegateToRegister(nil, nil, &components.Timer{}, func(params ...interface{}) bool {
 			fmt.Println()
@@ -46,7 +46,7 @@ func (h *GameManager) createBullet() engosdl.IEntity {
 	bullet.SetLayer(engosdl.LayerBottom)
 	box := components.NewBox("bullet-box", &engosdl.Rect{W: 10, H: 10}, sdl.Color{R: 255}, true)
 	body := components.NewBody("bullet-body", true)
-	move := components.NewMoveTo("bullet-move", engosdl.NewVector(10, 0))
+	move := components.NewMoveTo("bullet-move", engosdl.NewVector(300, 0))
 	bullet.AddComponent(box)
 	bullet.AddComponent(body)
 	bullet.AddComponent(move)
@@ -81,7 +81,7 @@ func (h *GameManager) createCoin() engosdl.IEntity {
 	coin.SetDieOnOutOfBounds(true)
 	coin.AddComponent(components.NewBox("coin/box", &engosdl.Rect{W: 32, H: 32}, sdl.Color{B: 255}, true))
 	coin.AddComponent(components.NewBody("coin/body", true))
-	coin.AddComponent(components.NewMoveTo("coin/move-to", engosdl.NewVector(-1, 0)))
+	coin.AddComponent(components.NewMoveTo("coin/move-to", engosdl.NewVector(-30, 0)))
 	coin.GetComponent(&components.Box{}).AddDelegateToRegister(nil, nil, &components.Body{}, func(params ...interface{}) bool {
 		entity := params[0].(engosdl.IEntity)
 		if outAt := params[2].(int); outAt == engosdl.Left {
@@ -104,7 +104,7 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 
 		controller := engosdl.NewEntity("controller")
 		controller.AddComponent(components.NewSceneController("controller/scene-controller"))
-		timer := components.NewTimer("controller-timer", 500, 0)
+		timer := components.NewTimer("controller-timer", 16.7, 0)
 		controller.AddComponent(timer)
 		controller.GetComponent(&components.SceneController{}).AddDelegateToRegister(nil, nil, &components.Timer{}, func(params ...interface{}) bool {
 			engosdl.GetSceneManager().RestartScene()
@@ -180,7 +180,7 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 		playerSprite.AddDelegateToRegister(nil, nil, &components.OutOfBounds{}, playerSprite.DefaultOnOutOfBounds)
 		playerKeyboard := components.NewKeyboard("player-keyboard", components.KeyboardStandardMoveAndShoot)
 		playerKeyboard.DefaultAddDelegateToRegister()
-		playerMoveIt := components.NewMoveIt("player-move-it", engosdl.NewVector(5, 5))
+		playerMoveIt := components.NewMoveIt("player-move-it", engosdl.NewVector(150, 150))
 		playerMoveIt.DefaultAddDelegateToRegister()
 		playerMoveIt.AddDelegateToRegister(engosdl.GetDelegateManager().GetCollisionDelegate(), nil, nil, func(params ...interface{}) bool {
 			c := playerMoveIt
@@ -216,7 +216,7 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 		h.player.AddComponent(playerShooter)
 
 		waller := engosdl.NewEntity("waller")
-		waller.AddComponent(components.NewTimer("waller-timer", 100, -1))
+		waller.AddComponent(components.NewTimer("waller-timer", 3.3, -1))
 		wallerCaller := engosdl.NewComponent("waller-caller")
 		wallerCaller.AddDelegateToRegister(nil, nil, &components.Timer{}, func(params ...interface{}) bool {
 			scene.AddEntity(h.createWall())
@@ -225,7 +225,7 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 		waller.AddComponent(wallerCaller)
 
 		coiner := engosdl.NewEntity("coiner")
-		coiner.AddComponent(components.NewTimer("coiner-timer", 200, -1))
+		coiner.AddComponent(components.NewTimer("coiner-timer", 6.7, -1))
 		coinerCaller := engosdl.NewComponent("cointer-caller")
 		coinerCaller.AddDelegateToRegister(nil, nil, &components.Timer{}, func(params ...interface{}) bool {
 			scene.AddEntity(h.createCoin())
@@ -259,7 +259,7 @@ func (h *GameManager) createWall() engosdl.IEntity {
 	wall.SetDieOnOutOfBounds(true)
 	wall.AddComponent(components.NewBox("wall2/box", &engosdl.Rect{W: 64, H: 64}, sdl.Color{G: 255, A: 255}, true))
 	wall.AddComponent(components.NewCollider2D("wall2/collider-2d"))
-	wall.AddComponent(components.NewMoveTo("wall2/move-to", engosdl.NewVector(-2, 0)))
+	wall.AddComponent(components.NewMoveTo("wall2/move-to", engosdl.NewVector(-60, 0)))
 	wall.AddComponent(components.NewOutOfBounds("wall2/out-of-bounds", false))
 	wall.GetComponent(&components.Box{}).AddDelegateToRegister(nil, nil, &components.OutOfBounds{}, func(params ...interface{}) bool {
 		entity := params[0].(engosdl.IEntity)
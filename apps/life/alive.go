@@ -133,7 +133,7 @@ func (c *Alive) OnUpdate() {
 					scene.AddEntity(child)
 					board.(*Board).AddEntityAt(child, row, col)
 					pos, _ := board.(*Board).GetPositionFromCell(row, col)
-					child.GetTransform().SetPosition(pos)
+					child.GetTransform().SetWorldPosition(pos)
 					c.Row = row
 					c.Col = col
 					fmt.Printf("new pixel at: %d, %d\n", row, col)
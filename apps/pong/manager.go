@@ -38,7 +38,7 @@ func (h *GameManager) CreateAssets() {
 
 func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engosdl.IScene) bool {
 	return func(engine *engosdl.Engine, scene engosdl.IScene) bool {
-		speed := 4.0
+		speed := 120.0
 
 		net := engosdl.NewEntity("net")
 		net.GetTransform().SetPositionXY(395, 0)
@@ -60,21 +60,16 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 		box1 := components.NewBox("player1/box", &engosdl.Rect{W: 10, H: 40}, sdl.Color{B: 255}, true)
 		body1 := components.NewBody("player1/body", false)
 		keyboard1 := components.NewKeyboard("player1/keyboard", map[int]bool{sdl.SCANCODE_LEFT: true, sdl.SCANCODE_RIGHT: true})
-		moveIt1 := components.NewMoveIt("player1/move-it", engosdl.NewVector(0, 5))
+		moveIt1 := components.NewMoveIt("player1/move-it", engosdl.NewVector(0, 150))
 		moveIt1.AddDelegateToRegister(nil, nil, &components.Keyboard{}, func(params ...interface{}) bool {
 			c := moveIt1
-			position := c.GetEntity().GetTransform().GetPosition()
 			key := params[0].(int)
 			switch key {
 			case sdl.SCANCODE_LEFT:
-				position.Y -= c.Speed.Y
-				c.LastMove.Y = -1 * c.Speed.Y
-				c.LastMove.X = 0
+				c.MoveBy(0, -1*c.Speed.Y)
 				break
 			case sdl.SCANCODE_RIGHT:
-				position.Y += c.Speed.Y
-				c.LastMove.Y = c.Speed.Y
-				c.LastMove.X = 0
+				c.MoveBy(0, c.Speed.Y)
 				break
 			}
 			return true
@@ -90,21 +85,16 @@ func (h *GameManager) createScenePlay() func(engine *engosdl.Engine, scene engos
 		box2 := components.NewBox("player2/box", &engosdl.Rect{W: 10, H: 40}, sdl.Color{B: 255}, true)
 		body2 := components.NewBody("player2/body", false)
 		keyboard2 := components.NewKeyboard("player2/keyboard", map[int]bool{sdl.SCANCODE_A: true, sdl.SCANCODE_S: true})
-		moveIt2 := components.NewMoveIt("player2/move-it", engosdl.NewVector(0, 5))
+		moveIt2 := components.NewMoveIt("player2/move-it", engosdl.NewVector(0, 150))
 		moveIt2.AddDelegateToRegister(nil, nil, &components.Keyboard{}, func(params ...interface{}) bool {
 			c := moveIt2
-			position := c.GetEntity().GetTransform().GetPosition()
 			key := params[0].(int)
 			switch key {
 			case sdl.SCANCODE_A:
-				position.Y -= c.Speed.Y
-				c.LastMove.Y = -1 * c.Speed.Y
-				c.LastMove.X = 0
+				c.MoveBy(0, -1*c.Speed.Y)
 				break
 			case sdl.SCANCODE_S:
-				position.Y += c.Speed.Y
-				c.LastMove.Y = c.Speed.Y
-				c.LastMove.X = 0
+				c.MoveBy(0, c.Speed.Y)
 				break
 			}
 			return true
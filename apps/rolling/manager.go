@@ -64,20 +64,22 @@ func (h *GameManager) createScenePlay() func(*engosdl.Engine, engosdl.IScene) bo
 		consoleText := components.NewText("console/text", "fonts/fira2.ttf", 12, sdl.Color{}, " ")
 		console.AddComponent(consoleText)
 
+		// Buttons are placed in screen coordinates, so they do not follow
+		// the player transform.
 		lookButton := player.GetChildByName("look")
+		lookButton.GetTransform().SetParent(nil)
 		lookButton.GetTransform().SetPositionXY(10, 50)
 		lookButton.AddComponent(components.NewButton("loo/button", "fonts/fira.ttf", 32, sdl.Color{B: 255}, "LOOK", &engosdl.Rect{}, sdl.Color{B: 255}, false))
-		player.AddChild(lookButton)
 
 		moveButton := player.GetChildByName("move")
+		moveButton.GetTransform().SetParent(nil)
 		moveButton.GetTransform().SetPositionXY(100, 50)
 		moveButton.AddComponent(components.NewButton("loo/button", "fonts/fira.ttf", 32, sdl.Color{B: 255}, "MOVE", &engosdl.Rect{}, sdl.Color{B: 255}, false))
-		player.AddChild(moveButton)
 
 		attackButton := player.GetChildByName("attack")
+		attackButton.GetTransform().SetParent(nil)
 		attackButton.GetTransform().SetPositionXY(210, 50)
 		attackButton.AddComponent(components.NewButton("attack/button", "fonts/fira.ttf", 32, sdl.Color{B: 255}, "ATTACK", &engosdl.Rect{}, sdl.Color{B: 255}, false))
-		player.AddChild(attackButton)
 
 		sceneController.GetComponent(&SceneController{}).(*SceneController).SetupResources()
 
@@ -63,7 +63,7 @@ func (c *SceneController) addDelegateToRegisterToButton(name string) {
 	component := c.Player.GetChildByName(name).GetComponent(&components.Button{})
 	component.AddDelegateToRegister(nil, c.Player, &components.Mouse{}, func(params ...interface{}) bool {
 		mousePos := engosdl.NewVector(float64(params[0].(int32)), float64(params[1].(int32)))
-		if component.GetEntity().IsInside(mousePos) {
+		if component.GetEntity().IsInsideScreen(mousePos) {
 			if component.GetEnabled() {
 				if output, err := c.Board.GetComponent(&Board{}).(*Board).ExecuteAtPlayerPos(name); err == nil {
 					if obj, error := c.Console.GetCache("message"); error == nil {
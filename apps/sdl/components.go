@@ -85,13 +85,13 @@ func (c *EnemyController) OnStart() {
 type enemySpriteT struct {
 	*components.Sprite
 	onCollisionF func(engosdl.ISprite) engosdl.TDelegateSignature
-	hit          bool
+	animator     engosdl.IAnimator
 }
 
 func newEnemySprite(name string, filenames []string, numberOfSprites int) *enemySpriteT {
 	result := &enemySpriteT{
-		Sprite: components.NewSprite(name, filenames, numberOfSprites, engosdl.FormatBMP),
-		hit:    false,
+		Sprite:   components.NewSprite(name, filenames, numberOfSprites, engosdl.FormatBMP),
+		animator: nil,
 	}
 	// result.AddDelegateToRegister(engosdl.GetDelegateManager().GetCollisionDelegate(), nil, nil, result.onCollision)
 	return result
@@ -112,17 +112,9 @@ func (c *enemySpriteT) DefaultOnCollision(params ...interface{}) bool {
 	collisionEntityTwo := params[1].(*engosdl.Entity)
 	if (collisionEntityOne.GetTag() == "bullet" || collisionEntityTwo.GetTag() == "bullet") &&
 		(collisionEntityOne.GetID() == c.GetEntity().GetID() || collisionEntityTwo.GetID() == c.GetEntity().GetID()) {
-		c.hit = true
-	}
-	return true
-}
-
-// OnUpdate is called for every update tick.
-func (c *enemySpriteT) OnUpdate() {
-	if c.hit {
-		c.NextSprite()
-		if c.GetSpriteIndex() == 0 {
-			c.hit = false
+		if c.animator != nil {
+			c.animator.Play("hit")
 		}
 	}
+	return true
 }
@@ -42,7 +42,7 @@ func createEnemy(engine *engosdl.Engine, index int, position *engosdl.Vector, en
 
 	enemyOutOfBounds := components.NewOutOfBounds("enemy-out-of-bounds", true)
 	enemyOutOfBounds.DefaultAddDelegateToRegister()
-	enemyMove := components.NewMoveTo("enemy-move", engosdl.NewVector(5, 0))
+	enemyMove := components.NewMoveTo("enemy-move", engosdl.NewVector(150, 0))
 	// enemySprite := components.NewSprite("enemy-sprite", "images/basic_enemy.bmp", engine.GetRenderer())
 	// enemySprite := components.NewMultiSprite("enemy-sprite", []string{"images/basic_enemy.bmp"}, engine.GetRenderer())
 	// enemySprite := components.NewSpriteSheet("enemy-sprite", []string{"images/enemies.bmp"}, 3, engine.GetRenderer())
@@ -54,17 +54,26 @@ func createEnemy(engine *engosdl.Engine, index int, position *engosdl.Vector, en
 		enemySprite.GetEntity().GetTransform().SetPosition(engosdl.NewVector(x, y+10))
 		return true
 	})
+	enemyAnimator := components.NewAnimator("enemy-animator", []*engosdl.AnimationClip{
+		engosdl.NewAnimationClip("hit", engosdl.AnimationOnce,
+			engosdl.NewAnimationFrame(1, 1.0/30),
+			engosdl.NewAnimationFrame(2, 1.0/30),
+			engosdl.NewAnimationFrame(0, 1.0/30)),
+	}, "")
+	enemyAnimator.SetSprite(enemySprite)
+	enemySprite.animator = enemyAnimator
 	enemyStats := components.NewEntityStats("enemy-stats", 50)
 	enemyStats.DefaultAddDelegateToRegister()
 	enemyCollider := components.NewCollider2D("enemy-collider-2D")
 	enemyCollider.DefaultAddDelegateToRegister()
-	enemyTimer := components.NewTimer("enemy-timer", 100, -1)
-	enemyShotBullet := components.NewShootBullet("enemy-bullet", engosdl.NewVector(0, 5))
+	enemyTimer := components.NewTimer("enemy-timer", 3.3, -1)
+	enemyShotBullet := components.NewShootBullet("enemy-bullet", engosdl.NewVector(0, 150))
 	enemyShotBullet.AddDelegateToRegister(nil, nil, &components.Timer{}, enemyShotBullet.ShootBulletSignature)
 
 	enemy.AddComponent(enemyMove)
 	enemy.AddComponent(enemyOutOfBounds)
 	enemy.AddComponent(enemySprite)
+	enemy.AddComponent(enemyAnimator)
 	enemy.AddComponent(enemyCollider)
 	enemy.AddComponent(enemyStats)
 	enemy.AddComponent(enemyTimer)
@@ -14,7 +14,7 @@ func createEntityBackground(engine *engosdl.Engine, filename string) engosdl.IEn
 	bgSprite.DefaultAddDelegateToRegister()
 	bgSprite.SetScroll(engosdl.NewVector(0, -1))
 	// bgSprite.SetCamera(&engosdl.Rect{X: 0, Y: 0, W: 400, H: 800})
-	bgMoveTo := components.NewMoveTo("bg-move", engosdl.NewVector(0, -5))
+	bgMoveTo := components.NewMoveTo("bg-move", engosdl.NewVector(0, -150))
 	bgMoveTo.DefaultAddDelegateToRegister()
 	bg.AddComponent(bgSprite)
 	bg.AddComponent(bgMoveTo)
@@ -64,11 +64,11 @@ func (h *GameManager) createEntityPlayer() engosdl.IEntity {
 	playerKeyboard.DefaultAddDelegateToRegister()
 	playerKeyShooter := components.NewKeyShooter("player-key-shooter", sdl.SCANCODE_SPACE)
 	playerKeyShooter.DefaultAddDelegateToRegister()
-	playerShootBullet := components.NewShootBullet("player-shoot-bullet", engosdl.NewVector(0, -5))
+	playerShootBullet := components.NewShootBullet("player-shoot-bullet", engosdl.NewVector(0, -150))
 	playerShootBullet.DefaultAddDelegateToRegister()
 	playerOutOfBounds := components.NewOutOfBounds("player-out-of-bounds", true)
 	playerOutOfBounds.DefaultAddDelegateToRegister()
-	playerMoveIt := components.NewMoveIt("player-move-it", engosdl.NewVector(5, 0))
+	playerMoveIt := components.NewMoveIt("player-move-it", engosdl.NewVector(150, 0))
 	playerMoveIt.DefaultAddDelegateToRegister()
 	playerCollider := components.NewCollider2D("player-collider")
 	if obj := h.player.GetComponent(&components.EntityStats{}); obj != nil {
@@ -36,7 +36,7 @@ func createSceneTitle(engine *engosdl.Engine, scene engosdl.IScene) bool {
 	titleKeyboard.DefaultAddDelegateToRegister()
 	titleOutOfBounds := components.NewOutOfBounds("title-out-of-bounds", true)
 	titleOutOfBounds.DefaultAddDelegateToRegister()
-	titleMoveIt := components.NewMoveIt("title-move-it", engosdl.NewVector(5, 0))
+	titleMoveIt := components.NewMoveIt("title-move-it", engosdl.NewVector(150, 0))
 	titleMoveIt.DefaultAddDelegateToRegister()
 	titleMouse := components.NewMouse("title-mouse", true)
 
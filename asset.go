@@ -0,0 +1,96 @@
+package engosdl
+
+import "fmt"
+
+// Asset kind constants.
+const (
+	// AssetImage identifies image assets loaded as resources.
+	AssetImage int = 1
+	// AssetFont identifies font assets.
+	AssetFont int = 2
+	// AssetSound identifies sound assets.
+	AssetSound int = 3
+)
+
+// Asset represents any file a scene requires, so it can be loaded before the
+// scene is set as active. Format is the image format or the sound format,
+// and font size is only used by fonts.
+type Asset struct {
+	Name     string `json:"name"`
+	Filename string `json:"filename"`
+	Kind     int    `json:"kind"`
+	Format   int    `json:"format"`
+	FontSize int    `json:"font-size"`
+}
+
+// NewImageAsset creates a new image asset. Format is taken from the file
+// extension.
+func NewImageAsset(name string, filename string) *Asset {
+	return &Asset{
+		Name:     name,
+		Filename: filename,
+		Kind:     AssetImage,
+		Format:   GetFormatFromFilename(filename),
+	}
+}
+
+// NewFontAsset creates a new font asset with the given font size.
+func NewFontAsset(name string, filename string, fontSize int) *Asset {
+	return &Asset{
+		Name:     name,
+		Filename: filename,
+		Kind:     AssetFont,
+		FontSize: fontSize,
+	}
+}
+
+// NewSoundAsset creates a new sound asset with the given sound format.
+func NewSoundAsset(name string, filename string, format int) *Asset {
+	return &Asset{
+		Name:     name,
+		Filename: filename,
+		Kind:     AssetSound,
+		Format:   format,
+	}
+}
+
+// isLoaded returns if the asset has already been created in its manager.
+func (asset *Asset) isLoaded() bool {
+	switch asset.Kind {
+	case AssetImage:
+		return GetResourceManager().GetResourceByFilename(asset.Filename) != nil
+	case AssetFont:
+		return GetFontManager().GetFontByFilenameAndSize(asset.Filename, asset.FontSize) != nil
+	case AssetSound:
+		return GetSoundManager().GetSoundByFilename(asset.Filename) != nil
+	}
+	return false
+}
+
+// key returns the key identifying the asset file. Fonts with different font
+// sizes are different assets for the same file.
+func (asset *Asset) key() string {
+	if asset.Kind == AssetFont {
+		return fmt.Sprintf("%s:%d", asset.Filename, asset.FontSize)
+	}
+	return asset.Filename
+}
+
+// load reads and decodes the asset file. It does not create any engine
+// object, so it can be called from any goroutine. Fonts are not opened,
+// because SDL_ttf is not thread-safe, so they are opened in the main thread
+// when they are created.
+func (asset *Asset) load() *assetData {
+	data := &assetData{asset: asset}
+	switch asset.Kind {
+	case AssetImage:
+		data.surface, data.err = loadSurface(asset.Filename, asset.Format)
+	case AssetFont:
+		// Font is opened when it is created in the main thread.
+	case AssetSound:
+		data.sound, data.chunk, data.err = loadSound(asset.Filename, asset.Format)
+	default:
+		data.err = fmt.Errorf("unknown asset kind %d", asset.Kind)
+	}
+	return data
+}
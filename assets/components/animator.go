@@ -0,0 +1,292 @@
+package components
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jrecuero/engosdl"
+)
+
+// ComponentNameAnimator is the name to refer animator component.
+var ComponentNameAnimator string = reflect.TypeOf(&Animator{}).String()
+
+func init() {
+	if componentManager := engosdl.GetComponentManager(); componentManager != nil {
+		componentManager.RegisterConstructor(ComponentNameAnimator, CreateAnimator)
+	}
+}
+
+// Animator represents a component that plays animation clips in a sprite.
+// It uses the first sprite in the entity if no sprite is set. Default clip
+// is played when the component starts. Speed multiplies frame durations, so
+// speed 2 plays clips twice as fast and speed 0 freezes them. Animator
+// delegate is triggered with clip name, frame index and event name for every
+// frame with an event, and with AnimationEventFinished when a clip played
+// once ends.
+type Animator struct {
+	*engosdl.Component
+	Clips     []*engosdl.AnimationClip `json:"clips"`
+	Default   string                   `json:"default"`
+	Speed     float64                  `json:"speed"`
+	sprite    engosdl.ISprite
+	clip      *engosdl.AnimationClip
+	frame     int
+	direction int
+	elapsed   float64
+	playing   bool
+}
+
+var _ engosdl.IAnimator = (*Animator)(nil)
+
+// NewAnimator creates a new animator instance.
+func NewAnimator(name string, clips []*engosdl.AnimationClip, defaultClip string) *Animator {
+	engosdl.Logger.Trace().Str("component", "animator").Str("animator", name).Msg("new animator")
+	return &Animator{
+		Component: engosdl.NewComponent(name),
+		Clips:     clips,
+		Default:   defaultClip,
+		Speed:     1,
+		sprite:    nil,
+		clip:      nil,
+		frame:     0,
+		direction: 1,
+		elapsed:   0,
+		playing:   false,
+	}
+}
+
+// CreateAnimator implements animator constructor used by component manager.
+func CreateAnimator(params ...interface{}) engosdl.IComponent {
+	if len(params) == 3 {
+		return NewAnimator(params[0].(string), params[1].([]*engosdl.AnimationClip), params[2].(string))
+	}
+	return NewAnimator("", []*engosdl.AnimationClip{}, "")
+}
+
+// AddClip adds a new clip to the animator. It replaces any clip with the same
+// name.
+func (c *Animator) AddClip(clip *engosdl.AnimationClip) engosdl.IAnimator {
+	for i, traverse := range c.Clips {
+		if traverse.Name == clip.Name {
+			c.Clips[i] = clip
+			return c
+		}
+	}
+	c.Clips = append(c.Clips, clip)
+	return c
+}
+
+// advance moves to the next frame in the clip based on the clip mode.
+func (c *Animator) advance() {
+	total := len(c.clip.Frames)
+	next := c.frame + c.direction
+	switch c.clip.Mode {
+	case engosdl.AnimationPingPong:
+		if next < 0 || next >= total {
+			c.direction = -c.direction
+			next = c.frame + c.direction
+			if next < 0 || next >= total {
+				next = c.frame
+			}
+		}
+	case engosdl.AnimationOnce:
+		if next >= total {
+			c.playing = false
+			c.elapsed = 0
+			c.triggerEvent(engosdl.AnimationEventFinished)
+			return
+		}
+	default:
+		next = next % total
+	}
+	c.setFrame(next)
+}
+
+// GetClip returns the clip with the given name. It returns nil if there is
+// not any clip with that name.
+func (c *Animator) GetClip(name string) *engosdl.AnimationClip {
+	for _, clip := range c.Clips {
+		if clip.Name == name {
+			return clip
+		}
+	}
+	return nil
+}
+
+// GetClipName returns the name of the clip being played. It returns an empty
+// string if no clip has been played.
+func (c *Animator) GetClipName() string {
+	if c.clip == nil {
+		return ""
+	}
+	return c.clip.Name
+}
+
+// GetFrameIndex returns the index in the clip for the frame being displayed.
+func (c *Animator) GetFrameIndex() int {
+	return c.frame
+}
+
+// GetSpeed returns the animator playback speed.
+func (c *Animator) GetSpeed() float64 {
+	return c.Speed
+}
+
+// GetSprite returns the sprite animated.
+func (c *Animator) GetSprite() engosdl.ISprite {
+	return c.sprite
+}
+
+// IsPlaying returns if a clip is being played.
+func (c *Animator) IsPlaying() bool {
+	return c.playing
+}
+
+// OnAwake is called the first time the component is loaded in the scene,
+// it creates the animator delegate.
+func (c *Animator) OnAwake() {
+	engosdl.Logger.Trace().Str("component", "animator").Str("animator", c.GetName()).Msg("OnAwake")
+	name := fmt.Sprintf("on-animation/%s", c.GetName())
+	c.SetDelegate(engosdl.GetDelegateManager().CreateDelegate(c, name))
+	c.Component.OnAwake()
+}
+
+// OnStart is called first time the component is enabled. It looks for the
+// sprite to animate and it plays the default clip.
+func (c *Animator) OnStart() {
+	engosdl.Logger.Trace().Str("component", "animator").Str("animator", c.GetName()).Msg("OnStart")
+	if c.sprite == nil {
+		for _, component := range c.GetEntity().GetComponents() {
+			if sprite, ok := component.(engosdl.ISprite); ok {
+				c.sprite = sprite
+				break
+			}
+		}
+	}
+	if c.Default != "" && c.clip == nil {
+		if err := c.Play(c.Default); err != nil {
+			engosdl.Logger.Error().Err(err).Str("animator", c.GetName()).Msg("play default clip error")
+		}
+	}
+	c.Component.OnStart()
+}
+
+// OnUpdate is called for every update tick. It moves to the next frame when
+// the frame duration has elapsed.
+func (c *Animator) OnUpdate() {
+	if !c.playing || c.clip == nil || len(c.clip.Frames) == 0 {
+		return
+	}
+	c.elapsed += engosdl.GetDeltaTime() * c.Speed
+	for c.playing {
+		duration := c.clip.Frames[c.frame].Duration
+		if duration <= 0 {
+			c.elapsed = 0
+			c.advance()
+			break
+		}
+		if c.elapsed < duration {
+			break
+		}
+		c.elapsed -= duration
+		c.advance()
+	}
+}
+
+// Pause stops the clip being played at the current frame.
+func (c *Animator) Pause() {
+	c.playing = false
+}
+
+// Play starts playing the clip with the given name from the first frame.
+func (c *Animator) Play(name string) error {
+	clip := c.GetClip(name)
+	if clip == nil {
+		return fmt.Errorf("clip %s not found", name)
+	}
+	if len(clip.Frames) == 0 {
+		return fmt.Errorf("clip %s does not have frames", name)
+	}
+	c.clip = clip
+	c.direction = 1
+	c.elapsed = 0
+	c.playing = true
+	c.setFrame(0)
+	return nil
+}
+
+// Resume continues playing the clip from the current frame.
+func (c *Animator) Resume() {
+	if c.clip != nil {
+		c.playing = true
+	}
+}
+
+// setFrame displays the given clip frame in the sprite and it triggers the
+// frame event.
+func (c *Animator) setFrame(index int) {
+	c.showFrame(index)
+	if event := c.clip.Frames[index].Event; event != "" {
+		c.triggerEvent(event)
+	}
+}
+
+// SetSpeed sets the animator playback speed. Negative speed is not allowed.
+func (c *Animator) SetSpeed(speed float64) {
+	if speed < 0 {
+		speed = 0
+	}
+	c.Speed = speed
+}
+
+// SetSprite sets the sprite to animate.
+func (c *Animator) SetSprite(sprite engosdl.ISprite) {
+	c.sprite = sprite
+}
+
+// showFrame displays the given clip frame in the sprite.
+func (c *Animator) showFrame(index int) {
+	c.frame = index
+	frame := c.clip.Frames[index]
+	if c.sprite != nil {
+		if frame.Region != "" {
+			if err := c.sprite.SetRegion(frame.Region); err != nil {
+				engosdl.Logger.Error().Err(err).Str("animator", c.GetName()).Msg("SetRegion error")
+			}
+		} else if frame.Rect != nil {
+			c.sprite.SetCamera(frame.Rect)
+		} else {
+			c.sprite.SetCamera(nil)
+			c.sprite.SetSpriteIndex(frame.Index)
+		}
+	}
+}
+
+// Stop stops the clip being played and it moves back to the first frame.
+func (c *Animator) Stop() {
+	c.playing = false
+	c.elapsed = 0
+	c.direction = 1
+	if c.clip != nil {
+		c.showFrame(0)
+	}
+}
+
+// triggerEvent triggers the animator delegate for the given event.
+func (c *Animator) triggerEvent(event string) {
+	if c.GetDelegate() != nil {
+		engosdl.GetDelegateManager().TriggerDelegate(c.GetDelegate(), false, c.clip.Name, c.frame, event)
+	}
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *Animator) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	c.Clips = []*engosdl.AnimationClip{}
+	for _, clip := range data["clips"].([]interface{}) {
+		c.Clips = append(c.Clips, engosdl.UnmarshalAnimationClip(clip.(map[string]interface{})))
+	}
+	c.Default = data["default"].(string)
+	c.Speed = data["speed"].(float64)
+}
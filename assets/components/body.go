@@ -25,6 +25,8 @@ func init() {
 // Body represents a component for any physical body component.
 type Body struct {
 	*engosdl.Component
+	engosdl.CollisionFilter
+	Shape         *engosdl.ColliderShape `json:"shape"`
 	collisionBox  *CollisionBox
 	AllBodyForOOB bool `json:"all-body-for-oob"`
 }
@@ -34,9 +36,10 @@ type Body struct {
 func NewBody(name string, origin bool) *Body {
 	engosdl.Logger.Trace().Str("component", "body").Str("body", name).Msg("new body")
 	return &Body{
-		Component:     engosdl.NewComponent(name),
-		AllBodyForOOB: origin,
-		collisionBox:  &CollisionBox{},
+		Component:       engosdl.NewComponent(name),
+		CollisionFilter: engosdl.NewCollisionFilter(),
+		AllBodyForOOB:   origin,
+		collisionBox:    &CollisionBox{},
 	}
 }
 
@@ -59,6 +62,16 @@ func (c *Body) GetCollisionBox() engosdl.ICollisionBox {
 	return c.collisionBox
 }
 
+// GetCollisionShape returns the collision shape in world coordinates.
+func (c *Body) GetCollisionShape() engosdl.ICollisionShape {
+	return engosdl.NewCollisionShape(c.Shape, c.GetEntity())
+}
+
+// GetShape returns the collider shape definition.
+func (c *Body) GetShape() *engosdl.ColliderShape {
+	return c.Shape
+}
+
 // OnAwake should create all component resources that don't have any dependency
 // with any other component or entity.
 // It creates delegate "body"
@@ -99,9 +112,20 @@ func (c *Body) OnUpdate() {
 	}
 }
 
+// SetShape sets the collider shape definition. Scene queries are refreshed
+// if entity is already in a scene.
+func (c *Body) SetShape(shape *engosdl.ColliderShape) {
+	c.Shape = shape
+	if entity := c.GetEntity(); entity != nil && entity.GetScene() != nil {
+		entity.GetScene().RefreshQueries(entity)
+	}
+}
+
 // Unmarshal takes a ComponentToMarshal instance and  creates a new entity
 // instance.
 func (c *Body) Unmarshal(data map[string]interface{}) {
 	c.Component.Unmarshal(data)
 	c.AllBodyForOOB = data["all-body-for-oob"].(bool)
+	c.UnmarshalFilter(data)
+	c.Shape = engosdl.UnmarshalShape(data)
 }
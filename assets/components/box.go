@@ -19,22 +19,25 @@ func init() {
 // Box represents a component that display a rectangle.
 type Box struct {
 	*engosdl.Component
-	renderer *sdl.Renderer
-	Border   *engosdl.Rect
-	Color    sdl.Color
-	filled   bool
+	renderer engosdl.IRenderer
+	Border   *engosdl.Rect `json:"border"`
+	Color    sdl.Color     `json:"color"`
+	Filled   bool          `json:"filled"`
 }
 
 // NewBox create a new box instance.
+// It depends on optional "out-of-bounds" component.
 func NewBox(name string, box *engosdl.Rect, color sdl.Color, filled bool) *Box {
 	engosdl.Logger.Trace().Str("component", "box").Str("box", name).Msg("new box")
-	return &Box{
+	result := &Box{
 		Component: engosdl.NewComponent(name),
 		renderer:  engosdl.GetRenderer(),
 		Border:    box,
 		Color:     color,
-		filled:    filled,
+		Filled:    filled,
 	}
+	result.AddDependency(&OutOfBounds{}, false)
+	return result
 }
 
 // CreateBox implements box constructor used by component manager.
@@ -81,6 +84,11 @@ func (c *Box) DefaultOnOutOfBounds(params ...interface{}) bool {
 	return true
 }
 
+// GetColor returns box color.
+func (c *Box) GetColor() sdl.Color {
+	return c.Color
+}
+
 // OnAwake should create all component resources that don't have any dependency
 // with any other component or entity.
 func (c *Box) OnAwake() {
@@ -89,14 +97,39 @@ func (c *Box) OnAwake() {
 	c.Component.OnAwake()
 }
 
-// OnRender is called every engine frame in order to render component.
+// OnRender is called every engine frame in order to render component. Box is
+// displayed through the scene camera.
 func (c *Box) OnRender() {
-	x, y, w, h := c.GetEntity().GetTransform().GetRectExt()
+	camera := c.GetEntity().GetScene().GetCamera()
+	rect := camera.RectToScreen(c.GetEntity().GetTransform().GetRenderRect())
 	c.renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
 	c.renderer.SetDrawColor(c.Color.R, c.Color.G, c.Color.B, c.Color.A)
-	if c.filled {
-		c.renderer.FillRect(&sdl.Rect{X: int32(x), Y: int32(y), W: int32(w), H: int32(h)})
+	if c.Filled {
+		c.renderer.FillRect(rect)
 	} else {
-		c.renderer.DrawRect(&sdl.Rect{X: int32(x), Y: int32(y), W: int32(w), H: int32(h)})
+		c.renderer.DrawRect(rect)
+	}
+}
+
+// SetColor sets box color.
+func (c *Box) SetColor(color sdl.Color) {
+	c.Color = color
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *Box) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	if border, ok := data["border"].(map[string]interface{}); ok {
+		c.Border = engosdl.NewRect(border["X"].(float64), border["Y"].(float64), border["W"].(float64), border["H"].(float64))
+	}
+	if color, ok := data["color"].(map[string]interface{}); ok {
+		c.Color = sdl.Color{R: uint8(color["R"].(float64)),
+			G: uint8(color["G"].(float64)),
+			B: uint8(color["B"].(float64)),
+			A: uint8(color["A"].(float64))}
+	}
+	if filled, ok := data["filled"].(bool); ok {
+		c.Filled = filled
 	}
 }
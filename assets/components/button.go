@@ -24,8 +24,8 @@ type Button struct {
 	FontSize    int       `json:"font-size"`
 	Color       sdl.Color `json:"color"`
 	Message     string    `json:"message"`
-	renderer    *sdl.Renderer
-	texture     *sdl.Texture
+	renderer    engosdl.IRenderer
+	texture     engosdl.ITexture
 	width       int32
 	height      int32
 	border      *engosdl.Rect
@@ -61,28 +61,63 @@ func CreateButton(params ...interface{}) engosdl.IComponent {
 	return NewButton("", "", 0, sdl.Color{}, "", &engosdl.Rect{}, sdl.Color{}, false)
 }
 
+// DoDestroy calls all methods to clean up button. Texture is destroyed and font
+// is released.
+func (c *Button) DoDestroy() {
+	engosdl.Logger.Trace().Str("component", "button").Str("button", c.GetName()).Msg("DoDestroy")
+	if c.texture != nil {
+		c.texture.Destroy()
+		c.texture = nil
+	}
+	c.releaseFont()
+	c.Component.DoDestroy()
+}
+
+// GetColor returns text color.
+func (c *Button) GetColor() sdl.Color {
+	return c.Color
+}
+
 // loadTextureFromTTF creates a texture from a ttf file.
 func (c *Button) loadTextureFromTTF() {
 	var err error
-	c.font = engosdl.GetFontManager().CreateFont(c.GetName(), c.FontFile, c.FontSize)
-	c.texture = c.font.GetTextureFromFont(c.Message, c.Color)
-	_, _, c.width, c.height, err = c.texture.Query()
-	if err != nil {
-		engosdl.Logger.Error().Err(err).Msg("Query error")
-		panic(err)
+	if c.texture != nil {
+		// Texture is created again every time text or color changes.
+		c.texture.Destroy()
+		c.texture = nil
+	}
+	if c.font == nil || c.font.GetFilename() != c.FontFile || c.font.GetFontSize() != c.FontSize {
+		// Font is reused while font file and size do not change, so there is
+		// not a new font reference every time text or color changes.
+		c.releaseFont()
+		if c.font = engosdl.GetFontManager().CreateFont(c.GetName(), c.FontFile, c.FontSize); c.font == nil {
+			return
+		}
+	}
+	if c.texture = c.font.GetTextureFromFont(c.Message, c.Color); c.texture == nil {
+		return
+	}
+	if _, _, c.width, c.height, err = c.texture.Query(); err != nil {
+		engosdl.ReportError(err)
+		c.texture.Destroy()
+		c.texture = nil
+		return
 	}
 	c.GetEntity().GetTransform().SetDim(engosdl.NewVector(float64(c.width), float64(c.height)))
 }
 
-// OnRender is called for every render tick.
+// OnRender is called for every render tick. Button is displayed through the
+// scene camera.
 func (c *Button) OnRender() {
-	x, y, w, h := c.GetEntity().GetTransform().GetRectExt()
+	transform := c.GetEntity().GetTransform()
+	camera := c.GetEntity().GetScene().GetCamera()
+	rect := camera.RectToScreen(transform.GetRenderRect())
 	c.renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
 	c.renderer.SetDrawColor(c.borderColor.R, c.borderColor.G, c.borderColor.B, c.borderColor.A)
 	if c.filled {
-		c.renderer.FillRect(&sdl.Rect{X: int32(x), Y: int32(y), W: int32(w), H: int32(h)})
+		c.renderer.FillRect(rect)
 	} else {
-		c.renderer.DrawRect(&sdl.Rect{X: int32(x), Y: int32(y), W: int32(w), H: int32(h)})
+		c.renderer.DrawRect(rect)
 	}
 
 	if c.GetDirty() {
@@ -95,11 +130,15 @@ func (c *Button) OnRender() {
 		c.SetColor(color)
 		c.SetDirty(false)
 	}
+	if c.texture == nil {
+		// Texture could not be created.
+		return
+	}
 	c.renderer.CopyEx(c.texture,
 		&sdl.Rect{X: 0, Y: 0, W: c.width, H: c.height},
-		&sdl.Rect{X: int32(x), Y: int32(y), W: int32(w), H: int32(h)},
-		0,
-		&sdl.Point{},
+		rect,
+		transform.GetWorldRotation()-camera.GetRotation(),
+		nil,
 		sdl.FLIP_NONE)
 }
 
@@ -114,7 +153,7 @@ func (c *Button) OnStart() {
 func (c *Button) OnUpdate() {
 	entity := c.GetEntity()
 	x, y, _ := sdl.GetMouseState()
-	if entity.IsInside(engosdl.NewVector(float64(x), float64(y))) {
+	if entity.IsInsideScreen(engosdl.NewVector(float64(x), float64(y))) {
 		// cursor := sdl.CreateSystemCursor(sdl.SYSTEM_CURSOR_HAND)
 		// sdl.SetCursor(cursor)
 		engosdl.GetCursorManager().CursorUpdate(c.GetEntity(), sdl.SYSTEM_CURSOR_HAND)
@@ -128,6 +167,14 @@ func (c *Button) OnUpdate() {
 	// c.Component.OnUpdate()
 }
 
+// releaseFont releases the font used by the button.
+func (c *Button) releaseFont() {
+	if c.font != nil {
+		engosdl.GetFontManager().DeleteFont(c.font)
+		c.font = nil
+	}
+}
+
 // SetColor sets text color.
 func (c *Button) SetColor(color sdl.Color) engosdl.IButton {
 	c.Color = color
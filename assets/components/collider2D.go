@@ -41,14 +41,17 @@ func (c *CollisionBox) GetRadius() float64 {
 // Collider2D represents a component that check for 2D collisions.
 type Collider2D struct {
 	*engosdl.Component
+	engosdl.CollisionFilter
+	Shape        *engosdl.ColliderShape `json:"shape"`
 	collisionBox *CollisionBox
 }
 
 // NewCollider2D create a new collider-2D instance.
 func NewCollider2D(name string) *Collider2D {
 	return &Collider2D{
-		Component:    engosdl.NewComponent(name),
-		collisionBox: &CollisionBox{},
+		Component:       engosdl.NewComponent(name),
+		CollisionFilter: engosdl.NewCollisionFilter(),
+		collisionBox:    &CollisionBox{},
 	}
 }
 
@@ -71,6 +74,33 @@ func (c *Collider2D) GetCollisionBox() engosdl.ICollisionBox {
 	return c.collisionBox
 }
 
+// GetCollisionShape returns the collision shape in world coordinates.
+func (c *Collider2D) GetCollisionShape() engosdl.ICollisionShape {
+	return engosdl.NewCollisionShape(c.Shape, c.GetEntity())
+}
+
+// GetShape returns the collider shape definition.
+func (c *Collider2D) GetShape() *engosdl.ColliderShape {
+	return c.Shape
+}
+
 // OnUpdate is called for every update tick.
 func (c *Collider2D) OnUpdate() {
 }
+
+// SetShape sets the collider shape definition. Scene queries are refreshed
+// if entity is already in a scene.
+func (c *Collider2D) SetShape(shape *engosdl.ColliderShape) {
+	c.Shape = shape
+	if entity := c.GetEntity(); entity != nil && entity.GetScene() != nil {
+		entity.GetScene().RefreshQueries(entity)
+	}
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *Collider2D) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	c.UnmarshalFilter(data)
+	c.Shape = engosdl.UnmarshalShape(data)
+}
@@ -19,7 +19,7 @@ func init() {
 // Line represents a component.
 type Line struct {
 	*engosdl.Component
-	renderer *sdl.Renderer
+	renderer engosdl.IRenderer
 	endPoint *engosdl.Vector
 	color    sdl.Color
 }
@@ -31,6 +31,7 @@ func NewLine(name string, endPoint *engosdl.Vector, color sdl.Color) *Line {
 	engosdl.Logger.Trace().Str("component", "Line").Str("Line", name).Msg("new Line")
 	return &Line{
 		Component: engosdl.NewComponent(name),
+		renderer:  engosdl.GetRenderer(),
 		endPoint:  endPoint,
 		color:     color,
 	}
@@ -75,13 +76,18 @@ func (c *Line) OnAwake() {
 }
 
 // OnRender is called every engine frame when component has to be rendered.
+// Line is displayed through the scene camera.
 func (c *Line) OnRender() {
-	x, y, w, h := c.GetEntity().GetTransform().GetRectExt()
+	rect := c.GetEntity().GetTransform().GetRenderRect()
+	x, y, w, h := rect.X, rect.Y, rect.W, rect.H
+	camera := c.GetEntity().GetScene().GetCamera()
+	from := camera.WorldToScreen(engosdl.NewVector(x, y))
+	to := camera.WorldToScreen(engosdl.NewVector(w, h))
 	c.renderer.SetDrawColor(c.color.R, c.color.G, c.color.B, c.color.A)
-	c.renderer.DrawLine(int32(x), int32(y), int32(w), int32(h))
-	c.renderer.DrawLine(int32(x), int32(y), int32(w+1), int32(h+1))
-	c.renderer.DrawLine(int32(x), int32(y), int32(w+2), int32(h+2))
-	c.renderer.DrawLine(int32(x), int32(y), int32(w+3), int32(h+3))
+	c.renderer.DrawLine(int32(from.X), int32(from.Y), int32(to.X), int32(to.Y))
+	c.renderer.DrawLine(int32(from.X), int32(from.Y), int32(to.X+1), int32(to.Y+1))
+	c.renderer.DrawLine(int32(from.X), int32(from.Y), int32(to.X+2), int32(to.Y+2))
+	c.renderer.DrawLine(int32(from.X), int32(from.Y), int32(to.X+3), int32(to.Y+3))
 }
 
 // OnStart is called at the end of the component being loaded by the scene.
@@ -76,15 +76,21 @@ func (c *Mouse) OnAwake() {
 	c.Component.OnAwake()
 }
 
-// OnUpdate is called for every update frame.
+// OnUpdate is called for every update frame. Delegate is triggered with the
+// screen position, the button and the world position given by the scene
+// camera.
 func (c Mouse) OnUpdate() {
 	x, y, state := sdl.GetMouseState()
+	world := engosdl.NewVector(float64(x), float64(y))
+	if scene := c.GetEntity().GetScene(); scene != nil && scene.GetCamera() != nil {
+		world = scene.GetCamera().ScreenToWorld(world)
+	}
 	for k := range c.buttons {
 		if state == k {
 			c.buttons[k] = true
 			if !c.OnClick {
 				// fmt.Printf("%d mouse push at (%d, %d) : %d\n", k, x, y, state)
-				engosdl.GetDelegateManager().TriggerDelegate(c.GetDelegate(), true, x, y, k)
+				engosdl.GetDelegateManager().TriggerDelegate(c.GetDelegate(), true, x, y, k, world)
 			}
 		}
 	}
@@ -93,7 +99,7 @@ func (c Mouse) OnUpdate() {
 			if v {
 				c.buttons[k] = false
 				// fmt.Printf("%d mouse click at (%d, %d) : %d\n", k, x, y, state)
-				engosdl.GetDelegateManager().TriggerDelegate(c.GetDelegate(), true, x, y, k)
+				engosdl.GetDelegateManager().TriggerDelegate(c.GetDelegate(), true, x, y, k, world)
 			}
 		}
 	}
@@ -9,8 +9,8 @@ import (
 // ComponentNameMove is the name to refer move to component.
 var ComponentNameMove string = reflect.TypeOf(&Move{}).String()
 
-// NextMoveT is the signature to be used in order to provide the next position
-// for the entity.
+// NextMoveT is the signature to be used in order to provide the next speed,
+// in units per second, for the entity.
 type NextMoveT func(engosdl.IComponent) *engosdl.Vector
 
 func init() {
@@ -28,6 +28,7 @@ type Move struct {
 
 // NewMove creates a new Move instance.
 // It registers to "on-out-of-bounds" delegate.
+// It depends on optional "out-of-bounds" component.
 func NewMove(name string, nextMove NextMoveT) *Move {
 	engosdl.Logger.Trace().Str("component", "Move").Str("Move", name).Msg("new Move")
 	result := &Move{
@@ -35,6 +36,7 @@ func NewMove(name string, nextMove NextMoveT) *Move {
 		nextMove:  nextMove,
 		lastMove:  engosdl.NewVector(0, 0),
 	}
+	result.AddDependency(&OutOfBounds{}, false)
 	return result
 }
 
@@ -69,7 +71,9 @@ func (c *Move) OnStart() {
 // OnUpdate is called for every update tick.
 func (c *Move) OnUpdate() {
 	position := c.GetEntity().GetTransform().GetPosition()
-	c.lastMove = c.nextMove(c)
+	delta := engosdl.GetDeltaTime()
+	speed := c.nextMove(c)
+	c.lastMove = engosdl.NewVector(speed.X*delta, speed.Y*delta)
 	position.X += c.lastMove.X
 	position.Y += c.lastMove.Y
 }
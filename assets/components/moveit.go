@@ -16,7 +16,8 @@ func init() {
 	}
 }
 
-// MoveIt represents a component that can take move-it input
+// MoveIt represents a component that can take move-it input. Speed is given
+// in units per second.
 type MoveIt struct {
 	*engosdl.Component
 	Speed    *engosdl.Vector `json:"speed"`
@@ -25,6 +26,8 @@ type MoveIt struct {
 
 // NewMoveIt creates a new move-it instance.
 // It registers to "on-out-of-bounds" delegate.
+// It requires "keyboard" component.
+// It depends on optional "out-of-bounds" component.
 func NewMoveIt(name string, speed *engosdl.Vector) *MoveIt {
 	engosdl.Logger.Trace().Str("component", "move-it").Str("move-it", name).Msg("new move-it")
 	result := &MoveIt{
@@ -32,6 +35,8 @@ func NewMoveIt(name string, speed *engosdl.Vector) *MoveIt {
 		Speed:     speed,
 		LastMove:  engosdl.NewVector(0, 0),
 	}
+	result.AddDependency(&Keyboard{}, true)
+	result.AddDependency(&OutOfBounds{}, false)
 	return result
 }
 
@@ -58,33 +63,35 @@ func (c *MoveIt) DefaultOnOutOfBounds(params ...interface{}) bool {
 }
 
 func (c *MoveIt) onKeyboard(params ...interface{}) bool {
-	position := c.GetEntity().GetTransform().GetPosition()
 	key := params[0].(int)
 	switch key {
 	case sdl.SCANCODE_LEFT:
-		position.X -= c.Speed.X
-		c.LastMove.X = -1 * c.Speed.X
-		c.LastMove.Y = 0
+		c.MoveBy(-1*c.Speed.X, 0)
 		break
 	case sdl.SCANCODE_RIGHT:
-		position.X += c.Speed.X
-		c.LastMove.X = c.Speed.X
-		c.LastMove.Y = 0
+		c.MoveBy(c.Speed.X, 0)
 		break
 	case sdl.SCANCODE_UP:
-		position.Y -= c.Speed.Y
-		c.LastMove.Y = -1 * c.Speed.Y
-		c.LastMove.X = 0
+		c.MoveBy(0, -1*c.Speed.Y)
 		break
 	case sdl.SCANCODE_DOWN:
-		position.Y += c.Speed.Y
-		c.LastMove.Y = c.Speed.Y
-		c.LastMove.X = 0
+		c.MoveBy(0, c.Speed.Y)
 		break
 	}
 	return true
 }
 
+// MoveBy moves the entity with the given speed, in units per second, for the
+// running update step. Movement is stored as the last move.
+func (c *MoveIt) MoveBy(speedX float64, speedY float64) {
+	delta := engosdl.GetDeltaTime()
+	position := c.GetEntity().GetTransform().GetPosition()
+	c.LastMove.X = speedX * delta
+	c.LastMove.Y = speedY * delta
+	position.X += c.LastMove.X
+	position.Y += c.LastMove.Y
+}
+
 // OnStart is called first time the component is enabled.
 func (c *MoveIt) OnStart() {
 	engosdl.Logger.Trace().Str("component", "move-to").Str("move-to", c.GetName()).Msg("OnStart")
@@ -15,20 +15,25 @@ func init() {
 	}
 }
 
-// MoveTo represents a component that moves a entity.
+// MoveTo represents a component that moves a entity. Speed is given in
+// units per second.
 type MoveTo struct {
 	*engosdl.Component
-	Speed *engosdl.Vector `json:"speed"`
+	Speed    *engosdl.Vector `json:"speed"`
+	lastMove *engosdl.Vector
 }
 
 // NewMoveTo creates a new move-to instance.
 // It registers to "on-out-of-bounds" delegate.
+// It depends on optional "out-of-bounds" component.
 func NewMoveTo(name string, speed *engosdl.Vector) *MoveTo {
 	engosdl.Logger.Trace().Str("component", "move-to").Str("move-to", name).Msg("new move-to")
 	result := &MoveTo{
 		Component: engosdl.NewComponent(name),
 		Speed:     speed,
+		lastMove:  engosdl.NewVector(0, 0),
 	}
+	result.AddDependency(&OutOfBounds{}, false)
 	return result
 }
 
@@ -49,8 +54,8 @@ func (c *MoveTo) DefaultAddDelegateToRegister() {
 // DefaultOnOutOfBounds checks if the entity has gone out of bounds.
 func (c *MoveTo) DefaultOnOutOfBounds(params ...interface{}) bool {
 	position := c.GetEntity().GetTransform().GetPosition()
-	position.X -= c.Speed.X
-	position.Y -= c.Speed.Y
+	position.X -= c.lastMove.X
+	position.Y -= c.lastMove.Y
 	return true
 }
 
@@ -67,9 +72,12 @@ func (c *MoveTo) OnStart() {
 
 // OnUpdate is called for every update tick.
 func (c *MoveTo) OnUpdate() {
+	delta := engosdl.GetDeltaTime()
 	position := c.GetEntity().GetTransform().GetPosition()
-	position.X += c.Speed.X
-	position.Y += c.Speed.Y
+	c.lastMove.X = c.Speed.X * delta
+	c.lastMove.Y = c.Speed.Y * delta
+	position.X += c.lastMove.X
+	position.Y += c.lastMove.Y
 }
 
 // SetSpeed sets movement speed.
@@ -0,0 +1,329 @@
+package components
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ComponentNameParticleEmitter is the name to refer particle emitter
+// component.
+var ComponentNameParticleEmitter string = reflect.TypeOf(&ParticleEmitter{}).String()
+
+func init() {
+	if componentManager := engosdl.GetComponentManager(); componentManager != nil {
+		componentManager.RegisterConstructor(ComponentNameParticleEmitter, CreateParticleEmitter)
+	}
+}
+
+// particle represents a single particle in the emitter pool. Particle
+// position is given in world coordinates.
+type particle struct {
+	position engosdl.Vector
+	velocity engosdl.Vector
+	age      float64
+	lifetime float64
+}
+
+// ParticleEmitter represents a component that emits, updates and displays
+// particles. Particles are kept in a pool with the configuration maximum
+// number of particles, so no memory is allocated while the emitter is
+// running. Particles are emitted from the entity center and they move in
+// world coordinates, so they are not affected by entity movement once they
+// are emitted. If AutoPlay is set, emitter starts emitting when the component
+// starts.
+type ParticleEmitter struct {
+	*engosdl.Component
+	Config    *engosdl.ParticleConfig `json:"config"`
+	AutoPlay  bool                    `json:"auto-play"`
+	renderer  engosdl.IRenderer
+	resource  engosdl.IResource
+	texture   engosdl.ITexture
+	particles []particle
+	alive     int
+	playing   bool
+	elapsed   float64
+	pending   float64
+	random    *rand.Rand
+}
+
+var _ engosdl.IParticleEmitter = (*ParticleEmitter)(nil)
+
+// NewParticleEmitter creates a new particle emitter instance.
+func NewParticleEmitter(name string, config *engosdl.ParticleConfig) *ParticleEmitter {
+	engosdl.Logger.Trace().Str("component", "particle-emitter").Str("particle-emitter", name).Msg("new particle-emitter")
+	return &ParticleEmitter{
+		Component: engosdl.NewComponent(name),
+		Config:    config,
+		AutoPlay:  true,
+		renderer:  engosdl.GetRenderer(),
+		resource:  nil,
+		texture:   nil,
+		particles: []particle{},
+		alive:     0,
+		playing:   false,
+		elapsed:   0,
+		pending:   0,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// CreateParticleEmitter implements particle emitter constructor used by
+// component manager.
+func CreateParticleEmitter(params ...interface{}) engosdl.IComponent {
+	if len(params) == 2 {
+		return NewParticleEmitter(params[0].(string), params[1].(*engosdl.ParticleConfig))
+	}
+	return NewParticleEmitter("", engosdl.NewParticleConfig())
+}
+
+// Clear removes all particles.
+func (c *ParticleEmitter) Clear() {
+	c.alive = 0
+}
+
+// DoDestroy calls all methods to clean up particle emitter.
+func (c *ParticleEmitter) DoDestroy() {
+	engosdl.Logger.Trace().Str("component", "particle-emitter").Str("particle-emitter", c.GetName()).Msg("DoDestroy")
+	if c.texture != nil {
+		c.texture.Destroy()
+		c.texture = nil
+	}
+	if c.resource != nil {
+		engosdl.GetResourceManager().DeleteResource(c.resource)
+		c.resource = nil
+	}
+	c.particles = []particle{}
+	c.alive = 0
+	c.Component.DoDestroy()
+}
+
+// Emit emits the given number of particles. Particles are not emitted if
+// the pool is full.
+func (c *ParticleEmitter) Emit(count int) {
+	if c.GetEntity() == nil {
+		return
+	}
+	transform := c.GetEntity().GetTransform()
+	x, y, w, h := transform.GetRectExt()
+	rotation := transform.GetWorldRotation()
+	config := c.Config
+	for i := 0; i < count && c.alive < len(c.particles); i++ {
+		angle := (config.Angle + rotation + c.spread(config.AngleSpread)) * math.Pi / 180
+		speed := config.Speed + c.spread(config.SpeedSpread)
+		p := &c.particles[c.alive]
+		p.position = engosdl.Vector{X: x + w/2, Y: y + h/2}
+		p.velocity = engosdl.Vector{X: speed * math.Cos(angle), Y: speed * math.Sin(angle)}
+		p.age = 0
+		p.lifetime = config.Lifetime + c.spread(config.LifetimeSpread)
+		c.alive++
+	}
+}
+
+// emitBursts emits all bursts with a time in the range from start, included,
+// to end, excluded.
+func (c *ParticleEmitter) emitBursts(start float64, end float64) {
+	for _, burst := range c.Config.Bursts {
+		if burst.Time >= start && burst.Time < end {
+			c.Emit(burst.Count)
+		}
+	}
+}
+
+// GetConfig returns the particle configuration.
+func (c *ParticleEmitter) GetConfig() *engosdl.ParticleConfig {
+	return c.Config
+}
+
+// GetParticleCount returns the number of particles alive.
+func (c *ParticleEmitter) GetParticleCount() int {
+	return c.alive
+}
+
+// IsPlaying returns if the emitter is emitting particles.
+func (c *ParticleEmitter) IsPlaying() bool {
+	return c.playing
+}
+
+// loadTexture creates the particle texture if the configuration has any
+// texture and it creates the particle pool.
+func (c *ParticleEmitter) loadTexture() {
+	if c.Config.Texture != "" && c.texture == nil {
+		if c.resource = engosdl.GetResourceManager().CreateResource(c.GetName(), c.Config.Texture, c.Config.Format); c.resource != nil {
+			c.texture = c.resource.GetTextureFromSurface()
+		}
+	}
+	if c.texture != nil {
+		if c.Config.Additive {
+			c.texture.SetBlendMode(sdl.BLENDMODE_ADD)
+		} else {
+			c.texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+		}
+	}
+	if len(c.particles) != c.Config.MaxParticles {
+		c.particles = make([]particle, c.Config.MaxParticles)
+		c.alive = 0
+	}
+}
+
+// OnAwake is called the first time the component is loaded in the scene,
+// it creates the particle texture and the particle pool.
+func (c *ParticleEmitter) OnAwake() {
+	engosdl.Logger.Trace().Str("component", "particle-emitter").Str("particle-emitter", c.GetName()).Msg("OnAwake")
+	c.loadTexture()
+	c.Component.OnAwake()
+}
+
+// OnRender is called for every render tick. Particles are displayed through
+// the scene camera with their color and size for their age.
+func (c *ParticleEmitter) OnRender() {
+	if c.alive == 0 {
+		return
+	}
+	camera := c.GetEntity().GetScene().GetCamera()
+	config := c.Config
+	if c.texture == nil {
+		if config.Additive {
+			c.renderer.SetDrawBlendMode(sdl.BLENDMODE_ADD)
+		} else {
+			c.renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+		}
+	}
+	for i := 0; i < c.alive; i++ {
+		p := &c.particles[i]
+		age := 1.0
+		if p.lifetime > 0 {
+			age = p.age / p.lifetime
+		}
+		color := engosdl.LerpColor(config.StartColor, config.EndColor, engosdl.EvaluateCurve(config.ColorCurve, age))
+		size := config.StartSize + (config.EndSize-config.StartSize)*engosdl.EvaluateCurve(config.SizeCurve, age)
+		if size <= 0 {
+			continue
+		}
+		displayAt := camera.RectToScreen(engosdl.NewRect(p.position.X-size/2, p.position.Y-size/2, size, size))
+		if c.texture != nil {
+			c.texture.SetColorMod(color.R, color.G, color.B)
+			c.texture.SetAlphaMod(color.A)
+			c.renderer.CopyEx(c.texture, nil, displayAt, -camera.GetRotation(), nil, sdl.FLIP_NONE)
+		} else {
+			c.renderer.SetDrawColor(color.R, color.G, color.B, color.A)
+			c.renderer.FillRect(displayAt)
+		}
+	}
+}
+
+// OnStart is called first time the component is enabled. It starts
+// emitting particles if AutoPlay is set.
+func (c *ParticleEmitter) OnStart() {
+	engosdl.Logger.Trace().Str("component", "particle-emitter").Str("particle-emitter", c.GetName()).Msg("OnStart")
+	if c.AutoPlay {
+		c.Play()
+	}
+	c.Component.OnStart()
+}
+
+// OnUpdate is called for every update tick. It emits new particles and it
+// moves and expires particles alive.
+func (c *ParticleEmitter) OnUpdate() {
+	dt := engosdl.GetDeltaTime()
+	c.updateParticles(dt)
+	if c.playing {
+		c.updateEmission(dt)
+	}
+}
+
+// Play starts emitting particles from the beginning of the emitter cycle.
+func (c *ParticleEmitter) Play() {
+	c.playing = true
+	c.elapsed = 0
+	c.pending = 0
+}
+
+// SetConfig sets the particle configuration. Particle pool is created again
+// if the maximum number of particles changes.
+func (c *ParticleEmitter) SetConfig(config *engosdl.ParticleConfig) {
+	c.Config = config
+	if c.GetEntity() != nil && c.GetEntity().GetScene() != nil {
+		c.loadTexture()
+	}
+}
+
+// SetSeed sets the seed used to randomize particle values.
+func (c *ParticleEmitter) SetSeed(seed int64) {
+	c.random = rand.New(rand.NewSource(seed))
+}
+
+// spread returns a random value in the range -value to value.
+func (c *ParticleEmitter) spread(value float64) float64 {
+	if value == 0 {
+		return 0
+	}
+	return (c.random.Float64()*2 - 1) * value
+}
+
+// Stop stops emitting particles. Particles alive are updated until they
+// expire.
+func (c *ParticleEmitter) Stop() {
+	c.playing = false
+}
+
+// updateEmission emits particles for the given elapsed time, based on the
+// emission rate and bursts.
+func (c *ParticleEmitter) updateEmission(dt float64) {
+	config := c.Config
+	start := c.elapsed
+	c.elapsed += dt
+	c.pending += config.Rate * dt
+	if count := int(c.pending); count > 0 {
+		c.pending -= float64(count)
+		c.Emit(count)
+	}
+	if config.Duration <= 0 {
+		c.emitBursts(start, c.elapsed)
+		return
+	}
+	for c.elapsed >= config.Duration {
+		c.emitBursts(start, config.Duration)
+		if !config.Loop {
+			c.playing = false
+			return
+		}
+		c.elapsed -= config.Duration
+		start = 0
+	}
+	c.emitBursts(start, c.elapsed)
+}
+
+// updateParticles moves all particles alive and it removes expired
+// particles from the pool.
+func (c *ParticleEmitter) updateParticles(dt float64) {
+	gravity := c.Config.Gravity
+	for i := 0; i < c.alive; {
+		p := &c.particles[i]
+		p.age += dt
+		if p.age >= p.lifetime {
+			c.alive--
+			c.particles[i], c.particles[c.alive] = c.particles[c.alive], c.particles[i]
+			continue
+		}
+		if gravity != nil {
+			p.velocity.X += gravity.X * dt
+			p.velocity.Y += gravity.Y * dt
+		}
+		p.position.X += p.velocity.X * dt
+		p.position.Y += p.velocity.Y * dt
+		i++
+	}
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *ParticleEmitter) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	c.Config = engosdl.UnmarshalParticleConfig(data["config"].(map[string]interface{}))
+	c.AutoPlay = data["auto-play"].(bool)
+}
@@ -0,0 +1,157 @@
+package components
+
+import (
+	"reflect"
+
+	"github.com/jrecuero/engosdl"
+)
+
+// ComponentNameRigidBody2D is the name to refer rigid body 2D component.
+var ComponentNameRigidBody2D string = reflect.TypeOf(&RigidBody2D{}).String()
+
+func init() {
+	if componentManager := engosdl.GetComponentManager(); componentManager != nil {
+		componentManager.RegisterConstructor(ComponentNameRigidBody2D, CreateRigidBody2D)
+	}
+}
+
+// RigidBody2D represents a component that moves an entity using physics.
+// Velocity is given in units per second, acceleration and gravity in units
+// per second squared. Drag reduces velocity every second. Restitution and
+// friction are used by the scene to resolve collisions, so the entity
+// requires a collider to collide with other entities.
+type RigidBody2D struct {
+	*engosdl.Component
+	BodyType     int             `json:"body-type"`
+	Mass         float64         `json:"mass"`
+	Velocity     *engosdl.Vector `json:"velocity"`
+	Acceleration *engosdl.Vector `json:"acceleration"`
+	GravityScale float64         `json:"gravity-scale"`
+	Drag         float64         `json:"drag"`
+	Restitution  float64         `json:"restitution"`
+	Friction     float64         `json:"friction"`
+	force        *engosdl.Vector
+}
+
+var _ engosdl.IRigidBody = (*RigidBody2D)(nil)
+
+// NewRigidBody2D creates a new rigid body 2D instance.
+func NewRigidBody2D(name string, bodyType int, mass float64) *RigidBody2D {
+	engosdl.Logger.Trace().Str("component", "rigid-body-2D").Str("rigid-body-2D", name).Msg("new rigid-body-2D")
+	return &RigidBody2D{
+		Component:    engosdl.NewComponent(name),
+		BodyType:     bodyType,
+		Mass:         mass,
+		Velocity:     engosdl.NewVector(0, 0),
+		Acceleration: engosdl.NewVector(0, 0),
+		GravityScale: 1,
+		Drag:         0,
+		Restitution:  0,
+		Friction:     0,
+		force:        engosdl.NewVector(0, 0),
+	}
+}
+
+// CreateRigidBody2D implements rigid body 2D constructor used by component
+// manager.
+func CreateRigidBody2D(params ...interface{}) engosdl.IComponent {
+	if len(params) == 3 {
+		return NewRigidBody2D(params[0].(string), params[1].(int), params[2].(float64))
+	}
+	return NewRigidBody2D("", engosdl.BodyDynamic, 1)
+}
+
+// AddForce adds a force to be applied in the next update.
+func (c *RigidBody2D) AddForce(force *engosdl.Vector) {
+	c.force.X += force.X
+	c.force.Y += force.Y
+}
+
+// AddImpulse changes velocity at once using the given impulse.
+func (c *RigidBody2D) AddImpulse(impulse *engosdl.Vector) {
+	inverseMass := c.GetInverseMass()
+	c.Velocity = engosdl.NewVector(c.Velocity.X+impulse.X*inverseMass, c.Velocity.Y+impulse.Y*inverseMass)
+}
+
+// GetBodyType returns the body type.
+func (c *RigidBody2D) GetBodyType() int {
+	return c.BodyType
+}
+
+// GetFriction returns the body friction.
+func (c *RigidBody2D) GetFriction() float64 {
+	return c.Friction
+}
+
+// GetInverseMass returns the inverse of the body mass. Only dynamic bodies
+// with mass have a finite mass.
+func (c *RigidBody2D) GetInverseMass() float64 {
+	if c.BodyType != engosdl.BodyDynamic || c.Mass <= 0 {
+		return 0
+	}
+	return 1 / c.Mass
+}
+
+// GetRestitution returns the body restitution.
+func (c *RigidBody2D) GetRestitution() float64 {
+	return c.Restitution
+}
+
+// GetVelocity returns the body velocity.
+func (c *RigidBody2D) GetVelocity() *engosdl.Vector {
+	return c.Velocity
+}
+
+// OnStart is called first time the component is enabled.
+func (c *RigidBody2D) OnStart() {
+	engosdl.Logger.Trace().Str("component", "rigid-body-2D").Str("rigid-body-2D", c.GetName()).Msg("OnStart")
+	c.Component.OnStart()
+}
+
+// OnUpdate is called for every update tick. Dynamic bodies integrate forces,
+// gravity and drag into the velocity. Dynamic and kinematic bodies move the
+// entity using the velocity.
+func (c *RigidBody2D) OnUpdate() {
+	delta := engosdl.GetDeltaTime()
+	if c.BodyType == engosdl.BodyDynamic {
+		gravity := engosdl.NewVector(0, 0)
+		if scene := c.GetEntity().GetScene(); scene != nil {
+			gravity = scene.GetGravity()
+		}
+		inverseMass := c.GetInverseMass()
+		c.Velocity.X += (c.Acceleration.X + gravity.X*c.GravityScale + c.force.X*inverseMass) * delta
+		c.Velocity.Y += (c.Acceleration.Y + gravity.Y*c.GravityScale + c.force.Y*inverseMass) * delta
+		if c.Drag > 0 {
+			factor := 1 / (1 + c.Drag*delta)
+			c.Velocity.X *= factor
+			c.Velocity.Y *= factor
+		}
+	}
+	c.force = engosdl.NewVector(0, 0)
+	if c.BodyType != engosdl.BodyStatic {
+		position := c.GetEntity().GetTransform().GetPosition()
+		position.X += c.Velocity.X * delta
+		position.Y += c.Velocity.Y * delta
+	}
+}
+
+// SetVelocity sets the body velocity.
+func (c *RigidBody2D) SetVelocity(velocity *engosdl.Vector) {
+	c.Velocity = velocity
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *RigidBody2D) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	c.BodyType = int(data["body-type"].(float64))
+	c.Mass = data["mass"].(float64)
+	velocity := data["velocity"].(map[string]interface{})
+	c.Velocity = engosdl.NewVector(velocity["X"].(float64), velocity["Y"].(float64))
+	acceleration := data["acceleration"].(map[string]interface{})
+	c.Acceleration = engosdl.NewVector(acceleration["X"].(float64), acceleration["Y"].(float64))
+	c.GravityScale = data["gravity-scale"].(float64)
+	c.Drag = data["drag"].(float64)
+	c.Restitution = data["restitution"].(float64)
+	c.Friction = data["friction"].(float64)
+}
@@ -52,40 +52,43 @@ func CreateScrollSprite(params ...interface{}) engosdl.IComponent {
 	return NewScrollSprite("", "", engosdl.FormatBMP)
 }
 
-// OnRender is called for every render tick.
+// OnRender is called for every render tick. Scroll sprite is displayed
+// through the scene camera.
 func (c *ScrollSprite) OnRender() {
 	// engosdl.Logger.Trace().Str("sprite", spr.GetName()).Msg("OnRender")
-	x := int32(c.GetEntity().GetTransform().GetPosition().X)
-	y := int32(c.GetEntity().GetTransform().GetPosition().Y)
-	width := c.width * int32(c.GetEntity().GetTransform().GetScale().X)
-	height := c.height * int32(c.GetEntity().GetTransform().GetScale().Y)
-	W, H, _ := engosdl.GetRenderer().GetOutputSize()
+	camera := c.GetEntity().GetScene().GetCamera()
+	rect := c.GetEntity().GetTransform().GetRenderRect()
+	x := int32(rect.X)
+	y := int32(rect.Y)
+	width := c.width * int32(c.GetEntity().GetTransform().GetWorldScale().X)
+	height := c.height * int32(c.GetEntity().GetTransform().GetWorldScale().Y)
+	W, H, _ := c.renderer.GetOutputSize()
 	if c.Scroll.Y == -1 {
 		y = y % height
 	} else if c.Scroll.X == -1 {
 		x = x % width
 	}
 	displayFrom := &sdl.Rect{X: 0, Y: 0, W: width, H: height}
-	displayAt := &sdl.Rect{X: x, Y: y, W: width, H: height}
+	displayAt := camera.RectToScreen(engosdl.NewRect(float64(x), float64(y), float64(width), float64(height)))
 	c.renderer.CopyEx(c.textures[0],
 		displayFrom,
 		displayAt,
-		0,
-		&sdl.Point{},
+		-camera.GetRotation(),
+		nil,
 		sdl.FLIP_NONE)
 	if c.Scroll.Y == -1 && (y+height) < H {
 		c.renderer.CopyEx(c.textures[0],
 			&sdl.Rect{X: 0, Y: 0, W: width, H: height},
-			&sdl.Rect{X: x, Y: y + height, W: width, H: height},
-			0,
-			&sdl.Point{},
+			camera.RectToScreen(engosdl.NewRect(float64(x), float64(y+height), float64(width), float64(height))),
+			-camera.GetRotation(),
+			nil,
 			sdl.FLIP_NONE)
 	} else if c.Scroll.X == -1 && (x+width) < W {
 		c.renderer.CopyEx(c.textures[0],
 			&sdl.Rect{X: 0, Y: 0, W: width, H: height},
-			&sdl.Rect{X: x + width, Y: y, W: width, H: height},
-			0,
-			&sdl.Point{},
+			camera.RectToScreen(engosdl.NewRect(float64(x+width), float64(y), float64(width), float64(height))),
+			-camera.GetRotation(),
+			nil,
 			sdl.FLIP_NONE)
 	}
 }
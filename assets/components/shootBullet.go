@@ -26,12 +26,14 @@ type ShootBullet struct {
 
 // NewShootBullet creates a instance of shoot bullet.
 // It registers to "on-shoot" delegate.
+// It requires "key-shooter" component.
 func NewShootBullet(name string, speed *engosdl.Vector) *ShootBullet {
 	engosdl.Logger.Trace().Str("component", "shoot-bullet").Str("shoot-bullet", name).Msg("new shoot-bullet")
 	result := &ShootBullet{
 		Component: engosdl.NewComponent(name),
 		Speed:     speed,
 	}
+	result.AddDependency(&KeyShooter{}, true)
 	return result
 }
 
@@ -21,18 +21,22 @@ func init() {
 	}
 }
 
-// Shooter represents a component that shoot any given bullet.
+// Shooter represents a component that shoot any given bullet. Bullets are
+// created with the given function, or they are acquired from the pool for
+// the given prefab if there is not any function.
 type Shooter struct {
 	*engosdl.Component
 	// delegate engosdl.IDelegate
 	// counter   int
 	newBullet ShooterSignatureT
 	Cooldown  time.Duration `json:"cool-down"`
+	Prefab    string        `json:"prefab"`
 	lastshoot time.Time
 }
 
 // NewShooter creates a instance of shooter.
 // It registers to "on-shoot" delegate.
+// It requires "key-shooter" component.
 func NewShooter(name string, newBullet ShooterSignatureT) *Shooter {
 	engosdl.Logger.Trace().Str("component", "shoot-bullet").Str("shoot-bullet", name).Msg("new shoot-bullet")
 	result := &Shooter{
@@ -40,8 +44,10 @@ func NewShooter(name string, newBullet ShooterSignatureT) *Shooter {
 		// counter:   0,
 		newBullet: newBullet,
 		Cooldown:  500 * time.Millisecond,
+		Prefab:    "",
 		lastshoot: time.Now(),
 	}
+	result.AddDependency(&KeyShooter{}, true)
 	return result
 }
 
@@ -54,6 +60,26 @@ func CreateShooter(params ...interface{}) engosdl.IComponent {
 	return NewShooter("", nil)
 }
 
+// NewShooterWithPrefab creates a instance of shooter that acquires bullets
+// from the pool for the given prefab.
+func NewShooterWithPrefab(name string, prefab string) *Shooter {
+	result := NewShooter(name, nil)
+	result.Prefab = prefab
+	return result
+}
+
+// createBullet creates a new bullet with the shooter function, or it
+// acquires a bullet from the prefab pool.
+func (c *Shooter) createBullet() engosdl.IEntity {
+	if c.newBullet != nil {
+		return c.newBullet()
+	}
+	if c.Prefab != "" {
+		return engosdl.GetPrefabManager().GetPool(c.Prefab).Acquire()
+	}
+	return nil
+}
+
 // DefaultAddDelegateToRegister will proceed to add default delegate to
 // register for the component.
 func (c *Shooter) DefaultAddDelegateToRegister() {
@@ -91,7 +117,10 @@ func (c *Shooter) ShooterSignature(params ...interface{}) bool {
 				// bullet.AddComponent(bulletOutOfBounds)
 				// bullet.AddComponent(bulletCollider2D)
 				// bulletSprite.LoadSprite()
-				bullet := c.newBullet()
+				bullet := c.createBullet()
+				if bullet == nil {
+					return true
+				}
 				bulletW, bulletH := bullet.GetTransform().GetDim().Get()
 				bullet.SetParent(c.GetEntity())
 				bullet.GetTransform().SetPosition(engosdl.NewVector((x + w/2 - bulletW/2), (y + h/2 - bulletH/2)))
@@ -108,4 +137,10 @@ func (c *Shooter) ShooterSignature(params ...interface{}) bool {
 // instance.
 func (c *Shooter) Unmarshal(data map[string]interface{}) {
 	c.Component.Unmarshal(data)
+	if cooldown, ok := data["cool-down"].(float64); ok {
+		c.Cooldown = time.Duration(cooldown)
+	}
+	if prefab, ok := data["prefab"].(string); ok {
+		c.Prefab = prefab
+	}
 }
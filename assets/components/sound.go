@@ -48,7 +48,9 @@ func CreateSound(params ...interface{}) engosdl.IComponent {
 // DoDestroy calls all methods to clean up sound.
 func (c *Sound) DoDestroy() {
 	engosdl.Logger.Trace().Str("component", "sound").Str("sound", c.GetName()).Msg("DoDestroy")
-	c.resource.Delete()
+	if c.resource != nil {
+		c.resource.Delete()
+	}
 	c.Component.DoDestroy()
 }
 
@@ -90,19 +92,21 @@ func (c *Sound) OnStart() {
 	c.Component.OnStart()
 }
 
-// Play plays the sound.
+// Play plays the sound. Errors are reported and sound is not played.
 func (c *Sound) Play(times int) {
+	if c.resource == nil {
+		// Sound could not be loaded.
+		return
+	}
 	// if err := c.resource.GetResource().Play(times); err != nil {
 	if sound, format := c.resource.GetResource(); sound != nil {
 		if format == engosdl.SoundMP3 {
 			if err := sound.(*mix.Music).FadeIn(times, 2500); err != nil {
-				engosdl.Logger.Error().Err(err).Msg("play mix MP3 resource error")
-				panic(err)
+				engosdl.ReportError(err)
 			}
 		} else if format == engosdl.SoundWAV {
 			if _, err := sound.(*mix.Chunk).Play(1, 1); err != nil {
-				engosdl.Logger.Error().Err(err).Msg("play mix WAV resource error")
-				panic(err)
+				engosdl.ReportError(err)
 			}
 		}
 	}
@@ -18,20 +18,26 @@ func init() {
 }
 
 // Sprite represents a component that can display multiple
-// sprites, which can be animated.
+// sprites, which can be animated. If an atlas is given, sprite displays the
+// atlas region with the given name instead of image files, and atlas texture
+// is shared with all other sprites using the same atlas.
 type Sprite struct {
 	*engosdl.Component
 	Filenames      []string `json:"filenames"`
 	width          int32
 	height         int32
-	renderer       *sdl.Renderer
-	textures       []*sdl.Texture
+	renderer       engosdl.IRenderer
+	textures       []engosdl.ITexture
 	camera         *engosdl.Rect
 	fileImageIndex int
 	SpriteTotal    int `json:"sprite-total"`
 	spriteIndex    int
 	resources      []engosdl.IResource
-	Format         int `json:"format"`
+	Format         int    `json:"format"`
+	Atlas          string `json:"atlas"`
+	Region         string `json:"region"`
+	atlas          engosdl.ITextureAtlas
+	region         *engosdl.AtlasFrame
 }
 
 var _ engosdl.ISprite = (*Sprite)(nil)
@@ -39,20 +45,35 @@ var _ engosdl.ISprite = (*Sprite)(nil)
 // NewSprite creates a new sprite instance.
 // It register to "collision" delegate.
 // It register to "out-of-bounds" delegate.
+// It depends on optional "out-of-bounds" component.
 func NewSprite(name string, filenames []string, numberOfSprites int, format int) *Sprite {
 	engosdl.Logger.Trace().Str("component", "sprite").Str("sprite", name).Msg("new sprite")
 	result := &Sprite{
 		Component:      engosdl.NewComponent(name),
 		Filenames:      filenames,
 		renderer:       engosdl.GetRenderer(),
-		textures:       []*sdl.Texture{},
+		textures:       []engosdl.ITexture{},
 		camera:         nil,
 		fileImageIndex: 0,
 		SpriteTotal:    numberOfSprites,
 		spriteIndex:    0,
 		resources:      []engosdl.IResource{},
 		Format:         format,
+		Atlas:          "",
+		Region:         "",
+		atlas:          nil,
+		region:         nil,
 	}
+	result.AddDependency(&OutOfBounds{}, false)
+	return result
+}
+
+// NewAtlasSprite creates a new sprite instance that displays the region with
+// the given name from the given atlas descriptor file.
+func NewAtlasSprite(name string, atlas string, region string) *Sprite {
+	result := NewSprite(name, []string{}, 1, engosdl.FormatBMP)
+	result.Atlas = atlas
+	result.Region = region
 	return result
 }
 
@@ -105,10 +126,17 @@ func (c *Sprite) DefaultOnOutOfBounds(params ...interface{}) bool {
 // DoDestroy calls all methods to clean up sprite.
 func (c *Sprite) DoDestroy() {
 	engosdl.Logger.Trace().Str("component", "sprite").Str("sprite", c.GetName()).Msg("DoDestroy")
-	for _, texture := range c.textures {
-		texture.Destroy()
+	if c.atlas != nil {
+		// Atlas texture is shared, it is released by the resource manager.
+		engosdl.GetResourceManager().DeleteAtlas(c.atlas)
+		c.atlas = nil
+		c.region = nil
+	} else {
+		for _, texture := range c.textures {
+			texture.Destroy()
+		}
 	}
-	c.textures = []*sdl.Texture{}
+	c.textures = []engosdl.ITexture{}
 	c.resources = []engosdl.IResource{}
 	c.Component.DoDestroy()
 }
@@ -125,7 +153,8 @@ func (c *Sprite) DoUnLoad() {
 	c.Component.DoUnLoad()
 }
 
-// GetCamera returns the camera used to display the sprite
+// GetCamera returns the camera used to display the sprite. Sprite camera is
+// the region of the sprite image to be displayed.
 func (c *Sprite) GetCamera() *engosdl.Rect {
 	return c.camera
 }
@@ -140,6 +169,11 @@ func (c *Sprite) GetFilename() []string {
 	return c.Filenames
 }
 
+// GetRegion returns the atlas region name displayed.
+func (c *Sprite) GetRegion() string {
+	return c.Region
+}
+
 // GetSpriteIndex returns sprite sheet sprite index currently used.
 func (c *Sprite) GetSpriteIndex() int {
 	return c.spriteIndex
@@ -149,23 +183,44 @@ func (c *Sprite) GetSpriteIndex() int {
 func (c *Sprite) LoadSprite() {
 	engosdl.Logger.Trace().Str("component", "sprite").Str("sprite", c.GetName()).Msg("LoadSprite")
 	c.loadTextures()
-	// TODO: assuming SpriteSheet is horizontal.
-	c.GetEntity().GetTransform().SetDim(engosdl.NewVector(float64(c.width/int32(c.SpriteTotal)), float64(c.height)))
+	c.setDim()
 }
 
-// loadTextures creates textures for every image file.
+// loadTextures creates textures for every image file, or the atlas texture if
+// sprite uses an atlas. Errors are reported and sprite is not displayed.
 func (c *Sprite) loadTextures() {
+	if c.Atlas != "" {
+		if c.atlas == nil {
+			if c.atlas = engosdl.GetResourceManager().CreateAtlas(c.GetName(), c.Atlas); c.atlas == nil {
+				return
+			}
+			c.resources = []engosdl.IResource{c.atlas.GetResource()}
+			if texture := c.atlas.GetTexture(); texture != nil {
+				c.textures = []engosdl.ITexture{texture}
+			}
+			if err := c.SetRegion(c.Region); err != nil {
+				engosdl.ReportError(err)
+			}
+		}
+		return
+	}
 	for _, filename := range c.Filenames {
 		if len(c.resources) == 0 && len(c.textures) == 0 {
 			var err error
 			resource := engosdl.GetResourceManager().CreateResource(c.GetName(), filename, c.Format)
-			texture := resource.GetTextureFromSurface()
-			_, _, c.width, c.height, err = texture.Query()
-			if err != nil {
-				engosdl.Logger.Error().Err(err).Msg("Query error")
-				panic(err)
+			if resource == nil {
+				return
 			}
 			c.resources = append(c.resources, resource)
+			texture := resource.GetTextureFromSurface()
+			if texture == nil {
+				return
+			}
+			if _, _, c.width, c.height, err = texture.Query(); err != nil {
+				engosdl.ReportError(err)
+				texture.Destroy()
+				return
+			}
 			c.textures = append(c.textures, texture)
 		}
 	}
@@ -188,27 +243,48 @@ func (c *Sprite) NextSprite() int {
 func (c *Sprite) OnAwake() {
 	engosdl.Logger.Trace().Str("component", "sprite").Str("sprite", c.GetName()).Msg("OnAwake")
 	c.loadTextures()
-	// TODO: assuming SpriteSheet is horizontal.
-	c.GetEntity().GetTransform().SetDim(engosdl.NewVector(float64(c.width/int32(c.SpriteTotal)), float64(c.height)))
+	c.setDim()
 	c.Component.OnAwake()
 }
 
-// OnRender is called for every render tick.
+// OnRender is called for every render tick. Sprite is displayed through the
+// scene camera. If the sprite has its own camera, it selects the region of
+// the sprite image to be displayed.
 func (c *Sprite) OnRender() {
 	// engosdl.Logger.Trace().Str("sprite", spr.GetName()).Msg("OnRender")
-	x, y, width, height := c.GetEntity().GetTransform().GetRectExt()
+	transform := c.GetEntity().GetTransform()
+	camera := c.GetEntity().GetScene().GetCamera()
 	var displayFrom *sdl.Rect
 	var displayAt *sdl.Rect
-	spriteX := (c.spriteIndex * int(c.width)) / int(c.SpriteTotal)
-
-	displayFrom = &sdl.Rect{X: int32(spriteX), Y: 0, W: c.width / int32(c.SpriteTotal), H: c.height}
-	displayAt = &sdl.Rect{X: int32(x), Y: int32(y), W: int32(width), H: int32(height)}
+	if c.camera != nil {
+		displayFrom = &sdl.Rect{X: int32(c.camera.X), Y: int32(c.camera.Y), W: int32(c.camera.W), H: int32(c.camera.H)}
+	} else {
+		spriteX := (c.spriteIndex * int(c.width)) / int(c.SpriteTotal)
+		displayFrom = &sdl.Rect{X: int32(spriteX), Y: 0, W: c.width / int32(c.SpriteTotal), H: c.height}
+	}
+	displayAt = camera.RectToScreen(transform.GetRenderRect())
+	rotation := transform.GetWorldRotation() - camera.GetRotation()
+	if c.region != nil && c.region.Rotated && c.camera == c.region.Rect {
+		// Rotated regions are stored rotated clockwise in the atlas, so they
+		// are displayed swapping width and height and rotated back.
+		displayAt = &sdl.Rect{
+			X: displayAt.X + (displayAt.W-displayAt.H)/2,
+			Y: displayAt.Y + (displayAt.H-displayAt.W)/2,
+			W: displayAt.H,
+			H: displayAt.W,
+		}
+		rotation -= 90
+	}
 
+	if c.fileImageIndex >= len(c.textures) {
+		// Texture could not be loaded.
+		return
+	}
 	c.renderer.CopyEx(c.textures[c.fileImageIndex],
 		displayFrom,
 		displayAt,
-		0,
-		&sdl.Point{},
+		rotation,
+		nil,
 		sdl.FLIP_NONE)
 }
 
@@ -231,11 +307,50 @@ func (c *Sprite) PreviousSprite() int {
 	return c.spriteIndex
 }
 
-// SetCamera sets the camera used to display the sprite.
+// SetCamera sets the camera used to display the sprite. Sprite camera is the
+// region of the sprite image to be displayed, nil value displays the sprite
+// sheet frame.
 func (c *Sprite) SetCamera(camera *engosdl.Rect) {
 	c.camera = camera
 }
 
+// setDim sets entity dimensions to the sprite frame size.
+func (c *Sprite) setDim() {
+	if c.region != nil {
+		size := c.region.GetSize()
+		c.GetEntity().GetTransform().SetDim(engosdl.NewVector(size.X, size.Y))
+		return
+	}
+	// TODO: assuming SpriteSheet is horizontal.
+	c.GetEntity().GetTransform().SetDim(engosdl.NewVector(float64(c.width/int32(c.SpriteTotal)), float64(c.height)))
+}
+
+// SetRegion sets the atlas region to be displayed. If the atlas has not
+// been loaded yet, region is displayed when it is loaded.
+func (c *Sprite) SetRegion(name string) error {
+	c.Region = name
+	if c.atlas == nil {
+		return nil
+	}
+	region := c.atlas.GetFrame(name)
+	if region == nil {
+		return fmt.Errorf("region %s not found in atlas %s", name, c.atlas.GetFilename())
+	}
+	c.region = region
+	c.camera = region.Rect
+	if c.GetEntity() != nil {
+		c.setDim()
+	}
+	return nil
+}
+
+// SetSpriteIndex sets the sprite sheet sprite index to be displayed.
+func (c *Sprite) SetSpriteIndex(index int) {
+	if c.SpriteTotal > 0 {
+		c.spriteIndex = ((index % c.SpriteTotal) + c.SpriteTotal) % c.SpriteTotal
+	}
+}
+
 // Unmarshal takes information from a ComponentToUnmarshal instance and
 //  creates a new component instance.
 func (c *Sprite) Unmarshal(data map[string]interface{}) {
@@ -247,4 +362,10 @@ func (c *Sprite) Unmarshal(data map[string]interface{}) {
 	}
 	c.SpriteTotal = int(data["sprite-total"].(float64))
 	c.Format = int(data["format"].(float64))
+	if atlas, ok := data["atlas"].(string); ok {
+		c.Atlas = atlas
+	}
+	if region, ok := data["region"].(string); ok {
+		c.Region = region
+	}
 }
@@ -25,8 +25,8 @@ type Text struct {
 	FontSize int       `json:"font-size"`
 	Color    sdl.Color `json:"color"`
 	Message  string    `json:"message"`
-	renderer *sdl.Renderer
-	texture  *sdl.Texture
+	renderer engosdl.IRenderer
+	texture  engosdl.ITexture
 	width    int32
 	height   int32
 }
@@ -54,22 +54,56 @@ func CreateText(params ...interface{}) engosdl.IComponent {
 	return NewText("", "", 0, sdl.Color{}, "")
 }
 
+// DoDestroy calls all methods to clean up text. Texture is destroyed and font
+// is released.
+func (c *Text) DoDestroy() {
+	engosdl.Logger.Trace().Str("component", "text").Str("text", c.GetName()).Msg("DoDestroy")
+	if c.texture != nil {
+		c.texture.Destroy()
+		c.texture = nil
+	}
+	c.releaseFont()
+	c.Component.DoDestroy()
+}
+
+// GetColor returns text color.
+func (c *Text) GetColor() sdl.Color {
+	return c.Color
+}
+
 // loadTextureFromTTF creates a texture from a ttf file.
 func (c *Text) loadTextureFromTTF() {
 	var err error
-	c.font = engosdl.GetFontManager().CreateFont(c.GetName(), c.FontFile, c.FontSize)
-	c.texture = c.font.GetTextureFromFont(c.Message, c.Color)
-	_, _, c.width, c.height, err = c.texture.Query()
-	if err != nil {
-		engosdl.Logger.Error().Err(err).Msg("Query error")
-		panic(err)
+	if c.texture != nil {
+		// Texture is created again every time text or color changes.
+		c.texture.Destroy()
+		c.texture = nil
+	}
+	if c.font == nil || c.font.GetFilename() != c.FontFile || c.font.GetFontSize() != c.FontSize {
+		// Font is reused while font file and size do not change, so there is
+		// not a new font reference every time text or color changes.
+		c.releaseFont()
+		if c.font = engosdl.GetFontManager().CreateFont(c.GetName(), c.FontFile, c.FontSize); c.font == nil {
+			return
+		}
+	}
+	if c.texture = c.font.GetTextureFromFont(c.Message, c.Color); c.texture == nil {
+		return
+	}
+	if _, _, c.width, c.height, err = c.texture.Query(); err != nil {
+		engosdl.ReportError(err)
+		c.texture.Destroy()
+		c.texture = nil
+		return
 	}
 	c.GetEntity().GetTransform().SetDim(engosdl.NewVector(float64(c.width), float64(c.height)))
 }
 
-// OnRender is called for every render tick.
+// OnRender is called for every render tick. Text is displayed through the
+// scene camera.
 func (c *Text) OnRender() {
-	x, y, width, height := c.GetEntity().GetTransform().GetRectExt()
+	transform := c.GetEntity().GetTransform()
+	camera := c.GetEntity().GetScene().GetCamera()
 	if c.GetDirty() {
 		color := c.Color
 		if c.GetEnabled() {
@@ -80,11 +114,15 @@ func (c *Text) OnRender() {
 		c.SetColor(color)
 		c.SetDirty(false)
 	}
+	if c.texture == nil {
+		// Texture could not be created.
+		return
+	}
 	c.renderer.CopyEx(c.texture,
 		&sdl.Rect{X: 0, Y: 0, W: c.width, H: c.height},
-		&sdl.Rect{X: int32(x), Y: int32(y), W: int32(width), H: int32(height)},
-		0,
-		&sdl.Point{},
+		camera.RectToScreen(transform.GetRenderRect()),
+		transform.GetWorldRotation()-camera.GetRotation(),
+		nil,
 		sdl.FLIP_NONE)
 }
 
@@ -107,6 +145,14 @@ func (c *Text) onUpdateStats(params ...interface{}) bool {
 	return true
 }
 
+// releaseFont releases the font used by the text.
+func (c *Text) releaseFont() {
+	if c.font != nil {
+		engosdl.GetFontManager().DeleteFont(c.font)
+		c.font = nil
+	}
+}
+
 // SetColor sets text color.
 func (c *Text) SetColor(color sdl.Color) engosdl.IText {
 	c.Color = color
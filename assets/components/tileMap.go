@@ -0,0 +1,366 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ComponentNameTileMap is the name to refer tile map component.
+var ComponentNameTileMap string = reflect.TypeOf(&TileMap{}).String()
+
+func init() {
+	if componentManager := engosdl.GetComponentManager(); componentManager != nil {
+		componentManager.RegisterConstructor(ComponentNameTileMap, CreateTileMap)
+	}
+}
+
+// tileObject is an entity created for a tile object, the tile is displayed
+// at the entity position.
+type tileObject struct {
+	entity engosdl.IEntity
+	gid    uint32
+}
+
+// TileMap represents a component that displays a map created with the Tiled
+// map editor, in TMX or JSON format. Map is placed at the entity position.
+// Only tiles visible through the scene camera are displayed. When component
+// starts, an entity is created for every object in object layers, with
+// object properties in the entity cache, and an entity with a collider is
+// created for every group of adjacent tiles with the collision property.
+// Objects with the collision property get a collider too.
+type TileMap struct {
+	*engosdl.Component
+	Filename      string `json:"filename"`
+	renderer      engosdl.IRenderer
+	tiledMap      *engosdl.TiledMap
+	textures      map[*engosdl.TiledTileset]engosdl.ITexture
+	resources     []engosdl.IResource
+	entities      []engosdl.IEntity
+	tileObjects   map[*engosdl.TiledLayer][]*tileObject
+	objectHandler func(engosdl.IEntity, *engosdl.TiledObject)
+}
+
+// NewTileMap creates a new tile map instance.
+func NewTileMap(name string, filename string) *TileMap {
+	engosdl.Logger.Trace().Str("component", "tile-map").Str("tile-map", name).Msg("new tile-map")
+	return &TileMap{
+		Component:     engosdl.NewComponent(name),
+		Filename:      filename,
+		renderer:      engosdl.GetRenderer(),
+		tiledMap:      nil,
+		textures:      make(map[*engosdl.TiledTileset]engosdl.ITexture),
+		resources:     []engosdl.IResource{},
+		entities:      []engosdl.IEntity{},
+		tileObjects:   make(map[*engosdl.TiledLayer][]*tileObject),
+		objectHandler: nil,
+	}
+}
+
+// CreateTileMap implements tile map constructor used by component manager.
+func CreateTileMap(params ...interface{}) engosdl.IComponent {
+	if len(params) == 2 {
+		return NewTileMap(params[0].(string), params[1].(string))
+	}
+	return NewTileMap("", "")
+}
+
+// createEntities creates entities for all objects and for tiles with the
+// collision property.
+func (c *TileMap) createEntities() {
+	entity := c.GetEntity()
+	origin := entity.GetTransform().GetWorldPosition()
+	for i, rect := range c.tiledMap.GetCollisionRects() {
+		collision := engosdl.NewEntity(fmt.Sprintf("%s/collision-%d", entity.GetName(), i))
+		collision.SetTag(entity.GetTag())
+		collision.SetLayer(entity.GetLayer())
+		collision.GetTransform().SetPositionXY(origin.X+rect.X, origin.Y+rect.Y)
+		collision.GetTransform().SetDim(engosdl.NewVector(rect.W, rect.H))
+		collider := NewCollider2D(collision.GetName() + "/collider-2D")
+		collider.SetShape(engosdl.NewColliderShape(engosdl.ShapeAABB))
+		collision.AddComponent(collider)
+		c.addEntity(collision)
+	}
+	for _, layer := range c.tiledMap.Layers {
+		for _, object := range layer.Objects {
+			name := object.Name
+			if name == "" {
+				name = fmt.Sprintf("%s/object-%d", entity.GetName(), object.ID)
+			}
+			objEntity := engosdl.NewEntity(name)
+			objEntity.SetTag(object.Type)
+			objEntity.SetLayer(entity.GetLayer())
+			// Tiled rotates objects around the top-left corner and entities
+			// are rotated around their center.
+			cx, cy := rotatePoint(object.Width/2, object.Height/2, object.Rotation)
+			objEntity.GetTransform().SetPositionXY(origin.X+object.X+cx-object.Width/2, origin.Y+object.Y+cy-object.Height/2)
+			objEntity.GetTransform().SetDim(engosdl.NewVector(object.Width, object.Height))
+			objEntity.GetTransform().SetRotation(object.Rotation)
+			objEntity.SetCache("tiled-object", object)
+			for key, value := range object.Properties {
+				objEntity.SetCache(key, value)
+			}
+			if object.GetCollision() {
+				collider := NewCollider2D(name + "/collider-2D")
+				collider.SetShape(getObjectShape(object))
+				objEntity.AddComponent(collider)
+			}
+			if object.GID != 0 {
+				c.tileObjects[layer] = append(c.tileObjects[layer], &tileObject{entity: objEntity, gid: object.GID})
+			}
+			if c.objectHandler != nil {
+				c.objectHandler(objEntity, object)
+			}
+			c.addEntity(objEntity)
+		}
+	}
+}
+
+// addEntity adds the given entity to the scene.
+func (c *TileMap) addEntity(entity engosdl.IEntity) {
+	c.entities = append(c.entities, entity)
+	if scene := c.GetEntity().GetScene(); scene != nil {
+		scene.AddEntity(entity)
+	}
+}
+
+// getObjectShape returns the collider shape for the given object.
+func getObjectShape(object *engosdl.TiledObject) *engosdl.ColliderShape {
+	switch {
+	case object.Ellipse:
+		return engosdl.NewCircleColliderShape(math.Min(object.Width, object.Height)/2, engosdl.NewVector(0, 0))
+	case len(object.Polygon) != 0:
+		points := []*engosdl.Vector{}
+		for _, point := range object.Polygon {
+			points = append(points, engosdl.NewVector(point.X, point.Y))
+		}
+		return engosdl.NewPolygonColliderShape(points)
+	case object.Rotation != 0:
+		return engosdl.NewColliderShape(engosdl.ShapeOBB)
+	}
+	return engosdl.NewColliderShape(engosdl.ShapeAABB)
+}
+
+// rotatePoint rotates the given coordinates by the given angle in degrees.
+func rotatePoint(x float64, y float64, angle float64) (float64, float64) {
+	if angle == 0 {
+		return x, y
+	}
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// DoDestroy calls all methods to clean up tile map. Entities created for
+// objects and collisions are destroyed too. Entities in a scene being
+// destroyed are destroyed by the scene.
+func (c *TileMap) DoDestroy() {
+	engosdl.Logger.Trace().Str("component", "tile-map").Str("tile-map", c.GetName()).Msg("DoDestroy")
+	for _, entity := range c.entities {
+		if scene := entity.GetScene(); scene == nil {
+			entity.DoDestroy()
+		} else if scene.GetLoaded() {
+			engosdl.GetEngine().DestroyEntity(entity)
+		}
+	}
+	c.entities = []engosdl.IEntity{}
+	c.tileObjects = make(map[*engosdl.TiledLayer][]*tileObject)
+	for _, texture := range c.textures {
+		texture.Destroy()
+	}
+	for _, resource := range c.resources {
+		engosdl.GetResourceManager().DeleteResource(resource)
+	}
+	c.textures = make(map[*engosdl.TiledTileset]engosdl.ITexture)
+	c.resources = []engosdl.IResource{}
+	c.tiledMap = nil
+	c.Component.DoDestroy()
+}
+
+// GetCell returns the column and row for the cell at the given world
+// position.
+func (c *TileMap) GetCell(position *engosdl.Vector) (int, int) {
+	if c.tiledMap == nil {
+		return -1, -1
+	}
+	transform := c.GetEntity().GetTransform()
+	origin, scale := transform.GetWorldPosition(), transform.GetWorldScale()
+	col := int(math.Floor((position.X - origin.X) / (float64(c.tiledMap.TileWidth) * scale.X)))
+	row := int(math.Floor((position.Y - origin.Y) / (float64(c.tiledMap.TileHeight) * scale.Y)))
+	return col, row
+}
+
+// GetEntities returns all entities created for objects and collisions.
+func (c *TileMap) GetEntities() []engosdl.IEntity {
+	return c.entities
+}
+
+// GetMap returns the Tiled map. It returns nil if the map has not been
+// loaded yet.
+func (c *TileMap) GetMap() *engosdl.TiledMap {
+	return c.tiledMap
+}
+
+// loadMap loads the Tiled map and textures for all tilesets. Errors are
+// reported and map is not loaded.
+func (c *TileMap) loadMap() {
+	if c.tiledMap != nil {
+		return
+	}
+	tiledMap, err := engosdl.LoadTiledMap(c.Filename)
+	if err != nil {
+		engosdl.ReportError(err)
+		return
+	}
+	c.tiledMap = tiledMap
+	for _, tileset := range tiledMap.Tilesets {
+		if tileset.Image == "" {
+			continue
+		}
+		resource := engosdl.GetResourceManager().CreateResource(c.GetName(), tileset.Image, engosdl.GetFormatFromFilename(tileset.Image))
+		if resource == nil {
+			continue
+		}
+		c.resources = append(c.resources, resource)
+		if texture := resource.GetTextureFromSurface(); texture != nil {
+			c.textures[tileset] = texture
+		}
+	}
+}
+
+// OnAwake should create all component resources that don't have any dependency
+// with any other component or entity.
+func (c *TileMap) OnAwake() {
+	engosdl.Logger.Trace().Str("component", "tile-map").Str("tile-map", c.GetName()).Msg("OnAwake")
+	if c.loadMap(); c.tiledMap != nil {
+		c.GetEntity().GetTransform().SetDim(engosdl.NewVector(
+			float64(c.tiledMap.Width*c.tiledMap.TileWidth),
+			float64(c.tiledMap.Height*c.tiledMap.TileHeight)))
+	}
+	c.Component.OnAwake()
+}
+
+// OnRender is called for every render tick. Tile layers and tile objects
+// are displayed through the scene camera, skipping tiles not visible.
+func (c *TileMap) OnRender() {
+	if c.tiledMap == nil {
+		return
+	}
+	transform := c.GetEntity().GetTransform()
+	camera := c.GetEntity().GetScene().GetCamera()
+	origin, scale := transform.GetWorldPosition(), transform.GetWorldScale()
+	tw, th := float64(c.tiledMap.TileWidth)*scale.X, float64(c.tiledMap.TileHeight)*scale.Y
+	if tw <= 0 || th <= 0 {
+		return
+	}
+	// Tiles bigger than map cells overlap cells above and to the right.
+	overlapX, overlapY := 0, 0
+	for _, tileset := range c.tiledMap.Tilesets {
+		overlapX = maxInt(overlapX, int(math.Ceil(float64(tileset.TileWidth)/float64(c.tiledMap.TileWidth)))-1)
+		overlapY = maxInt(overlapY, int(math.Ceil(float64(tileset.TileHeight)/float64(c.tiledMap.TileHeight)))-1)
+	}
+	visible := camera.GetVisibleRect()
+	minCol := maxInt(int(math.Floor((visible.X-origin.X)/tw))-overlapX, 0)
+	maxCol := minInt(int(math.Floor((visible.X+visible.W-origin.X)/tw)), c.tiledMap.Width-1)
+	minRow := maxInt(int(math.Floor((visible.Y-origin.Y)/th)), 0)
+	maxRow := minInt(int(math.Floor((visible.Y+visible.H-origin.Y)/th))+overlapY, c.tiledMap.Height-1)
+	for _, layer := range c.tiledMap.Layers {
+		if !layer.Visible {
+			continue
+		}
+		alpha := uint8(math.Round(math.Max(0, math.Min(1, layer.Opacity)) * 255))
+		for row := minRow; row <= maxRow && layer.Type == engosdl.TiledTileLayer; row++ {
+			for col := minCol; col <= maxCol; col++ {
+				if gid := layer.GetTile(col, row); gid != 0 {
+					x, y := origin.X+float64(col)*tw, origin.Y+float64(row+1)*th
+					c.renderTile(gid, x, y, scale, alpha, 0, camera)
+				}
+			}
+		}
+		for _, object := range c.tileObjects[layer] {
+			if object.entity.GetActive() {
+				rect := object.entity.GetTransform().GetRect()
+				c.renderTile(object.gid, rect.X, rect.Y+rect.H, scale, alpha, object.entity.GetTransform().GetWorldRotation(), camera)
+			}
+		}
+	}
+}
+
+// renderTile displays the given tile with the bottom-left corner at the given
+// world position.
+func (c *TileMap) renderTile(gid uint32, x float64, y float64, scale *engosdl.Vector, alpha uint8, rotation float64, camera engosdl.ICamera) {
+	tileset, id := c.tiledMap.GetTileset(gid)
+	texture, ok := c.textures[tileset]
+	if !ok {
+		return
+	}
+	src := tileset.GetTileRect(id)
+	w, h := src.W*scale.X, src.H*scale.Y
+	displayAt := camera.RectToScreen(engosdl.NewRect(x, y-h, w, h))
+	angle := rotation - camera.GetRotation()
+	horizontal := gid&engosdl.TiledFlippedHorizontally != 0
+	vertical := gid&engosdl.TiledFlippedVertically != 0
+	if gid&engosdl.TiledFlippedDiagonally != 0 {
+		// Diagonal flip is a rotation of 90 degrees after a vertical flip.
+		angle += 90
+		horizontal, vertical = vertical, !horizontal
+	}
+	flip := sdl.FLIP_NONE
+	if horizontal {
+		flip |= sdl.FLIP_HORIZONTAL
+	}
+	if vertical {
+		flip |= sdl.FLIP_VERTICAL
+	}
+	texture.SetAlphaMod(alpha)
+	c.renderer.CopyEx(texture,
+		&sdl.Rect{X: int32(src.X), Y: int32(src.Y), W: int32(src.W), H: int32(src.H)},
+		displayAt,
+		angle,
+		nil,
+		flip)
+}
+
+// OnStart is called first time the component is enabled. It creates
+// entities for objects and collisions.
+func (c *TileMap) OnStart() {
+	engosdl.Logger.Trace().Str("component", "tile-map").Str("tile-map", c.GetName()).Msg("OnStart")
+	if !c.GetStarted() {
+		if c.loadMap(); c.tiledMap != nil {
+			c.createEntities()
+		}
+	}
+	c.Component.OnStart()
+}
+
+// SetObjectHandler sets the function called for every entity created for an
+// object. It is called before the entity is added to the scene, so it can be
+// used to add components to the entity.
+func (c *TileMap) SetObjectHandler(handler func(engosdl.IEntity, *engosdl.TiledObject)) {
+	c.objectHandler = handler
+}
+
+// Unmarshal takes a ComponentToMarshal instance and  creates a new entity
+// instance.
+func (c *TileMap) Unmarshal(data map[string]interface{}) {
+	c.Component.Unmarshal(data)
+	c.Filename = data["filename"].(string)
+}
+
+// maxInt returns the maximum of two integers.
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// minInt returns the minimum of two integers.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/jrecuero/engosdl"
 )
 
+// _timerTolerance is the time in seconds a timer can be triggered before its
+// tick, so rounding errors adding update steps do not delay the timer a whole
+// step.
+const _timerTolerance float64 = 1e-9
+
 // ComponentNameTimer is the name to refer timer component.
 var ComponentNameTimer string = reflect.TypeOf(&Timer{}).String()
 
@@ -17,39 +22,45 @@ func init() {
 }
 
 // Timer is the default implementation for the timer component interface.
+// Tick is given in seconds.
 type Timer struct {
 	*engosdl.Component
-	Tick         int `json:"tick"`
-	Times        int `json:"times"`
-	tickCounter  int
+	Tick         float64 `json:"tick"`
+	Times        int     `json:"times"`
+	elapsed      float64
 	timesCounter int
 }
 
 var _ engosdl.ITimer = (*Timer)(nil)
 
 // NewTimer creates a new timer instance.
-func NewTimer(name string, tick int, times int) *Timer {
+func NewTimer(name string, tick float64, times int) *Timer {
 	engosdl.Logger.Trace().Str("timer", name).Msg("new timer")
 	return &Timer{
 		Component:    engosdl.NewComponent(name),
 		Tick:         tick,
-		tickCounter:  0,
+		elapsed:      0,
 		Times:        times,
 		timesCounter: 0,
 	}
 }
 
-// CreateTimer implements timer constructor used by component manager.
+// CreateTimer implements timer constructor used by component manager. Tick
+// can be given as a float64 or as an int number of seconds.
 func CreateTimer(params ...interface{}) engosdl.IComponent {
 	if len(params) == 3 {
-		return NewTimer(params[0].(string), params[1].(int), params[2].(int))
+		tick, _ := params[1].(float64)
+		if seconds, ok := params[1].(int); ok {
+			tick = float64(seconds)
+		}
+		return NewTimer(params[0].(string), tick, params[2].(int))
 	}
 	return NewTimer("", 0, 0)
 }
 
-// GetTick returns the timer tick. Tick is the number of engine frames before
-// the timer has to be triggered.
-func (t *Timer) GetTick() int {
+// GetTick returns the timer tick. Tick is the number of seconds before the
+// timer has to be triggered.
+func (t *Timer) GetTick() float64 {
 	return t.Tick
 }
 
@@ -67,22 +78,37 @@ func (t *Timer) OnAwake() {
 	t.SetDelegate(engosdl.GetDelegateManager().CreateDelegate(t, name))
 }
 
-// OnUpdate is called every engine frame in order to update the component.
+// OnSpawn is called when the timer entity is acquired from its pool. Timer
+// starts again.
+func (t *Timer) OnSpawn() {
+	t.Component.OnSpawn()
+	t.elapsed = 0
+	t.timesCounter = 0
+}
+
+// OnUpdate is called every engine update step in order to update the
+// component.
 func (t *Timer) OnUpdate() {
-	if t.Times == -1 || t.timesCounter < t.Times {
-		if t.tickCounter == t.Tick {
-			t.timesCounter++
-			t.tickCounter = 0
-			engosdl.GetDelegateManager().TriggerDelegate(t.GetDelegate(), false)
-		} else {
-			t.tickCounter++
+	if t.Times != -1 && t.timesCounter >= t.Times {
+		return
+	}
+	t.elapsed += engosdl.GetDeltaTime()
+	for t.elapsed+_timerTolerance >= t.Tick && (t.Times == -1 || t.timesCounter < t.Times) {
+		t.timesCounter++
+		engosdl.GetDelegateManager().TriggerDelegate(t.GetDelegate(), false)
+		if t.Tick <= 0 {
+			// Timer without tick is triggered once every update step.
+			t.elapsed = 0
+			break
 		}
+		// Time elapsed after the tick is kept for the next tick.
+		t.elapsed -= t.Tick
 	}
 }
 
-// SetTick sets the timer tick. This is the number of engine frames before the
-// timer has to be triggered.
-func (t *Timer) SetTick(tick int) {
+// SetTick sets the timer tick. This is the number of seconds before the timer
+// has to be triggered.
+func (t *Timer) SetTick(tick float64) {
 	t.Tick = tick
 }
 
@@ -95,6 +121,6 @@ func (t *Timer) SetTimes(times int) {
 // instance.
 func (t *Timer) Unmarshal(data map[string]interface{}) {
 	t.Component.Unmarshal(data)
-	t.Tick = int(data["tick"].(float64))
+	t.Tick = data["tick"].(float64)
 	t.Times = int(data["times"].(float64))
 }
@@ -0,0 +1,309 @@
+package engosdl
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AtlasFrame represents a named region in a texture atlas. Rect is the region
+// in the atlas texture. Rotated regions are stored in the texture rotated 90
+// degrees clockwise, so Rect width and height are swapped. Trimmed regions
+// have transparent pixels removed, Offset is the position of the region in
+// the original image and SourceSize is the original image size.
+type AtlasFrame struct {
+	Name       string
+	Rect       *Rect
+	Rotated    bool
+	Offset     *Vector
+	SourceSize *Vector
+}
+
+// NewAtlasFrame creates a new atlas frame instance for a region that is not
+// rotated or trimmed.
+func NewAtlasFrame(name string, rect *Rect) *AtlasFrame {
+	return &AtlasFrame{
+		Name:       name,
+		Rect:       rect,
+		Rotated:    false,
+		Offset:     NewVector(0, 0),
+		SourceSize: NewVector(rect.W, rect.H),
+	}
+}
+
+// GetSize returns region size as it has to be displayed, which is different
+// from the atlas texture size for rotated regions.
+func (f *AtlasFrame) GetSize() *Vector {
+	if f.Rotated {
+		return NewVector(f.Rect.H, f.Rect.W)
+	}
+	return NewVector(f.Rect.W, f.Rect.H)
+}
+
+// ITextureAtlas represents the interface for any texture atlas. Texture
+// atlas contains a single image with multiple named regions, so all sprites
+// using the atlas share the same texture.
+type ITextureAtlas interface {
+	IObject
+	Destroy()
+	GetFilename() string
+	GetFrame(string) *AtlasFrame
+	GetFrameNames() []string
+	GetFramesWithPrefix(string) []*AtlasFrame
+	GetImage() string
+	GetResource() IResource
+	GetTexture() ITexture
+	SetResource(IResource)
+}
+
+// TextureAtlas is the default implementation for the texture atlas
+// interface.
+type TextureAtlas struct {
+	*Object
+	filename string
+	image    string
+	frames   map[string]*AtlasFrame
+	resource IResource
+	texture  ITexture
+}
+
+var _ ITextureAtlas = (*TextureAtlas)(nil)
+
+// NewTextureAtlas creates a new texture atlas instance. Image is the atlas
+// image filename and filename is the atlas descriptor filename.
+func NewTextureAtlas(name string, filename string, image string, frames []*AtlasFrame) *TextureAtlas {
+	Logger.Trace().Str("texture-atlas", name).Str("filename", filename).Msg("new texture-atlas")
+	result := &TextureAtlas{
+		Object:   NewObject(name),
+		filename: filename,
+		image:    image,
+		frames:   make(map[string]*AtlasFrame),
+		resource: nil,
+		texture:  nil,
+	}
+	for _, frame := range frames {
+		result.frames[frame.Name] = frame
+	}
+	return result
+}
+
+// Destroy releases the atlas texture.
+func (a *TextureAtlas) Destroy() {
+	Logger.Trace().Str("texture-atlas", a.GetName()).Msg("Destroy")
+	if a.texture != nil {
+		a.texture.Destroy()
+		a.texture = nil
+	}
+}
+
+// GetFilename returns the atlas descriptor filename.
+func (a *TextureAtlas) GetFilename() string {
+	return a.filename
+}
+
+// GetFrame returns the region with the given name. It returns nil if there
+// is not any region with that name.
+func (a *TextureAtlas) GetFrame(name string) *AtlasFrame {
+	return a.frames[name]
+}
+
+// GetFrameNames returns all region names sorted alphabetically.
+func (a *TextureAtlas) GetFrameNames() []string {
+	result := []string{}
+	for name := range a.frames {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetFramesWithPrefix returns all regions with a name starting with the
+// given prefix sorted by name. It can be used to retrieve all regions for an
+// animation.
+func (a *TextureAtlas) GetFramesWithPrefix(prefix string) []*AtlasFrame {
+	result := []*AtlasFrame{}
+	for _, name := range a.GetFrameNames() {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, a.frames[name])
+		}
+	}
+	return result
+}
+
+// GetImage returns the atlas image filename.
+func (a *TextureAtlas) GetImage() string {
+	return a.image
+}
+
+// GetResource returns the resource for the atlas image.
+func (a *TextureAtlas) GetResource() IResource {
+	return a.resource
+}
+
+// GetTexture returns the atlas texture. Texture is created the first time it
+// is required and it is shared by all sprites using the atlas.
+func (a *TextureAtlas) GetTexture() ITexture {
+	if a.texture == nil && a.resource != nil {
+		a.texture = a.resource.GetTextureFromSurface()
+	}
+	return a.texture
+}
+
+// SetResource sets the resource for the atlas image.
+func (a *TextureAtlas) SetResource(resource IResource) {
+	a.resource = resource
+}
+
+// LoadTextureAtlas reads and parses the given atlas descriptor file. Atlas
+// image path is relative to the descriptor file.
+func LoadTextureAtlas(name string, filename string) (*TextureAtlas, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	image, frames, err := ParseTextureAtlas(data)
+	if err != nil {
+		return nil, fmt.Errorf("atlas %s: %w", filename, err)
+	}
+	if image != "" && !filepath.IsAbs(image) {
+		image = filepath.Join(filepath.Dir(filename), image)
+	}
+	return NewTextureAtlas(name, filename, image, frames), nil
+}
+
+// ParseTextureAtlas parses an atlas descriptor and it returns the atlas
+// image filename and all regions. Supported descriptors are TexturePacker
+// JSON, with frames as a hash or as an array, and Starling/Sparrow XML.
+func ParseTextureAtlas(data []byte) (string, []*AtlasFrame, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("empty atlas descriptor")
+	}
+	if data[0] == '<' {
+		return parseAtlasXML(data)
+	}
+	return parseAtlasJSON(data)
+}
+
+// atlasJSONRect is a rectangle in a TexturePacker JSON descriptor.
+type atlasJSONRect struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+// atlasJSONFrame is a region in a TexturePacker JSON descriptor. Filename is
+// used only when frames are given as an array.
+type atlasJSONFrame struct {
+	Filename         string         `json:"filename"`
+	Frame            atlasJSONRect  `json:"frame"`
+	Rotated          bool           `json:"rotated"`
+	Trimmed          bool           `json:"trimmed"`
+	SpriteSourceSize atlasJSONRect  `json:"spriteSourceSize"`
+	SourceSize       *atlasJSONRect `json:"sourceSize"`
+}
+
+// atlasJSON is a TexturePacker JSON descriptor.
+type atlasJSON struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image string `json:"image"`
+	} `json:"meta"`
+}
+
+// parseAtlasJSON parses a TexturePacker JSON descriptor. Frame rectangles
+// for rotated regions are given with the size before rotation.
+func parseAtlasJSON(data []byte) (string, []*AtlasFrame, error) {
+	descriptor := &atlasJSON{}
+	if err := json.Unmarshal(data, descriptor); err != nil {
+		return "", nil, err
+	}
+	frames := []*atlasJSONFrame{}
+	hash := map[string]*atlasJSONFrame{}
+	if err := json.Unmarshal(descriptor.Frames, &frames); err != nil {
+		if err := json.Unmarshal(descriptor.Frames, &hash); err != nil {
+			return "", nil, fmt.Errorf("frames are not a hash or an array")
+		}
+		for name, frame := range hash {
+			frame.Filename = name
+			frames = append(frames, frame)
+		}
+		sort.Slice(frames, func(i, j int) bool {
+			return frames[i].Filename < frames[j].Filename
+		})
+	}
+	result := []*AtlasFrame{}
+	for _, frame := range frames {
+		rect := NewRect(frame.Frame.X, frame.Frame.Y, frame.Frame.W, frame.Frame.H)
+		if frame.Rotated {
+			rect.W, rect.H = rect.H, rect.W
+		}
+		atlasFrame := NewAtlasFrame(frame.Filename, rect)
+		atlasFrame.Rotated = frame.Rotated
+		atlasFrame.SourceSize = atlasFrame.GetSize()
+		if frame.Trimmed {
+			atlasFrame.Offset = NewVector(frame.SpriteSourceSize.X, frame.SpriteSourceSize.Y)
+		}
+		if frame.SourceSize != nil {
+			atlasFrame.SourceSize = NewVector(frame.SourceSize.W, frame.SourceSize.H)
+		}
+		result = append(result, atlasFrame)
+	}
+	return descriptor.Meta.Image, result, nil
+}
+
+// atlasXML is a Starling/Sparrow XML descriptor.
+type atlasXML struct {
+	ImagePath   string `xml:"imagePath,attr"`
+	SubTextures []struct {
+		Name        string  `xml:"name,attr"`
+		X           float64 `xml:"x,attr"`
+		Y           float64 `xml:"y,attr"`
+		Width       float64 `xml:"width,attr"`
+		Height      float64 `xml:"height,attr"`
+		FrameX      float64 `xml:"frameX,attr"`
+		FrameY      float64 `xml:"frameY,attr"`
+		FrameWidth  float64 `xml:"frameWidth,attr"`
+		FrameHeight float64 `xml:"frameHeight,attr"`
+		Rotated     bool    `xml:"rotated,attr"`
+	} `xml:"SubTexture"`
+}
+
+// parseAtlasXML parses a Starling/Sparrow XML descriptor. Region width and
+// height are given as stored in the texture.
+func parseAtlasXML(data []byte) (string, []*AtlasFrame, error) {
+	descriptor := &atlasXML{}
+	if err := xml.Unmarshal(data, descriptor); err != nil {
+		return "", nil, err
+	}
+	result := []*AtlasFrame{}
+	for _, subTexture := range descriptor.SubTextures {
+		frame := NewAtlasFrame(subTexture.Name, NewRect(subTexture.X, subTexture.Y, subTexture.Width, subTexture.Height))
+		frame.Rotated = subTexture.Rotated
+		frame.SourceSize = frame.GetSize()
+		if subTexture.FrameWidth != 0 && subTexture.FrameHeight != 0 {
+			frame.Offset = NewVector(-subTexture.FrameX, -subTexture.FrameY)
+			frame.SourceSize = NewVector(subTexture.FrameWidth, subTexture.FrameHeight)
+		}
+		result = append(result, frame)
+	}
+	return descriptor.ImagePath, result, nil
+}
+
+// GetFormatFromFilename returns the image format for the given filename
+// based on its extension.
+func GetFormatFromFilename(filename string) int {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".png":
+		return FormatPNG
+	case ".jpg", ".jpeg":
+		return FormatJPG
+	}
+	return FormatBMP
+}
@@ -0,0 +1,95 @@
+package engosdl_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+)
+
+const atlasHashJSON = `{
+	"frames": {
+		"walk-02": {"frame": {"x": 32, "y": 0, "w": 16, "h": 24}, "rotated": true, "trimmed": false,
+			"spriteSourceSize": {"x": 0, "y": 0, "w": 16, "h": 24}, "sourceSize": {"w": 16, "h": 24}},
+		"walk-01": {"frame": {"x": 0, "y": 0, "w": 32, "h": 32}, "rotated": false, "trimmed": true,
+			"spriteSourceSize": {"x": 2, "y": 4, "w": 32, "h": 32}, "sourceSize": {"w": 36, "h": 40}}
+	},
+	"meta": {"image": "sheet.png"}
+}`
+
+const atlasArrayJSON = `{
+	"frames": [
+		{"filename": "walk-01", "frame": {"x": 0, "y": 0, "w": 32, "h": 32}, "rotated": false, "trimmed": true,
+			"spriteSourceSize": {"x": 2, "y": 4, "w": 32, "h": 32}, "sourceSize": {"w": 36, "h": 40}},
+		{"filename": "walk-02", "frame": {"x": 32, "y": 0, "w": 16, "h": 24}, "rotated": true, "trimmed": false,
+			"spriteSourceSize": {"x": 0, "y": 0, "w": 16, "h": 24}, "sourceSize": {"w": 16, "h": 24}}
+	],
+	"meta": {"image": "sheet.png"}
+}`
+
+const atlasXML = `<?xml version="1.0" encoding="UTF-8"?>
+<TextureAtlas imagePath="sheet.png">
+	<SubTexture name="walk-01" x="0" y="0" width="32" height="32" frameX="-2" frameY="-4" frameWidth="36" frameHeight="40"/>
+	<SubTexture name="walk-02" x="32" y="0" width="24" height="16" rotated="true"/>
+</TextureAtlas>`
+
+func TestTextureAtlas_Parse(t *testing.T) {
+	exp := []*engosdl.AtlasFrame{
+		{Name: "walk-01", Rect: engosdl.NewRect(0, 0, 32, 32), Offset: engosdl.NewVector(2, 4), SourceSize: engosdl.NewVector(36, 40)},
+		{Name: "walk-02", Rect: engosdl.NewRect(32, 0, 24, 16), Rotated: true, Offset: engosdl.NewVector(0, 0), SourceSize: engosdl.NewVector(16, 24)},
+	}
+	for name, data := range map[string]string{"hash": atlasHashJSON, "array": atlasArrayJSON, "xml": atlasXML} {
+		image, got, err := engosdl.ParseTextureAtlas([]byte(data))
+		if err != nil {
+			t.Errorf("error parsing %s atlas: %v", name, err)
+			continue
+		}
+		if image != "sheet.png" {
+			t.Errorf("error parsing %s atlas image\nexp: %s\ngot: %s\n", name, "sheet.png", image)
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("error parsing %s atlas frames\nexp: %v %v\ngot: %v %v\n", name, *exp[0], *exp[1], *got[0], *got[1])
+		}
+	}
+	if size := exp[1].GetSize(); size.X != 16 || size.Y != 24 {
+		t.Errorf("error getting rotated frame size\nexp: %v\ngot: %v\n", engosdl.NewVector(16, 24), size)
+	}
+	if _, _, err := engosdl.ParseTextureAtlas([]byte(`{"frames": 1}`)); err == nil {
+		t.Errorf("error parsing invalid atlas")
+	}
+}
+
+func TestTextureAtlas_Load(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atlas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "sheet.json")
+	if err := ioutil.WriteFile(filename, []byte(atlasHashJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	atlas, err := engosdl.LoadTextureAtlas("atlas", filename)
+	if err != nil {
+		t.Fatalf("error loading atlas: %v", err)
+	}
+	if exp := filepath.Join(dir, "sheet.png"); atlas.GetImage() != exp {
+		t.Errorf("error getting atlas image\nexp: %s\ngot: %s\n", exp, atlas.GetImage())
+	}
+	if exp, got := []string{"walk-01", "walk-02"}, atlas.GetFrameNames(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error getting frame names\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if frame := atlas.GetFrame("walk-02"); frame == nil || !frame.Rotated {
+		t.Errorf("error getting frame by name")
+	}
+	clip := engosdl.NewAnimationClipFromAtlas("walk", engosdl.AnimationLoop, atlas, "walk-", 0.1)
+	if len(clip.Frames) != 2 || clip.Frames[1].Region != "walk-02" {
+		t.Errorf("error creating clip from atlas\nexp: %d frames\ngot: %d frames\n", 2, len(clip.Frames))
+	}
+	if _, err := engosdl.LoadTextureAtlas("missing", filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("error loading missing atlas")
+	}
+}
@@ -0,0 +1,243 @@
+package engosdl
+
+import (
+	"math"
+	"sort"
+)
+
+// CollisionPair represents two colliders that could be colliding. I and J
+// are indexes in the collection of rectangles given to the broadphase, and I
+// is always lower than J.
+type CollisionPair struct {
+	I int
+	J int
+}
+
+// IBroadphase represents the interface for any collision broadphase.
+// Broadphase returns candidate pairs of rectangles that could be colliding,
+// so only those pairs have to be checked by the narrowphase. Query returns
+// indexes for rectangles given in the last GetPairs call that overlap the
+// given area.
+type IBroadphase interface {
+	GetPairs([]*Rect) []CollisionPair
+	Query(*Rect) []int
+}
+
+// broadphaseMargin is added to every rectangle in order to avoid missing
+// pairs because of rounding done in the narrowphase.
+const broadphaseMargin float64 = 1
+
+// overlap returns if two rectangles overlap, including the broadphase
+// margin.
+func overlap(a *Rect, b *Rect) bool {
+	return a.X-broadphaseMargin <= b.X+b.W+broadphaseMargin &&
+		b.X-broadphaseMargin <= a.X+a.W+broadphaseMargin &&
+		a.Y-broadphaseMargin <= b.Y+b.H+broadphaseMargin &&
+		b.Y-broadphaseMargin <= a.Y+a.H+broadphaseMargin
+}
+
+// sortPairs sorts pairs in the same order they are returned by the brute
+// force broadphase, so delegates are always triggered in the same order.
+func sortPairs(pairs []CollisionPair) []CollisionPair {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].I != pairs[j].I {
+			return pairs[i].I < pairs[j].I
+		}
+		return pairs[i].J < pairs[j].J
+	})
+	return pairs
+}
+
+// BruteForceBroadphase is the broadphase that checks every rectangle against
+// all others.
+type BruteForceBroadphase struct {
+	rects []*Rect
+}
+
+var _ IBroadphase = (*BruteForceBroadphase)(nil)
+
+// NewBruteForceBroadphase creates a new brute force broadphase instance.
+func NewBruteForceBroadphase() *BruteForceBroadphase {
+	return &BruteForceBroadphase{}
+}
+
+// GetPairs returns all pairs of overlapping rectangles.
+func (b *BruteForceBroadphase) GetPairs(rects []*Rect) []CollisionPair {
+	b.rects = rects
+	pairs := []CollisionPair{}
+	for i := 0; i < len(rects); i++ {
+		for j := i + 1; j < len(rects); j++ {
+			if overlap(rects[i], rects[j]) {
+				pairs = append(pairs, CollisionPair{I: i, J: j})
+			}
+		}
+	}
+	return pairs
+}
+
+// Query returns indexes for all rectangles overlapping the given area.
+func (b *BruteForceBroadphase) Query(area *Rect) []int {
+	result := []int{}
+	for i, rect := range b.rects {
+		if overlap(rect, area) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// cellKey identifies a cell in the spatial hash grid.
+type cellKey struct {
+	x int
+	y int
+}
+
+// SpatialHashBroadphase is the broadphase using a uniform grid. Every
+// rectangle is placed in all cells it covers, and only rectangles sharing a
+// cell are checked.
+type SpatialHashBroadphase struct {
+	cellSize float64
+	cells    map[cellKey][]int
+	rects    []*Rect
+}
+
+var _ IBroadphase = (*SpatialHashBroadphase)(nil)
+
+// NewSpatialHashBroadphase creates a new spatial hash broadphase instance.
+// Cell size should be close to the size of most colliders.
+func NewSpatialHashBroadphase(cellSize float64) *SpatialHashBroadphase {
+	if cellSize <= 0 {
+		cellSize = _broadphaseCellSize
+	}
+	return &SpatialHashBroadphase{
+		cellSize: cellSize,
+		cells:    make(map[cellKey][]int),
+	}
+}
+
+// GetCellSize returns the grid cell size.
+func (b *SpatialHashBroadphase) GetCellSize() float64 {
+	return b.cellSize
+}
+
+// GetPairs returns all pairs of overlapping rectangles.
+func (b *SpatialHashBroadphase) GetPairs(rects []*Rect) []CollisionPair {
+	for key, cell := range b.cells {
+		if len(cell) == 0 {
+			delete(b.cells, key)
+		} else {
+			b.cells[key] = cell[:0]
+		}
+	}
+	b.rects = rects
+	pairs := []CollisionPair{}
+	visited := make(map[CollisionPair]bool)
+	for i, rect := range rects {
+		minX, minY, maxX, maxY := b.getCells(rect)
+		for x := minX; x <= maxX; x++ {
+			for y := minY; y <= maxY; y++ {
+				key := cellKey{x: x, y: y}
+				for _, j := range b.cells[key] {
+					pair := CollisionPair{I: j, J: i}
+					if !visited[pair] && overlap(rects[j], rect) {
+						visited[pair] = true
+						pairs = append(pairs, pair)
+					}
+				}
+				b.cells[key] = append(b.cells[key], i)
+			}
+		}
+	}
+	return sortPairs(pairs)
+}
+
+// getCells returns the range of cells covered by the given rectangle.
+func (b *SpatialHashBroadphase) getCells(rect *Rect) (int, int, int, int) {
+	minX := int(math.Floor((rect.X - broadphaseMargin) / b.cellSize))
+	minY := int(math.Floor((rect.Y - broadphaseMargin) / b.cellSize))
+	maxX := int(math.Floor((rect.X + rect.W + broadphaseMargin) / b.cellSize))
+	maxY := int(math.Floor((rect.Y + rect.H + broadphaseMargin) / b.cellSize))
+	return minX, minY, maxX, maxY
+}
+
+// Query returns indexes for all rectangles overlapping the given area.
+func (b *SpatialHashBroadphase) Query(area *Rect) []int {
+	result := []int{}
+	visited := make(map[int]bool)
+	minX, minY, maxX, maxY := b.getCells(area)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			for _, i := range b.cells[cellKey{x: x, y: y}] {
+				if !visited[i] && overlap(b.rects[i], area) {
+					visited[i] = true
+					result = append(result, i)
+				}
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// SweepAndPruneBroadphase is the broadphase that sorts rectangles along the
+// X-axis and only checks rectangles overlapping in that axis.
+type SweepAndPruneBroadphase struct {
+	order []int
+	rects []*Rect
+}
+
+var _ IBroadphase = (*SweepAndPruneBroadphase)(nil)
+
+// NewSweepAndPruneBroadphase creates a new sweep and prune broadphase
+// instance.
+func NewSweepAndPruneBroadphase() *SweepAndPruneBroadphase {
+	return &SweepAndPruneBroadphase{}
+}
+
+// GetPairs returns all pairs of overlapping rectangles.
+func (b *SweepAndPruneBroadphase) GetPairs(rects []*Rect) []CollisionPair {
+	b.rects = rects
+	b.order = b.order[:0]
+	for i := range rects {
+		b.order = append(b.order, i)
+	}
+	sort.Slice(b.order, func(i, j int) bool {
+		return rects[b.order[i]].X < rects[b.order[j]].X
+	})
+	pairs := []CollisionPair{}
+	for i, indexI := range b.order {
+		rectI := rects[indexI]
+		maxX := rectI.X + rectI.W + 2*broadphaseMargin
+		for _, indexJ := range b.order[i+1:] {
+			if rects[indexJ].X > maxX {
+				break
+			}
+			if overlap(rectI, rects[indexJ]) {
+				if indexI < indexJ {
+					pairs = append(pairs, CollisionPair{I: indexI, J: indexJ})
+				} else {
+					pairs = append(pairs, CollisionPair{I: indexJ, J: indexI})
+				}
+			}
+		}
+	}
+	return sortPairs(pairs)
+}
+
+// Query returns indexes for all rectangles overlapping the given area.
+// Rectangles are sorted along the X-axis, so only rectangles starting before
+// the area end are checked.
+func (b *SweepAndPruneBroadphase) Query(area *Rect) []int {
+	maxX := area.X + area.W + 2*broadphaseMargin
+	last := sort.Search(len(b.order), func(i int) bool {
+		return b.rects[b.order[i]].X > maxX
+	})
+	result := []int{}
+	for _, i := range b.order[:last] {
+		if overlap(b.rects[i], area) {
+			result = append(result, i)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
@@ -0,0 +1,154 @@
+package engosdl_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+func randomRects(n int, size float64, seed int64) []*engosdl.Rect {
+	r := rand.New(rand.NewSource(seed))
+	rects := []*engosdl.Rect{}
+	for i := 0; i < n; i++ {
+		rects = append(rects, engosdl.NewRect(r.Float64()*800-size, r.Float64()*600-size, size, size))
+	}
+	return rects
+}
+
+func TestBroadphase_SamePairs(t *testing.T) {
+	rects := randomRects(300, 24, 1)
+	exp := engosdl.NewBruteForceBroadphase().GetPairs(rects)
+	if len(exp) == 0 {
+		t.Errorf("error getting pairs from brute force broadphase")
+	}
+	broadphases := map[string]engosdl.IBroadphase{
+		"spatial-hash":    engosdl.NewSpatialHashBroadphase(32),
+		"sweep-and-prune": engosdl.NewSweepAndPruneBroadphase(),
+	}
+	for name, broadphase := range broadphases {
+		// Run twice to check broadphase internal data is reset.
+		broadphase.GetPairs(randomRects(50, 60, 2))
+		if got := broadphase.GetPairs(rects); !reflect.DeepEqual(exp, got) {
+			t.Errorf("error getting pairs from %s broadphase\nexp: %d pairs\ngot: %d pairs\n", name, len(exp), len(got))
+		}
+	}
+}
+
+func TestBroadphase_LargeRect(t *testing.T) {
+	rects := []*engosdl.Rect{
+		engosdl.NewRect(0, 0, 500, 500),
+		engosdl.NewRect(400, 400, 10, 10),
+		engosdl.NewRect(-50, -50, 10, 10),
+	}
+	exp := []engosdl.CollisionPair{{I: 0, J: 1}}
+	if got := engosdl.NewSpatialHashBroadphase(16).GetPairs(rects); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error getting pairs\nexp: %v\ngot: %v\n", exp, got)
+	}
+}
+
+func TestBroadphase_Query(t *testing.T) {
+	rects := randomRects(300, 24, 1)
+	area := engosdl.NewRect(200, 150, 120, 80)
+	exp := engosdl.NewBruteForceBroadphase()
+	exp.GetPairs(rects)
+	broadphases := map[string]engosdl.IBroadphase{
+		"spatial-hash":    engosdl.NewSpatialHashBroadphase(32),
+		"sweep-and-prune": engosdl.NewSweepAndPruneBroadphase(),
+	}
+	for name, broadphase := range broadphases {
+		broadphase.GetPairs(rects)
+		if got := broadphase.Query(area); !reflect.DeepEqual(exp.Query(area), got) {
+			t.Errorf("error querying %s broadphase\nexp: %v\ngot: %v\n", name, exp.Query(area), got)
+		}
+	}
+}
+
+func benchmarkBroadphase(b *testing.B, broadphase engosdl.IBroadphase, n int) {
+	rects := randomRects(n, 16, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		broadphase.GetPairs(rects)
+	}
+}
+
+func BenchmarkBroadphase_BruteForce100(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewBruteForceBroadphase(), 100)
+}
+
+func BenchmarkBroadphase_BruteForce500(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewBruteForceBroadphase(), 500)
+}
+
+func BenchmarkBroadphase_BruteForce2000(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewBruteForceBroadphase(), 2000)
+}
+
+func BenchmarkBroadphase_SpatialHash100(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSpatialHashBroadphase(32), 100)
+}
+
+func BenchmarkBroadphase_SpatialHash500(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSpatialHashBroadphase(32), 500)
+}
+
+func BenchmarkBroadphase_SpatialHash2000(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSpatialHashBroadphase(32), 2000)
+}
+
+func BenchmarkBroadphase_SweepAndPrune100(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSweepAndPruneBroadphase(), 100)
+}
+
+func BenchmarkBroadphase_SweepAndPrune500(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSweepAndPruneBroadphase(), 500)
+}
+
+func BenchmarkBroadphase_SweepAndPrune2000(b *testing.B) {
+	benchmarkBroadphase(b, engosdl.NewSweepAndPruneBroadphase(), 2000)
+}
+
+// benchmarkCheckCollisions runs a full scene update step, which checks
+// collisions for all colliders in the scene with the given broadphase. Brute
+// force broadphase checks all pairs, like the scene did before broadphases
+// were added.
+func benchmarkCheckCollisions(b *testing.B, broadphase engosdl.IBroadphase, n int) {
+	engine := newTestEngine(b)
+	scene := engosdl.NewScene("benchmark-scene", "test")
+	scene.SetCollisionMode(engosdl.ModeBox)
+	scene.SetBroadphase(broadphase)
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		for _, rect := range randomRects(n, 16, 1) {
+			entity := engosdl.NewEntity("box")
+			entity.GetTransform().SetPositionXY(rect.X, rect.Y)
+			entity.GetTransform().SetDimXY(rect.W, rect.H)
+			entity.AddComponent(components.NewCollider2D("box/collider-2D"))
+			scene.AddEntity(entity)
+		}
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scene.OnUpdate()
+	}
+}
+
+func BenchmarkScene_CheckCollisionsBruteForce500(b *testing.B) {
+	benchmarkCheckCollisions(b, engosdl.NewBruteForceBroadphase(), 500)
+}
+
+func BenchmarkScene_CheckCollisionsBruteForce2000(b *testing.B) {
+	benchmarkCheckCollisions(b, engosdl.NewBruteForceBroadphase(), 2000)
+}
+
+func BenchmarkScene_CheckCollisionsSweepAndPrune500(b *testing.B) {
+	benchmarkCheckCollisions(b, engosdl.NewSweepAndPruneBroadphase(), 500)
+}
+
+func BenchmarkScene_CheckCollisionsSweepAndPrune2000(b *testing.B) {
+	benchmarkCheckCollisions(b, engosdl.NewSweepAndPruneBroadphase(), 2000)
+}
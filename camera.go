@@ -0,0 +1,270 @@
+package engosdl
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ICamera represents the interface for the scene camera. Camera converts
+// world coordinates to screen coordinates and viceversa.
+type ICamera interface {
+	IObject
+	Follow(IEntity)
+	GetBounds() *Rect
+	GetPosition() *Vector
+	GetRotation() float64
+	GetSmoothing() float64
+	GetTarget() IEntity
+	GetViewport() *Vector
+	GetVisibleRect() *Rect
+	GetZoom() float64
+	OnUpdate()
+	RectToScreen(*Rect) *sdl.Rect
+	ScreenToWorld(*Vector) *Vector
+	SetBounds(*Rect) ICamera
+	SetPosition(*Vector) ICamera
+	SetRotation(float64) ICamera
+	SetSmoothing(float64) ICamera
+	SetViewport(*Vector) ICamera
+	SetZoom(float64) ICamera
+	WorldToScreen(*Vector) *Vector
+}
+
+// Camera is the default implementation for the camera interface.
+// Position is the world coordinate displayed at the top-left corner of the
+// screen when zoom is 1 and there is not rotation. Zoom and rotation are
+// applied around the center of the viewport. Rotation is given in degrees.
+// Position is saved at the start of every update step, so screen conversions
+// interpolate it like entity transforms when rendering.
+type Camera struct {
+	*Object
+	position  *Vector
+	previous  *Vector
+	zoom      float64
+	rotation  float64
+	bounds    *Rect
+	viewport  *Vector
+	target    IEntity
+	smoothing float64
+}
+
+var _ ICamera = (*Camera)(nil)
+
+// NewCamera creates a new camera instance. By default camera does not
+// change any coordinate, so world coordinates are screen coordinates.
+func NewCamera(name string) *Camera {
+	Logger.Trace().Str("camera", name).Msg("new camera")
+	return &Camera{
+		Object:    NewObject(name),
+		position:  NewVector(0, 0),
+		previous:  nil,
+		zoom:      1,
+		rotation:  0,
+		bounds:    nil,
+		viewport:  nil,
+		target:    nil,
+		smoothing: 0,
+	}
+}
+
+// clamp moves the camera to keep the visible area inside camera bounds.
+func (c *Camera) clamp() {
+	if c.bounds == nil {
+		return
+	}
+	w, h := c.GetViewport().Get()
+	halfW := w / (2 * c.zoom)
+	halfH := h / (2 * c.zoom)
+	centerX := clampCenter(c.position.X+w/2, halfW, c.bounds.X, c.bounds.W)
+	centerY := clampCenter(c.position.Y+h/2, halfH, c.bounds.Y, c.bounds.H)
+	c.position = NewVector(centerX-w/2, centerY-h/2)
+}
+
+// clampCenter returns the center value that keeps the given half extent
+// inside bounds. If bounds are smaller than the extent, bounds center is
+// used.
+func clampCenter(center float64, half float64, origin float64, size float64) float64 {
+	if size < 2*half {
+		return origin + size/2
+	}
+	return math.Max(origin+half, math.Min(origin+size-half, center))
+}
+
+// Follow sets the entity camera has to follow. Camera is centered in the
+// entity at every update. Passing nil stops following any entity.
+func (c *Camera) Follow(target IEntity) {
+	c.target = target
+}
+
+// GetBounds returns world rectangle camera can not display outside of.
+func (c *Camera) GetBounds() *Rect {
+	return c.bounds
+}
+
+// GetPosition returns camera position.
+func (c *Camera) GetPosition() *Vector {
+	return c.position
+}
+
+// getRenderPosition returns camera position interpolated between the
+// position saved at the start of the last update step and the current one.
+func (c *Camera) getRenderPosition() *Vector {
+	if c.previous == nil {
+		return c.position
+	}
+	return c.previous.Lerp(c.position, GetAlpha())
+}
+
+// GetRotation returns camera rotation in degrees.
+func (c *Camera) GetRotation() float64 {
+	return c.rotation
+}
+
+// GetSmoothing returns the follow smoothing. Zero value moves the camera to
+// the target at once, higher values move the camera faster to the target.
+func (c *Camera) GetSmoothing() float64 {
+	return c.smoothing
+}
+
+// GetTarget returns the entity camera is following.
+func (c *Camera) GetTarget() IEntity {
+	return c.target
+}
+
+// GetViewport returns the camera viewport dimensions. If viewport has not
+// been set, engine window dimensions are used.
+func (c *Camera) GetViewport() *Vector {
+	if c.viewport != nil {
+		return c.viewport
+	}
+	if engine := GetEngine(); engine != nil {
+		return NewVector(float64(engine.GetWidth()), float64(engine.GetHeight()))
+	}
+	return NewVector(0, 0)
+}
+
+// GetVisibleRect returns the smallest world rectangle containing the area
+// displayed by the camera.
+func (c *Camera) GetVisibleRect() *Rect {
+	w, h := c.GetViewport().Get()
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, corner := range []*Vector{NewVector(0, 0), NewVector(w, 0), NewVector(0, h), NewVector(w, h)} {
+		pos := c.ScreenToWorld(corner)
+		minX, minY = math.Min(minX, pos.X), math.Min(minY, pos.Y)
+		maxX, maxY = math.Max(maxX, pos.X), math.Max(maxY, pos.Y)
+	}
+	return NewRect(minX, minY, maxX-minX, maxY-minY)
+}
+
+// GetZoom returns camera zoom.
+func (c *Camera) GetZoom() float64 {
+	return c.zoom
+}
+
+// OnUpdate moves the camera to the target being followed and clamps camera
+// inside bounds.
+func (c *Camera) OnUpdate() {
+	c.previous = NewVector(c.position.X, c.position.Y)
+	if c.target != nil && c.target.GetActive() {
+		w, h := c.GetViewport().Get()
+		rect := c.target.GetTransform().GetRect()
+		x := rect.X + rect.W/2 - w/2
+		y := rect.Y + rect.H/2 - h/2
+		if c.smoothing > 0 {
+			factor := 1 - math.Exp(-c.smoothing*GetDeltaTime())
+			x = c.position.X + (x-c.position.X)*factor
+			y = c.position.Y + (y-c.position.Y)*factor
+		}
+		c.position = NewVector(x, y)
+	}
+	c.clamp()
+}
+
+// RectToScreen returns the screen rectangle for the given world rectangle.
+// Rectangle is scaled by the camera zoom and placed around the screen
+// position of its center. Camera rotation has to be applied by the caller
+// when rendering.
+func (c *Camera) RectToScreen(rect *Rect) *sdl.Rect {
+	center := c.WorldToScreen(NewVector(rect.X+rect.W/2, rect.Y+rect.H/2))
+	w := rect.W * c.zoom
+	h := rect.H * c.zoom
+	return &sdl.Rect{
+		X: int32(math.Round(center.X - w/2)),
+		Y: int32(math.Round(center.Y - h/2)),
+		W: int32(math.Round(w)),
+		H: int32(math.Round(h)),
+	}
+}
+
+// rotate rotates the given coordinates by the given angle in degrees.
+func rotate(x float64, y float64, angle float64) (float64, float64) {
+	if angle == 0 {
+		return x, y
+	}
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// ScreenToWorld converts the given screen position to world position.
+func (c *Camera) ScreenToWorld(pos *Vector) *Vector {
+	w, h := c.GetViewport().Get()
+	x, y := rotate(pos.X-w/2, pos.Y-h/2, c.rotation)
+	position := c.getRenderPosition()
+	return NewVector(x/c.zoom+position.X+w/2, y/c.zoom+position.Y+h/2)
+}
+
+// SetBounds sets world rectangle camera can not display outside of.
+func (c *Camera) SetBounds(bounds *Rect) ICamera {
+	c.bounds = bounds
+	c.clamp()
+	return c
+}
+
+// SetPosition sets camera position. Camera is moved to the given position
+// without any interpolation.
+func (c *Camera) SetPosition(position *Vector) ICamera {
+	c.position = position
+	c.previous = nil
+	c.clamp()
+	return c
+}
+
+// SetRotation sets camera rotation in degrees.
+func (c *Camera) SetRotation(rotation float64) ICamera {
+	c.rotation = rotation
+	return c
+}
+
+// SetSmoothing sets the follow smoothing.
+func (c *Camera) SetSmoothing(smoothing float64) ICamera {
+	c.smoothing = smoothing
+	return c
+}
+
+// SetViewport sets the camera viewport dimensions.
+func (c *Camera) SetViewport(viewport *Vector) ICamera {
+	c.viewport = viewport
+	c.clamp()
+	return c
+}
+
+// SetZoom sets the camera zoom. Zoom has to be greater than zero.
+func (c *Camera) SetZoom(zoom float64) ICamera {
+	if zoom > 0 {
+		c.zoom = zoom
+		c.clamp()
+	}
+	return c
+}
+
+// WorldToScreen converts the given world position to screen position.
+func (c *Camera) WorldToScreen(pos *Vector) *Vector {
+	w, h := c.GetViewport().Get()
+	position := c.getRenderPosition()
+	x := (pos.X - position.X - w/2) * c.zoom
+	y := (pos.Y - position.Y - h/2) * c.zoom
+	x, y = rotate(x, y, -c.rotation)
+	return NewVector(x+w/2, y+h/2)
+}
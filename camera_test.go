@@ -0,0 +1,91 @@
+package engosdl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func equalVector(a *engosdl.Vector, b *engosdl.Vector) bool {
+	return math.Abs(a.X-b.X) < 0.0001 && math.Abs(a.Y-b.Y) < 0.0001
+}
+
+func TestCamera_WorldToScreen(t *testing.T) {
+	camera := engosdl.NewCamera("test-camera")
+	camera.SetViewport(engosdl.NewVector(200, 100))
+	pos := engosdl.NewVector(30, 40)
+	if got := camera.WorldToScreen(pos); !equalVector(got, pos) {
+		t.Errorf("error with default camera\nexp: %v\ngot: %v\n", pos, got)
+	}
+	camera.SetPosition(engosdl.NewVector(10, 20))
+	if got, exp := camera.WorldToScreen(pos), engosdl.NewVector(20, 20); !equalVector(got, exp) {
+		t.Errorf("error with camera position\nexp: %v\ngot: %v\n", exp, got)
+	}
+	camera.SetPosition(engosdl.NewVector(0, 0)).SetZoom(2)
+	if got, exp := camera.WorldToScreen(engosdl.NewVector(100, 50)), engosdl.NewVector(100, 50); !equalVector(got, exp) {
+		t.Errorf("error with camera zoom center\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if got, exp := camera.WorldToScreen(engosdl.NewVector(110, 50)), engosdl.NewVector(120, 50); !equalVector(got, exp) {
+		t.Errorf("error with camera zoom\nexp: %v\ngot: %v\n", exp, got)
+	}
+	camera.SetRotation(90)
+	for _, pos := range []*engosdl.Vector{engosdl.NewVector(0, 0), engosdl.NewVector(110, 50), engosdl.NewVector(-30, 75)} {
+		if got := camera.ScreenToWorld(camera.WorldToScreen(pos)); !equalVector(got, pos) {
+			t.Errorf("error converting screen to world\nexp: %v\ngot: %v\n", pos, got)
+		}
+	}
+}
+
+func TestCamera_FollowAndBounds(t *testing.T) {
+	camera := engosdl.NewCamera("test-camera")
+	camera.SetViewport(engosdl.NewVector(200, 100))
+	camera.SetBounds(engosdl.NewRect(0, 0, 1000, 500))
+	target := engosdl.NewEntity("target")
+	target.GetTransform().SetPositionXY(490, 240).SetDimXY(20, 20)
+	camera.Follow(target)
+	camera.OnUpdate()
+	if got, exp := camera.GetPosition(), engosdl.NewVector(400, 200); !equalVector(got, exp) {
+		t.Errorf("error following target\nexp: %v\ngot: %v\n", exp, got)
+	}
+	target.GetTransform().SetPositionXY(0, 0)
+	camera.OnUpdate()
+	if got, exp := camera.GetPosition(), engosdl.NewVector(0, 0); !equalVector(got, exp) {
+		t.Errorf("error clamping camera to bounds\nexp: %v\ngot: %v\n", exp, got)
+	}
+	camera.SetZoom(0.1)
+	if got, exp := camera.GetPosition(), engosdl.NewVector(400, 200); !equalVector(got, exp) {
+		t.Errorf("error centering camera in bounds\nexp: %v\ngot: %v\n", exp, got)
+	}
+}
+
+func TestCamera_Render(t *testing.T) {
+	engine := newTestEngine(t)
+	scene := engosdl.NewScene("test-camera-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		box := engosdl.NewEntity("box")
+		box.GetTransform().SetPositionXY(190, 140)
+		box.AddComponent(components.NewBox("box/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{G: 255, A: 255}, true))
+		scene.AddEntity(box)
+		scene.GetCamera().SetZoom(2).SetPosition(engosdl.NewVector(-10, 0))
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	exp := sdl.Rect{X: 200, Y: 130, W: 40, H: 40}
+	found := false
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect && drawCall.Color.G == 255 {
+			found = true
+			if *drawCall.Dst != exp {
+				t.Errorf("error rendering through camera\nexp: %v\ngot: %v\n", exp, *drawCall.Dst)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("error rendering box through camera")
+	}
+}
@@ -0,0 +1,225 @@
+package engosdl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Collision categories and masks.
+const (
+	// CollisionCategoryDefault is the category for any collider that has not
+	// set any category. It is the category for the default layer.
+	CollisionCategoryDefault uint32 = 1
+	// CollisionMaskAll is the mask for colliders colliding with any category.
+	CollisionMaskAll uint32 = 0xFFFFFFFF
+	// CollisionLayerDefault is the name for the default collision layer.
+	CollisionLayerDefault string = "default"
+	// maxCollisionLayers is the maximum number of collision layers.
+	maxCollisionLayers int = 32
+)
+
+// CollisionFilter contains category and mask bits for a collider. Category
+// identifies layers the collider belongs to, mask identifies layers the
+// collider collides with. Two colliders collide only if the category for
+// every collider is in the other collider mask.
+type CollisionFilter struct {
+	Category uint32 `json:"category"`
+	Mask     uint32 `json:"mask"`
+	Trigger  bool   `json:"trigger"`
+}
+
+// NewCollisionFilter creates a new collision filter instance that collides
+// with any category.
+func NewCollisionFilter() CollisionFilter {
+	return CollisionFilter{
+		Category: CollisionCategoryDefault,
+		Mask:     CollisionMaskAll,
+	}
+}
+
+// GetCategory returns category bits.
+func (f *CollisionFilter) GetCategory() uint32 {
+	return f.Category
+}
+
+// GetMask returns mask bits.
+func (f *CollisionFilter) GetMask() uint32 {
+	return f.Mask
+}
+
+// IsTrigger returns if the collider is a trigger.
+func (f *CollisionFilter) IsTrigger() bool {
+	return f.Trigger
+}
+
+// SetCategory sets category bits.
+func (f *CollisionFilter) SetCategory(category uint32) {
+	f.Category = category
+}
+
+// SetMask sets mask bits.
+func (f *CollisionFilter) SetMask(mask uint32) {
+	f.Mask = mask
+}
+
+// SetTrigger sets if the collider is a trigger.
+func (f *CollisionFilter) SetTrigger(trigger bool) {
+	f.Trigger = trigger
+}
+
+// UnmarshalFilter takes category, mask and trigger from the given data.
+// Values not present are not changed.
+func (f *CollisionFilter) UnmarshalFilter(data map[string]interface{}) {
+	if category, ok := data["category"].(float64); ok {
+		f.Category = uint32(category)
+	}
+	if mask, ok := data["mask"].(float64); ok {
+		f.Mask = uint32(mask)
+	}
+	if trigger, ok := data["trigger"].(bool); ok {
+		f.Trigger = trigger
+	}
+}
+
+// CollisionMatrix contains named collision layers for a scene and which
+// layers collide with each other. Every layer uses one category bit. By
+// default all layers collide with all others.
+type CollisionMatrix struct {
+	layers map[string]int
+	matrix [maxCollisionLayers]uint32
+}
+
+// collisionMatrixToMarshal identifies collision matrix information being
+// marshaled in JSON format. Matrix contains the layers colliding with every
+// layer, by layer index.
+type collisionMatrixToMarshal struct {
+	Layers map[string]int `json:"layers"`
+	Matrix []uint32       `json:"matrix"`
+}
+
+// NewCollisionMatrix creates a new collision matrix instance. It contains
+// only the default layer.
+func NewCollisionMatrix() *CollisionMatrix {
+	result := &CollisionMatrix{
+		layers: map[string]int{CollisionLayerDefault: 0},
+	}
+	for i := range result.matrix {
+		result.matrix[i] = CollisionMaskAll
+	}
+	return result
+}
+
+// AddLayer adds a new named layer and it returns the category for the layer.
+// If the layer already exists, the existing category is returned.
+func (m *CollisionMatrix) AddLayer(name string) (uint32, error) {
+	if index, ok := m.layers[name]; ok {
+		return 1 << uint(index), nil
+	}
+	if len(m.layers) == maxCollisionLayers {
+		err := fmt.Errorf("collision layer %s can not be added, maximum is %d", name, maxCollisionLayers)
+		Logger.Error().Err(err).Msg("AddLayer error")
+		return 0, err
+	}
+	index := len(m.layers)
+	m.layers[name] = index
+	return 1 << uint(index), nil
+}
+
+// CanCollide returns if the given colliders can collide. Colliders collide
+// if their categories and masks match and if scene layers for those
+// categories collide.
+func (m *CollisionMatrix) CanCollide(one ICollider, two ICollider) bool {
+	categoryOne, categoryTwo := one.GetCategory(), two.GetCategory()
+	if categoryOne&two.GetMask() == 0 || categoryTwo&one.GetMask() == 0 {
+		return false
+	}
+	return m.getCollidesWith(categoryOne)&categoryTwo != 0
+}
+
+// getCollidesWith returns all categories colliding with any of the given
+// categories.
+func (m *CollisionMatrix) getCollidesWith(category uint32) uint32 {
+	var result uint32
+	for i := 0; i < maxCollisionLayers; i++ {
+		if category&(1<<uint(i)) != 0 {
+			result |= m.matrix[i]
+		}
+	}
+	return result
+}
+
+// GetLayer returns the category for the given layer name. It returns zero if
+// the layer does not exist.
+func (m *CollisionMatrix) GetLayer(name string) uint32 {
+	if index, ok := m.layers[name]; ok {
+		return 1 << uint(index)
+	}
+	return 0
+}
+
+// GetLayerCollision returns if the given layers collide.
+func (m *CollisionMatrix) GetLayerCollision(one string, two string) bool {
+	indexOne, okOne := m.layers[one]
+	indexTwo, okTwo := m.layers[two]
+	if !okOne || !okTwo {
+		return false
+	}
+	return m.matrix[indexOne]&(1<<uint(indexTwo)) != 0
+}
+
+// GetLayers returns the category bits for all given layer names. It can be
+// used to build a collider mask.
+func (m *CollisionMatrix) GetLayers(names ...string) uint32 {
+	var result uint32
+	for _, name := range names {
+		result |= m.GetLayer(name)
+	}
+	return result
+}
+
+// MarshalJSON returns the collision matrix in JSON format.
+func (m *CollisionMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&collisionMatrixToMarshal{
+		Layers: m.layers,
+		Matrix: m.matrix[:len(m.layers)],
+	})
+}
+
+// SetLayerCollision sets if the given layers collide.
+func (m *CollisionMatrix) SetLayerCollision(one string, two string, collide bool) error {
+	indexOne, okOne := m.layers[one]
+	indexTwo, okTwo := m.layers[two]
+	if !okOne || !okTwo {
+		err := fmt.Errorf("collision layer %s or %s not found", one, two)
+		Logger.Error().Err(err).Msg("SetLayerCollision error")
+		return err
+	}
+	if collide {
+		m.matrix[indexOne] |= 1 << uint(indexTwo)
+		m.matrix[indexTwo] |= 1 << uint(indexOne)
+	} else {
+		m.matrix[indexOne] &^= 1 << uint(indexTwo)
+		m.matrix[indexTwo] &^= 1 << uint(indexOne)
+	}
+	return nil
+}
+
+// UnmarshalJSON sets the collision matrix from the given JSON data.
+func (m *CollisionMatrix) UnmarshalJSON(data []byte) error {
+	toUnmarshal := &collisionMatrixToMarshal{}
+	if err := json.Unmarshal(data, toUnmarshal); err != nil {
+		return err
+	}
+	if len(toUnmarshal.Layers) > maxCollisionLayers || len(toUnmarshal.Matrix) > maxCollisionLayers {
+		return fmt.Errorf("collision matrix can not have more than %d layers", maxCollisionLayers)
+	}
+	*m = *NewCollisionMatrix()
+	for name, index := range toUnmarshal.Layers {
+		if index < 0 || index >= maxCollisionLayers {
+			return fmt.Errorf("collision layer %s has invalid index %d", name, index)
+		}
+		m.layers[name] = index
+	}
+	copy(m.matrix[:], toUnmarshal.Matrix)
+	return nil
+}
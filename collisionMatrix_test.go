@@ -0,0 +1,48 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+func TestCollisionMatrix_Layers(t *testing.T) {
+	m := engosdl.NewCollisionMatrix()
+	player, _ := m.AddLayer("player")
+	bullet, _ := m.AddLayer("bullet")
+	enemy, _ := m.AddLayer("enemy")
+	if player != 2 || bullet != 4 || enemy != 8 {
+		t.Errorf("error adding layers\nexp: %d %d %d\ngot: %d %d %d\n", 2, 4, 8, player, bullet, enemy)
+	}
+	if again, _ := m.AddLayer("bullet"); again != bullet {
+		t.Errorf("error adding existing layer\nexp: %d\ngot: %d\n", bullet, again)
+	}
+	if mask := m.GetLayers("player", "enemy"); mask != 10 {
+		t.Errorf("error getting layers mask\nexp: %d\ngot: %d\n", 10, mask)
+	}
+	if err := m.SetLayerCollision("player", "unknown", false); err == nil {
+		t.Errorf("error setting unknown layer collision")
+	}
+
+	colliderPlayer := components.NewCollider2D("player")
+	colliderPlayer.SetCategory(player)
+	colliderBullet := components.NewCollider2D("bullet")
+	colliderBullet.SetCategory(bullet)
+	colliderEnemy := components.NewCollider2D("enemy")
+	colliderEnemy.SetCategory(enemy)
+	if !m.CanCollide(colliderPlayer, colliderBullet) {
+		t.Errorf("error colliding layers by default")
+	}
+	m.SetLayerCollision("player", "bullet", false)
+	if m.CanCollide(colliderBullet, colliderPlayer) || m.GetLayerCollision("bullet", "player") {
+		t.Errorf("error filtering layers in collision matrix")
+	}
+	if !m.CanCollide(colliderBullet, colliderEnemy) {
+		t.Errorf("error colliding bullet with enemy")
+	}
+	colliderEnemy.SetMask(m.GetLayers("player"))
+	if m.CanCollide(colliderBullet, colliderEnemy) || !m.CanCollide(colliderEnemy, colliderPlayer) {
+		t.Errorf("error filtering colliders with mask")
+	}
+}
@@ -3,6 +3,7 @@ package engosdl
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -11,7 +12,9 @@ import (
 // Entity
 type IComponent interface {
 	IObject
+	AddDelegateLink(*DelegateLink) IComponent
 	AddDelegateToRegister(IDelegate, IEntity, IComponent, TDelegateSignature) IComponent
+	AddDependency(IComponent, bool) IComponent
 	DefaultAddDelegateToRegister()
 	DefaultOnCollision(...interface{}) bool
 	DefaultOnDestroy(...interface{}) bool
@@ -26,11 +29,16 @@ type IComponent interface {
 	GetActive() bool
 	GetCache(string) (interface{}, error)
 	GetDelegate() IDelegate
+	GetDelegateLinks() []*DelegateLink
+	GetDependencies() []*ComponentDependency
 	GetEntity() IEntity
+	GetPriority() int
 	GetRemoveOnDestroy() bool
 	OnAwake()
+	OnDespawn()
 	OnEnable()
 	OnRender()
+	OnSpawn()
 	OnStart()
 	OnUpdate()
 	RemoveDelegateToRegister(IDelegate, IEntity, IComponent) error
@@ -39,6 +47,7 @@ type IComponent interface {
 	SetCustomOnUpdate(func(IComponent))
 	SetDelegate(IDelegate)
 	SetEntity(IEntity)
+	SetPriority(int)
 	SetRemoveOnDestroy(bool)
 	Unmarshal(map[string]interface{})
 }
@@ -51,9 +60,21 @@ type ICollisionBox interface {
 }
 
 // ICollider represents a special kind of component that implement collisions.
+// Category and mask bits filter which colliders collide. Shape defines the
+// collision shape, a nil shape uses the scene collision mode. Trigger
+// colliders trigger collision delegates, but contacts are not resolved.
 type ICollider interface {
 	IComponent
+	GetCategory() uint32
 	GetCollisionBox() ICollisionBox
+	GetCollisionShape() ICollisionShape
+	GetMask() uint32
+	GetShape() *ColliderShape
+	IsTrigger() bool
+	SetCategory(uint32)
+	SetMask(uint32)
+	SetShape(*ColliderShape)
+	SetTrigger(bool)
 }
 
 // ISprite represents the interface for any sprite component.
@@ -62,6 +83,7 @@ type ISprite interface {
 	GetCamera() *Rect
 	GetFileImageIndex() int
 	GetFilename() []string
+	GetRegion() string
 	LoadSprite()
 	GetSpriteIndex() int
 	NextFileImage() int
@@ -69,6 +91,42 @@ type ISprite interface {
 	PreviousFileImage() int
 	PreviousSprite() int
 	SetCamera(*Rect)
+	SetRegion(string) error
+	SetSpriteIndex(int)
+}
+
+// IAnimator represents the interface for any animator component. Animator
+// plays animation clips in a sprite.
+type IAnimator interface {
+	IComponent
+	AddClip(*AnimationClip) IAnimator
+	GetClip(string) *AnimationClip
+	GetClipName() string
+	GetFrameIndex() int
+	GetSpeed() float64
+	GetSprite() ISprite
+	IsPlaying() bool
+	Pause()
+	Play(string) error
+	Resume()
+	SetSpeed(float64)
+	SetSprite(ISprite)
+	Stop()
+}
+
+// IParticleEmitter represents the interface for any particle emitter
+// component. Particles are not entities, they are updated and displayed by
+// the emitter.
+type IParticleEmitter interface {
+	IComponent
+	Clear()
+	Emit(int)
+	GetConfig() *ParticleConfig
+	GetParticleCount() int
+	IsPlaying() bool
+	Play()
+	SetConfig(*ParticleConfig)
+	Stop()
 }
 
 // ISound represents the interface for any sound component.
@@ -81,6 +139,7 @@ type ISound interface {
 // IText represents the interface for any text component.
 type IText interface {
 	IComponent
+	GetColor() sdl.Color
 	SetFontFilename(string) IText
 	SetColor(sdl.Color) IText
 	SetMessage(string) IText
@@ -89,17 +148,17 @@ type IText interface {
 // IButton represents the interface for any button component.
 type IButton interface {
 	IComponent
+	GetColor() sdl.Color
 	SetFontFilename(string) IButton
 	SetColor(sdl.Color) IButton
 	SetMessage(string) IButton
 }
 
-// ITimer represents the timer component. Timer should be base in engine
-// frames.
+// ITimer represents the timer component. Timer should be base in seconds.
 type ITimer interface {
 	IComponent
-	GetTick() int
-	SetTick(int)
+	GetTick() float64
+	SetTick(float64)
 	GetTimes() int
 	SetTimes(int)
 }
@@ -111,6 +170,10 @@ type Component struct {
 	active          bool
 	delegate        IDelegate
 	registers       []IRegister
+	links           []*DelegateLink
+	pendingLinks    []*DelegateLink
+	dependencies    []*ComponentDependency
+	priority        int
 	removeOnDestroy bool
 	customOnUpdate  func(IComponent)
 	cache           map[string]interface{}
@@ -127,13 +190,31 @@ func NewComponent(name string) *Component {
 		active:          true,
 		delegate:        nil,
 		registers:       []IRegister{},
+		links:           []*DelegateLink{},
+		pendingLinks:    []*DelegateLink{},
+		dependencies:    []*ComponentDependency{},
+		priority:        0,
 		removeOnDestroy: true,
 		customOnUpdate:  nil,
 		cache:           make(map[string]interface{}),
 	}
 }
 
+// AddDelegateLink adds a new delegate link that component should register.
+// Link is resolved when the component starts, so entities in the link can be
+// added to the scene later.
+func (c *Component) AddDelegateLink(link *DelegateLink) IComponent {
+	Logger.Trace().Str("component", c.GetName()).Str("handler", link.Handler).Msg("AddDelegateLink")
+	c.links = append(c.links, link)
+	c.pendingLinks = append(c.pendingLinks, link)
+	return c
+}
+
 // AddDelegateToRegister adds a new delegate that component should register.
+// If delegate is nil, it is the delegate for the given component in the given
+// entity, or in the component entity if entity is nil. Component can be the
+// component instance, or any instance with the component type, and then the
+// first component with that type is used.
 func (c *Component) AddDelegateToRegister(delegate IDelegate, entity IEntity, component IComponent, signature TDelegateSignature) IComponent {
 	Logger.Trace().Str("component", c.GetName()).Msg("AddDelegateToRegister")
 	register := NewRegister("new-register", c, entity, component, delegate, signature)
@@ -141,6 +222,21 @@ func (c *Component) AddDelegateToRegister(delegate IDelegate, entity IEntity, co
 	return c
 }
 
+// AddDependency adds a new component type the component depends on in the
+// same entity. Component is always placed after its dependencies in the
+// entity, so they are loaded, started and updated before. If component type
+// was already a dependency, it becomes required if any of them is required.
+func (c *Component) AddDependency(component IComponent, required bool) IComponent {
+	for _, dependency := range c.dependencies {
+		if reflect.TypeOf(dependency.Component) == reflect.TypeOf(component) {
+			dependency.Required = dependency.Required || required
+			return c
+		}
+	}
+	c.dependencies = append(c.dependencies, NewComponentDependency(component, required))
+	return c
+}
+
 // DefaultAddDelegateToRegister will proceed to add default delegate to
 // register for the component.
 func (c *Component) DefaultAddDelegateToRegister() {
@@ -226,6 +322,7 @@ func (c *Component) DoUnLoad() {
 		if register.GetDelegate() != nil &&
 			register.GetDelegate().GetID() != GetDelegateManager().GetCollisionDelegate().GetID() &&
 			register.GetDelegate().GetID() != GetDelegateManager().GetDestroyDelegate().GetID() &&
+			register.GetDelegate().GetID() != GetDelegateManager().GetErrorDelegate().GetID() &&
 			register.GetDelegate().GetID() != GetDelegateManager().GetLoadDelegate().GetID() {
 			register.SetDelegate(nil)
 
@@ -257,11 +354,26 @@ func (c *Component) GetDelegate() IDelegate {
 	return c.delegate
 }
 
+// GetDelegateLinks returns all delegate links for the component.
+func (c *Component) GetDelegateLinks() []*DelegateLink {
+	return c.links
+}
+
+// GetDependencies returns all component types the component depends on.
+func (c *Component) GetDependencies() []*ComponentDependency {
+	return c.dependencies
+}
+
 // GetEntity return the component entity parent.
 func (c *Component) GetEntity() IEntity {
 	return c.entity
 }
 
+// GetPriority returns the component priority.
+func (c *Component) GetPriority() int {
+	return c.priority
+}
+
 // GetRemoveOnDestroy returns component remove on destroy. If this attribute
 // is true, the component will be removed from the entity when scene is
 // fully unloaded.
@@ -269,6 +381,77 @@ func (c *Component) GetRemoveOnDestroy() bool {
 	return c.removeOnDestroy
 }
 
+// isOptionalDependency returns if the given register is for an optional
+// dependency not found in the component entity.
+func (c *Component) isOptionalDependency(register IRegister) bool {
+	if register.GetEntity() != nil || register.GetComponent() == nil {
+		return false
+	}
+	for _, dependency := range c.dependencies {
+		if !dependency.Required && reflect.TypeOf(dependency.Component) == reflect.TypeOf(register.GetComponent()) {
+			return c.GetEntity().GetComponent(register.GetComponent()) == nil
+		}
+	}
+	return false
+}
+
+// linkDelegate adds a register for the given delegate link. Handler is
+// called with the component in the entity, so it receives the component
+// embedding this one.
+func (c *Component) linkDelegate(link *DelegateLink) error {
+	handler, ok := GetComponentManager().Handlers[link.Handler]
+	if !ok {
+		return fmt.Errorf("handler %s not found", link.Handler)
+	}
+	var delegate IDelegate
+	var entity IEntity
+	var component IComponent
+	if link.Delegate != "" {
+		if delegate = GetDelegateManager().GetDelegateByName(link.Delegate); delegate == nil {
+			return fmt.Errorf("delegate %s not found", link.Delegate)
+		}
+	} else {
+		constructor, ok := GetComponentManager().Constructors[link.Component]
+		if !ok {
+			return fmt.Errorf("component %s not found", link.Component)
+		}
+		component = constructor()
+		if link.Entity != "" {
+			if entity = c.GetEntity().GetScene().GetEntityByName(link.Entity); entity == nil {
+				return fmt.Errorf("entity %s not found", link.Entity)
+			}
+		}
+		if link.ComponentName != "" {
+			target := entity
+			if target == nil {
+				target = c.GetEntity()
+			}
+			if component = target.GetComponentByName(component, link.ComponentName); component == nil {
+				return fmt.Errorf("component %s not found", link.ComponentName)
+			}
+		}
+	}
+	var owner IComponent = c
+	for _, comp := range c.GetEntity().GetComponents() {
+		if comp.GetID() == c.GetID() {
+			owner = comp
+			break
+		}
+	}
+	c.AddDelegateToRegister(delegate, entity, component, handler(owner))
+	return nil
+}
+
+// linkDelegates adds registers for all delegate links not linked yet.
+func (c *Component) linkDelegates() {
+	for _, link := range c.pendingLinks {
+		if err := c.linkDelegate(link); err != nil {
+			Logger.Error().Err(err).Str("component", c.GetName()).Msg("delegate link error")
+		}
+	}
+	c.pendingLinks = []*DelegateLink{}
+}
+
 // OnAwake should create all component resources that don't have any dependency
 // with any other component or entity.
 func (c *Component) OnAwake() {
@@ -276,6 +459,12 @@ func (c *Component) OnAwake() {
 	c.SetLoaded(true)
 }
 
+// OnDespawn is called when the component entity is released to its pool.
+// Component keeps all delegate registrations.
+func (c *Component) OnDespawn() {
+	Logger.Trace().Str("component", c.GetName()).Msg("OnDespawn")
+}
+
 // OnEnable is called every time the component is enabled.
 func (c *Component) OnEnable() {
 	Logger.Trace().Str("component", c.GetName()).Msg("OnEnable")
@@ -286,10 +475,17 @@ func (c *Component) OnRender() {
 	// Logger.Trace().Str("component", c.GetName()).Msg("OnRender")
 }
 
+// OnSpawn is called when the component entity is acquired from its pool. It
+// should reset any component state.
+func (c *Component) OnSpawn() {
+	Logger.Trace().Str("component", c.GetName()).Msg("OnSpawn")
+}
+
 // OnStart is called first time the component is enabled.
 func (c *Component) OnStart() {
 	Logger.Trace().Str("component", c.GetName()).Msg("OnStart")
 	if !c.GetStarted() {
+		c.linkDelegates()
 		for _, register := range c.registers {
 			delegate := register.GetDelegate()
 			// Retrieve delegate from entity and component provided.
@@ -309,6 +505,8 @@ func (c *Component) OnStart() {
 					register.SetRegisterID(registerID)
 					continue
 				}
+			} else if c.isOptionalDependency(register) {
+				continue
 			}
 			Logger.Error().Err(fmt.Errorf("register for component %s failed", c.GetName())).Str("component", c.GetName()).Msg("registration error")
 			// panic("Failure at register " + register.GetName())
@@ -380,6 +578,12 @@ func (c *Component) SetEntity(entity IEntity) {
 	c.entity = entity
 }
 
+// SetPriority sets the component priority. Components with lower priority
+// are placed first in the entity, after all their dependencies.
+func (c *Component) SetPriority(priority int) {
+	c.priority = priority
+}
+
 // SetRemoveOnDestroy sets component remove on destroy. If this attribute
 // is true, the component will be removed from the entity when scene is
 // fully unloaded.
@@ -0,0 +1,96 @@
+package engosdl
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ComponentDependency represents a component type another component depends
+// on in the same entity. Required dependencies have to be in the entity when
+// it is added to a scene, and they are added with the default constructor if
+// they are missing. Optional dependencies are used only to order components.
+type ComponentDependency struct {
+	Component IComponent
+	Required  bool
+}
+
+// NewComponentDependency creates a new component dependency instance for the
+// given component type.
+func NewComponentDependency(component IComponent, required bool) *ComponentDependency {
+	return &ComponentDependency{
+		Component: component,
+		Required:  required,
+	}
+}
+
+// dependsOn returns if the given component depends on the given other
+// component. Components with the same type do not depend on each other, so
+// a component depending on its own type is not a dependency cycle.
+func dependsOn(component IComponent, other IComponent) bool {
+	if reflect.TypeOf(component) == reflect.TypeOf(other) {
+		return false
+	}
+	for _, dependency := range component.GetDependencies() {
+		if reflect.TypeOf(dependency.Component) == reflect.TypeOf(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderComponents returns given components in the same order they have in
+// the given order components.
+func orderComponents(components []IComponent, order []IComponent) []IComponent {
+	contains := make(map[IComponent]bool, len(components))
+	for _, component := range components {
+		contains[component] = true
+	}
+	result := make([]IComponent, 0, len(components))
+	for _, component := range order {
+		if contains[component] {
+			result = append(result, component)
+		}
+	}
+	return result
+}
+
+// sortComponents returns given components sorted so every component is after
+// all components it depends on. Components with lower priority are placed
+// first, and components with the same priority keep their order. It returns
+// an error if there is any dependency cycle.
+func sortComponents(components []IComponent) ([]IComponent, error) {
+	n := len(components)
+	before := make([]int, n)
+	for i, component := range components {
+		for j, other := range components {
+			if i != j && dependsOn(component, other) {
+				before[i]++
+			}
+		}
+	}
+	result := make([]IComponent, 0, n)
+	sorted := make([]bool, n)
+	for len(result) < n {
+		next := -1
+		for i, component := range components {
+			if !sorted[i] && before[i] == 0 && (next == -1 || component.GetPriority() < components[next].GetPriority()) {
+				next = i
+			}
+		}
+		if next == -1 {
+			for i, component := range components {
+				if !sorted[i] {
+					return nil, fmt.Errorf("component %s has a dependency cycle", component.GetName())
+				}
+			}
+		}
+		sorted[next] = true
+		result = append(result, components[next])
+		for i, component := range components {
+			if !sorted[i] && dependsOn(component, components[next]) {
+				before[i]--
+			}
+		}
+	}
+	return result, nil
+}
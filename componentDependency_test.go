@@ -0,0 +1,122 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// testComponent is a component without any constructor registered.
+type testComponent struct {
+	*engosdl.Component
+}
+
+func getComponentNames(comps []engosdl.IComponent) []string {
+	result := []string{}
+	for _, component := range comps {
+		result = append(result, component.GetName())
+	}
+	return result
+}
+
+func TestEntity_ResolveDependencies(t *testing.T) {
+	scene := engosdl.NewScene("dependencies", "test")
+
+	// Required dependency declared by the component constructor is added
+	// with the default constructor when missing, and it is placed before the
+	// component depending on it.
+	player := engosdl.NewEntity("player")
+	shooter := components.NewShooter("player/shooter", nil)
+	player.AddComponent(shooter)
+	if !scene.AddEntity(player) {
+		t.Fatalf("error adding entity with required dependency")
+	}
+	if names := getComponentNames(player.GetComponents()); len(names) != 2 || names[0] != "player/KeyShooter" || names[1] != "player/shooter" {
+		t.Errorf("error adding required dependency\nexp: %v\ngot: %v\n", []string{"player/KeyShooter", "player/shooter"}, names)
+	}
+
+	// Components are sorted by dependencies and by priority. Optional
+	// dependencies are only used to order components.
+	bullet := engosdl.NewEntity("bullet")
+	moveTo := components.NewMoveTo("bullet/move-to", engosdl.NewVector(0, 0))
+	timer := components.NewTimer("bullet/timer", 1, 1)
+	timer.SetPriority(-1)
+	bullet.AddComponent(moveTo)
+	bullet.AddComponent(components.NewOutOfBounds("bullet/out-of-bounds", true))
+	bullet.AddComponent(timer)
+	if !scene.AddEntity(bullet) {
+		t.Fatalf("error adding entity with optional dependency")
+	}
+	exp := []string{"bullet/timer", "bullet/out-of-bounds", "bullet/move-to"}
+	if names := getComponentNames(bullet.GetComponents()); len(names) != 3 || names[0] != exp[0] || names[1] != exp[1] || names[2] != exp[2] {
+		t.Errorf("error sorting components\nexp: %v\ngot: %v\n", exp, names)
+	}
+	other := engosdl.NewEntity("other")
+	otherMoveTo := components.NewMoveTo("other/move-to", engosdl.NewVector(0, 0))
+	other.AddComponent(otherMoveTo)
+	if !scene.AddEntity(other) || len(other.GetComponents()) != 1 {
+		t.Errorf("error adding entity with optional dependency missing")
+	}
+
+	// Entities with required dependencies that can not be added or with
+	// dependency cycles are not added to the scene.
+	invalid := engosdl.NewEntity("invalid")
+	invalid.AddComponent(engosdl.NewComponent("invalid/component").AddDependency(&testComponent{}, true))
+	if scene.AddEntity(invalid) || scene.GetEntityByName("invalid") != nil {
+		t.Errorf("error adding entity with required dependency not found")
+	}
+	cycle := engosdl.NewEntity("cycle")
+	cycleTimer := components.NewTimer("cycle/timer", 1, 1)
+	cycleTimer.AddDependency(&components.Box{}, false)
+	cycleBox := components.NewBox("cycle/box", nil, sdl.Color{}, false)
+	cycleBox.AddDependency(&components.Timer{}, false)
+	cycle.AddComponent(cycleTimer)
+	cycle.AddComponent(cycleBox)
+	if scene.AddEntity(cycle) {
+		t.Errorf("error adding entity with dependency cycle")
+	}
+
+	// Components with the same type depending on their own type are not a
+	// dependency cycle, and they keep their order.
+	timers := engosdl.NewEntity("timers")
+	for _, name := range []string{"timers/first", "timers/second"} {
+		timer := components.NewTimer(name, 1, 1)
+		timer.AddDependency(&components.Timer{}, true)
+		timers.AddComponent(timer)
+	}
+	if !scene.AddEntity(timers) {
+		t.Fatalf("error adding entity with components depending on their own type")
+	}
+	if names := getComponentNames(timers.GetComponents()); len(names) != 2 || names[0] != "timers/first" || names[1] != "timers/second" {
+		t.Errorf("error sorting components with the same type\nexp: %v\ngot: %v\n", []string{"timers/first", "timers/second"}, names)
+	}
+}
+
+func TestScene_AddEntityError(t *testing.T) {
+	errors := []error{}
+	scene := engosdl.NewScene("dependencies-error", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		listener := engosdl.NewEntity("listener")
+		handler := engosdl.NewComponent("listener/handler")
+		handler.AddDelegateToRegister(engosdl.GetDelegateManager().GetErrorDelegate(), nil, nil, func(params ...interface{}) bool {
+			errors = append(errors, params[0].(error))
+			return true
+		})
+		listener.AddComponent(handler)
+		scene.AddEntity(listener)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+
+	// Entity not added because dependencies can not be resolved is
+	// reported.
+	invalid := engosdl.NewEntity("invalid")
+	invalid.AddComponent(engosdl.NewComponent("invalid/component").AddDependency(&testComponent{}, true))
+	if scene.AddEntity(invalid) || len(errors) != 1 {
+		t.Errorf("error reporting entity not added\nexp: %d\ngot: %d\n", 1, len(errors))
+	}
+}
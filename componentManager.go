@@ -1,10 +1,12 @@
 package engosdl
 
-// ComponentManager is in charge of storing all components registered.
+// ComponentManager is in charge of storing all components registered. It
+// stores delegate handlers too, so delegate links can refer to them by name.
 type ComponentManager struct {
 	*Object
 	// components   []IComponent
 	Constructors map[string]func(...interface{}) IComponent
+	Handlers     map[string]func(IComponent) TDelegateSignature
 }
 
 // NewComponentManager create a new component manager instance
@@ -14,6 +16,7 @@ func NewComponentManager(name string) *ComponentManager {
 		Object: NewObject(name),
 		// components:   []IComponent{},
 		Constructors: make(map[string]func(...interface{}) IComponent),
+		Handlers:     make(map[string]func(IComponent) TDelegateSignature),
 	}
 }
 
@@ -34,3 +37,10 @@ func (cm *ComponentManager) RegisterConstructor(componentName string, constructo
 	Logger.Trace().Str("component-manager", cm.GetName()).Str("component", componentName).Msg("register constructor")
 	cm.Constructors[componentName] = constructor
 }
+
+// RegisterHandler registers a new delegate handler. Handler returns the
+// signature to be registered for the component with the delegate link.
+func (cm *ComponentManager) RegisterHandler(handlerName string, handler func(IComponent) TDelegateSignature) {
+	Logger.Trace().Str("component-manager", cm.GetName()).Str("handler", handlerName).Msg("register handler")
+	cm.Handlers[handlerName] = handler
+}
@@ -0,0 +1,141 @@
+package engosdl
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ComponentQuery selects entities in a scene by component types. Entities
+// match the query if they have all components in with and none of components
+// in without. Component types are given by any component instance, as it is
+// done with entity GetComponent method.
+type ComponentQuery struct {
+	with    []reflect.Type
+	without []reflect.Type
+}
+
+// NewComponentQuery creates a new component query instance that matches
+// entities with all given components.
+func NewComponentQuery(components ...IComponent) *ComponentQuery {
+	return (&ComponentQuery{
+		with:    []reflect.Type{},
+		without: []reflect.Type{},
+	}).With(components...)
+}
+
+// copyComponentQuery returns a copy of the given component query, or a new
+// component query if it is nil.
+func copyComponentQuery(query *ComponentQuery) *ComponentQuery {
+	if query == nil {
+		return NewComponentQuery()
+	}
+	return &ComponentQuery{
+		with:    append([]reflect.Type{}, query.with...),
+		without: append([]reflect.Type{}, query.without...),
+	}
+}
+
+// getTypeNames returns sorted names for the given component types.
+func getTypeNames(types []reflect.Type) []string {
+	result := []string{}
+	for _, t := range types {
+		result = append(result, t.String())
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetKey returns the key identifying the query. Queries with the same
+// component types have the same key.
+func (query *ComponentQuery) GetKey() string {
+	return strings.Join(getTypeNames(query.with), ",") + "!" + strings.Join(getTypeNames(query.without), ",")
+}
+
+// Match returns if the given entity matches the query.
+func (query *ComponentQuery) Match(entity IEntity) bool {
+	types := map[reflect.Type]bool{}
+	for _, component := range entity.GetComponents() {
+		types[reflect.TypeOf(component)] = true
+	}
+	for _, t := range query.with {
+		if !types[t] {
+			return false
+		}
+	}
+	for _, t := range query.without {
+		if types[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// With adds components entities are required to have.
+func (query *ComponentQuery) With(components ...IComponent) *ComponentQuery {
+	for _, component := range components {
+		query.with = append(query.with, reflect.TypeOf(component))
+	}
+	return query
+}
+
+// Without adds components entities are required not to have.
+func (query *ComponentQuery) Without(components ...IComponent) *ComponentQuery {
+	for _, component := range components {
+		query.without = append(query.without, reflect.TypeOf(component))
+	}
+	return query
+}
+
+// queryCache contains all scene entities matching a component query. Cached
+// entities are never modified in place, so entities returned by any query are
+// not changed when the cache is updated.
+type queryCache struct {
+	query    *ComponentQuery
+	entities []IEntity
+	matched  map[string]bool
+}
+
+// newQueryCache creates a new query cache with all given entities matching
+// the given query. Query is copied, so it can be changed later.
+func newQueryCache(query *ComponentQuery, entities []IEntity) *queryCache {
+	cache := &queryCache{
+		query:    copyComponentQuery(query),
+		entities: []IEntity{},
+		matched:  make(map[string]bool),
+	}
+	for _, entity := range entities {
+		cache.update(entity)
+	}
+	return cache
+}
+
+// remove removes the given entity from the cache.
+func (cache *queryCache) remove(entity IEntity) {
+	if !cache.matched[entity.GetID()] {
+		return
+	}
+	delete(cache.matched, entity.GetID())
+	entities := make([]IEntity, 0, len(cache.entities)-1)
+	for _, traverse := range cache.entities {
+		if traverse.GetID() != entity.GetID() {
+			entities = append(entities, traverse)
+		}
+	}
+	cache.entities = entities
+}
+
+// update adds the given entity to the cache if it matches the query, or it
+// removes the entity if it does not match anymore.
+func (cache *queryCache) update(entity IEntity) {
+	if !cache.query.Match(entity) {
+		cache.remove(entity)
+		return
+	}
+	if !cache.matched[entity.GetID()] {
+		cache.matched[entity.GetID()] = true
+		entities := make([]IEntity, len(cache.entities), len(cache.entities)+1)
+		copy(entities, cache.entities)
+		cache.entities = append(entities, entity)
+	}
+}
@@ -0,0 +1,42 @@
+package engosdl
+
+// GetComponentOf returns the component with type T in the given entity.
+func GetComponentOf[T IComponent](entity IEntity) (T, bool) {
+	var zero T
+	if component, ok := entity.GetComponent(zero).(T); ok {
+		return component, true
+	}
+	return zero, false
+}
+
+// Query returns components with type T for all entities in the given scene
+// matching the given query. Query can be nil, and component type T is always
+// added to a copy of the query.
+func Query[T IComponent](scene IScene, query *ComponentQuery) []T {
+	var zero T
+	result := []T{}
+	for _, entity := range scene.QueryEntities(copyComponentQuery(query).With(zero)) {
+		if component, ok := GetComponentOf[T](entity); ok {
+			result = append(result, component)
+		}
+	}
+	return result
+}
+
+// Query2 returns components with types T1 and T2 for all entities in the
+// given scene matching the given query. Components with the same index belong
+// to the same entity.
+func Query2[T1 IComponent, T2 IComponent](scene IScene, query *ComponentQuery) ([]T1, []T2) {
+	var zero1 T1
+	var zero2 T2
+	result1, result2 := []T1{}, []T2{}
+	for _, entity := range scene.QueryEntities(copyComponentQuery(query).With(zero1, zero2)) {
+		component1, ok1 := GetComponentOf[T1](entity)
+		component2, ok2 := GetComponentOf[T2](entity)
+		if ok1 && ok2 {
+			result1 = append(result1, component1)
+			result2 = append(result2, component2)
+		}
+	}
+	return result1, result2
+}
@@ -0,0 +1,34 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+func TestQuery(t *testing.T) {
+	scene := engosdl.NewScene("query", "test")
+	player := engosdl.NewEntity("player")
+	player.AddComponent(components.NewTimer("player/timer", 1, 0))
+	player.AddComponent(components.NewEntityStats("player/stats", 10))
+	enemy := engosdl.NewEntity("enemy")
+	enemy.AddComponent(components.NewTimer("enemy/timer", 2, 0))
+	scene.AddEntity(player)
+	scene.AddEntity(enemy)
+	timers := engosdl.Query[*components.Timer](scene, nil)
+	if len(timers) != 2 || timers[0].GetName() != "player/timer" || timers[1].GetTick() != 2 {
+		t.Errorf("error querying components\nexp: %d\ngot: %d\n", 2, len(timers))
+	}
+	timers = engosdl.Query[*components.Timer](scene, engosdl.NewComponentQuery().Without(&components.EntityStats{}))
+	if len(timers) != 1 || timers[0].GetName() != "enemy/timer" {
+		t.Errorf("error querying components without\nexp: %d\ngot: %d\n", 1, len(timers))
+	}
+	timers, stats := engosdl.Query2[*components.Timer, *components.EntityStats](scene, nil)
+	if len(timers) != 1 || len(stats) != 1 || timers[0].GetEntity() != stats[0].GetEntity() {
+		t.Errorf("error querying two components")
+	}
+	if _, ok := engosdl.GetComponentOf[*components.EntityStats](enemy); ok {
+		t.Errorf("error getting component not in the entity")
+	}
+}
@@ -0,0 +1,67 @@
+package engosdl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+// getEntityNames returns names for all given entities.
+func getEntityNames(entities []engosdl.IEntity) []string {
+	result := []string{}
+	for _, entity := range entities {
+		result = append(result, entity.GetName())
+	}
+	return result
+}
+
+func TestScene_QueryEntities(t *testing.T) {
+	scene := engosdl.NewScene("query", "test")
+	player := engosdl.NewEntity("player")
+	player.AddComponent(components.NewTimer("player/timer", 1, 0))
+	playerStats := components.NewEntityStats("player/stats", 10)
+	player.AddComponent(playerStats)
+	enemy := engosdl.NewEntity("enemy")
+	enemy.AddComponent(components.NewTimer("enemy/timer", 1, 0))
+	wall := engosdl.NewEntity("wall")
+	scene.AddEntity(player)
+	scene.AddEntity(enemy)
+	scene.AddEntity(wall)
+	query := engosdl.NewComponentQuery(&components.Timer{}).Without(&components.EntityStats{})
+	if exp, got := []string{"enemy"}, getEntityNames(scene.QueryEntities(query)); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error querying entities\nexp: %v\ngot: %v\n", exp, got)
+	}
+	timers := scene.QueryEntities(engosdl.NewComponentQuery(&components.Timer{}))
+	if exp, got := []string{"player", "enemy"}, getEntityNames(timers); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error querying entities\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if engosdl.NewComponentQuery(&components.Timer{}).Without(&components.EntityStats{}).GetKey() != engosdl.NewComponentQuery().Without(&components.EntityStats{}).With(&components.Timer{}).GetKey() {
+		t.Errorf("error building query key")
+	}
+
+	// Cached queries are updated when entities and components change, but
+	// entities returned before are not changed.
+	query.With(&components.Box{})
+	wall.AddComponent(components.NewTimer("wall/timer", 1, 0))
+	enemy.AddComponent(components.NewEntityStats("enemy/stats", 10))
+	player.RemoveComponent(playerStats)
+	coin := engosdl.NewEntity("coin")
+	coin.AddComponent(components.NewTimer("coin/timer", 1, 0))
+	scene.AddEntity(coin)
+	if exp, got := []string{"wall", "player", "coin"}, getEntityNames(scene.QueryEntities(engosdl.NewComponentQuery(&components.Timer{}).Without(&components.EntityStats{}))); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error updating cached query\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if exp, got := []string{"player", "enemy"}, getEntityNames(timers); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error changing entities returned\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if got := scene.QueryEntities(query); len(got) != 0 {
+		t.Errorf("error querying entities\nexp: %d\ngot: %d\n", 0, len(got))
+	}
+	scene.RemoveEntity(player)
+	scene.OnAfterUpdate()
+	if exp, got := []string{"enemy", "wall", "coin"}, getEntityNames(scene.QueryEntities(engosdl.NewComponentQuery(&components.Timer{}))); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error removing entity from cached query\nexp: %v\ngot: %v\n", exp, got)
+	}
+}
@@ -0,0 +1,71 @@
+package engosdl
+
+import "math"
+
+// Contact represents the contact information for two colliders that are
+// colliding. Rect is the intersection between both collision rectangles,
+// Normal is the unit vector pointing from the first collider to the second
+// one and Depth is the penetration along the normal.
+type Contact struct {
+	Rect   *Rect
+	Normal *Vector
+	Depth  float64
+}
+
+// NewContact creates a new contact instance.
+func NewContact(rect *Rect, normal *Vector) *Contact {
+	return &Contact{
+		Rect:   rect,
+		Normal: normal,
+	}
+}
+
+// NewBoxContact creates a new contact instance for two colliding rectangles.
+// Normal is placed in the axis with the minimum overlap.
+func NewBoxContact(one *Rect, two *Rect) *Contact {
+	rect, _ := one.Intersect(two)
+	dx := (two.X + two.W/2) - (one.X + one.W/2)
+	dy := (two.Y + two.H/2) - (one.Y + one.H/2)
+	overlapX := math.Min(one.X+one.W, two.X+two.W) - math.Max(one.X, two.X)
+	overlapY := math.Min(one.Y+one.H, two.Y+two.H) - math.Max(one.Y, two.Y)
+	var contact *Contact
+	if overlapX < overlapY {
+		contact = NewContact(rect, NewVector(sign(dx), 0))
+		contact.Depth = overlapX
+	} else {
+		contact = NewContact(rect, NewVector(0, sign(dy)))
+		contact.Depth = overlapY
+	}
+	return contact
+}
+
+// sign returns -1 for negative values and 1 for any other value.
+func sign(value float64) float64 {
+	if value < 0 {
+		return -1
+	}
+	return 1
+}
+
+// contactKey identifies two colliders in contact.
+type contactKey struct {
+	one ICollider
+	two ICollider
+}
+
+// contactPair contains colliders and the last contact for two colliders in
+// contact.
+type contactPair struct {
+	one     ICollider
+	two     ICollider
+	contact *Contact
+}
+
+// newContactKey returns the key for the given colliders. Key is the same
+// independently of colliders order.
+func newContactKey(one ICollider, two ICollider) contactKey {
+	if one.GetID() > two.GetID() {
+		one, two = two, one
+	}
+	return contactKey{one: one, two: two}
+}
@@ -0,0 +1,74 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestContact_NewBoxContact(t *testing.T) {
+	contact := engosdl.NewBoxContact(engosdl.NewRect(0, 0, 20, 20), engosdl.NewRect(15, 5, 20, 20))
+	if contact.Rect.X != 15 || contact.Rect.W != 5 || contact.Rect.H != 15 {
+		t.Errorf("error getting contact rectangle\nexp: %v\ngot: %v\n", engosdl.NewRect(15, 5, 5, 15), contact.Rect)
+	}
+	if !equalVector(contact.Normal, engosdl.NewVector(1, 0)) {
+		t.Errorf("error getting contact normal\nexp: %v\ngot: %v\n", engosdl.NewVector(1, 0), contact.Normal)
+	}
+	contact = engosdl.NewBoxContact(engosdl.NewRect(0, 10, 20, 20), engosdl.NewRect(5, 0, 20, 12))
+	if !equalVector(contact.Normal, engosdl.NewVector(0, -1)) {
+		t.Errorf("error getting contact normal\nexp: %v\ngot: %v\n", engosdl.NewVector(0, -1), contact.Normal)
+	}
+}
+
+func TestContact_EnterStayExit(t *testing.T) {
+	engine := newTestEngine(t)
+	var enter, stay, exit int
+	var normal *engosdl.Vector
+	scene := engosdl.NewScene("test-contact-scene", "test")
+	scene.SetCollisionMode(engosdl.ModeBox)
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		mover := engosdl.NewEntity("mover")
+		mover.GetTransform().SetPositionXY(10, 10)
+		mover.AddComponent(components.NewBox("mover/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{A: 255}, true))
+		mover.AddComponent(components.NewMoveTo("mover/move-to", engosdl.NewVector(30, 0)))
+		mover.AddComponent(components.NewCollider2D("mover/collider-2D"))
+		scene.AddEntity(mover)
+		wall := engosdl.NewEntity("wall")
+		wall.GetTransform().SetPositionXY(50, 10)
+		wall.AddComponent(components.NewBox("wall/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{A: 255}, true))
+		wall.AddComponent(components.NewCollider2D("wall/collider-2D"))
+		counter := engosdl.NewComponent("wall/counter")
+		delegateManager := engosdl.GetDelegateManager()
+		counter.AddDelegateToRegister(delegateManager.GetCollisionEnterDelegate(), nil, nil, func(params ...interface{}) bool {
+			enter++
+			if params[0].(engosdl.IEntity).GetName() == "mover" {
+				normal = params[2].(*engosdl.Contact).Normal
+			}
+			return true
+		})
+		counter.AddDelegateToRegister(delegateManager.GetCollisionStayDelegate(), nil, nil, func(params ...interface{}) bool {
+			stay++
+			return true
+		})
+		counter.AddDelegateToRegister(delegateManager.GetCollisionExitDelegate(), nil, nil, func(params ...interface{}) bool {
+			exit++
+			return true
+		})
+		wall.AddComponent(counter)
+		scene.AddEntity(wall)
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 100)
+	if enter != 1 || exit != 1 {
+		t.Errorf("error triggering enter and exit\nexp: %d %d\ngot: %d %d\n", 1, 1, enter, exit)
+	}
+	if stay == 0 {
+		t.Errorf("error triggering stay")
+	}
+	if normal == nil || !equalVector(normal, engosdl.NewVector(1, 0)) {
+		t.Errorf("error getting contact normal\nexp: %v\ngot: %v\n", engosdl.NewVector(1, 0), normal)
+	}
+}
@@ -5,20 +5,33 @@ import "fmt"
 const (
 	// CollisionName represents on collision delegate.
 	CollisionName = "on-collision"
+	// CollisionEnterName represents on collision enter delegate.
+	CollisionEnterName = "on-collision-enter"
+	// CollisionExitName represents on collision exit delegate.
+	CollisionExitName = "on-collision-exit"
+	// CollisionStayName represents on collision stay delegate.
+	CollisionStayName = "on-collision-stay"
 	// DestroyName represents on destroy delegate.
 	DestroyName = "on-destroy"
+	// ErrorName represents on error delegate.
+	ErrorName = "on-error"
 	// LoadName represents on load delegate.
 	LoadName = "on-load"
 	// OutOfBoundsName represents on out of bounds delegate.
 	OutOfBoundsName = "on-out-of-bounds"
 
-	delegateManagerName   = "delegate-manager"
-	collisionDelegate     = CollisionName
-	collisionDelegateName = delegateManagerName + "/" + collisionDelegate
-	destroyDelegate       = DestroyName
-	destroyDelegateName   = delegateManagerName + "/" + destroyDelegate
-	loadDelegate          = LoadName
-	loadDelegateName      = delegateManagerName + "/" + loadDelegate
+	delegateManagerName    = "delegate-manager"
+	collisionDelegate      = CollisionName
+	collisionDelegateName  = delegateManagerName + "/" + collisionDelegate
+	collisionEnterDelegate = CollisionEnterName
+	collisionExitDelegate  = CollisionExitName
+	collisionStayDelegate  = CollisionStayName
+	destroyDelegate        = DestroyName
+	destroyDelegateName    = delegateManagerName + "/" + destroyDelegate
+	errorDelegate          = ErrorName
+	errorDelegateName      = delegateManagerName + "/" + errorDelegate
+	loadDelegate           = LoadName
+	loadDelegateName       = delegateManagerName + "/" + loadDelegate
 )
 
 // IDelegate represents any delegate to be used in the delegate event handler.
@@ -50,6 +63,32 @@ type IRegister interface {
 	SetSignature(TDelegateSignature) IRegister
 }
 
+// DelegateLink represents a register to a delegate described only by names,
+// so it can be saved and loaded with the scene. Delegate is the name for any
+// delegate in the delegate manager, like the tween manager delegate. If it
+// is empty, delegate is the one for the component with the given component
+// type in the entity with the given entity name, or in the component entity
+// if entity name is empty. Component name selects the component when entity
+// has many components with the same type. Handler is the name for the
+// handler registered in the component manager.
+type DelegateLink struct {
+	Delegate      string `json:"delegate"`
+	Entity        string `json:"entity"`
+	Component     string `json:"component"`
+	ComponentName string `json:"component-name,omitempty"`
+	Handler       string `json:"handler"`
+}
+
+// NewDelegateLink creates a new delegate link instance.
+func NewDelegateLink(delegate string, entity string, component string, handler string) *DelegateLink {
+	return &DelegateLink{
+		Delegate:  delegate,
+		Entity:    entity,
+		Component: component,
+		Handler:   handler,
+	}
+}
+
 // IDelegateManager represents the interface for the delegate event manager.
 type IDelegateManager interface {
 	IObject
@@ -60,7 +99,12 @@ type IDelegateManager interface {
 	DeregisterFromDelegate(string) bool
 	DoInit()
 	GetCollisionDelegate() IDelegate
+	GetCollisionEnterDelegate() IDelegate
+	GetCollisionExitDelegate() IDelegate
+	GetCollisionStayDelegate() IDelegate
+	GetDelegateByName(string) IDelegate
 	GetDestroyDelegate() IDelegate
+	GetErrorDelegate() IDelegate
 	GetLoadDelegate() IDelegate
 	OnStart()
 	OnUpdate()
@@ -277,7 +321,11 @@ func (h *DelegateManager) DeregisterFromDelegate(registerID string) bool {
 func (h *DelegateManager) DoInit() {
 	Logger.Trace().Str("delegate-manager", h.GetName()).Msg("DoInit")
 	h.defaults[collisionDelegate] = h.CreateDelegate(h, collisionDelegate)
+	h.defaults[collisionEnterDelegate] = h.CreateDelegate(h, collisionEnterDelegate)
+	h.defaults[collisionExitDelegate] = h.CreateDelegate(h, collisionExitDelegate)
+	h.defaults[collisionStayDelegate] = h.CreateDelegate(h, collisionStayDelegate)
 	h.defaults[destroyDelegate] = h.CreateDelegate(h, destroyDelegate)
+	h.defaults[errorDelegate] = h.CreateDelegate(h, errorDelegate)
 	h.defaults[loadDelegate] = h.CreateDelegate(h, loadDelegate)
 }
 
@@ -286,11 +334,48 @@ func (h *DelegateManager) GetCollisionDelegate() IDelegate {
 	return h.defaults[collisionDelegate]
 }
 
+// GetCollisionEnterDelegate returns default delegate when two colliders
+// start colliding.
+func (h *DelegateManager) GetCollisionEnterDelegate() IDelegate {
+	return h.defaults[collisionEnterDelegate]
+}
+
+// GetCollisionExitDelegate returns default delegate when two colliders stop
+// colliding.
+func (h *DelegateManager) GetCollisionExitDelegate() IDelegate {
+	return h.defaults[collisionExitDelegate]
+}
+
+// GetCollisionStayDelegate returns default delegate when two colliders keep
+// colliding from the previous frame.
+func (h *DelegateManager) GetCollisionStayDelegate() IDelegate {
+	return h.defaults[collisionStayDelegate]
+}
+
+// GetDelegateByName returns the delegate with the given name. Delegate name
+// is the name for the object that created the delegate and the delegate
+// event name, like "engine-tween-manager/on-tween". Latest delegate created is
+// returned if there are many with the same name.
+func (h *DelegateManager) GetDelegateByName(name string) IDelegate {
+	for i := len(h.delegates) - 1; i >= 0; i-- {
+		if h.delegates[i].GetName() == name {
+			return h.delegates[i]
+		}
+	}
+	return nil
+}
+
 // GetDestroyDelegate returns default delegate when entity is destroyed.
 func (h *DelegateManager) GetDestroyDelegate() IDelegate {
 	return h.defaults[destroyDelegate]
 }
 
+// GetErrorDelegate returns default delegate when any error is reported. It
+// is triggered with the error as parameter.
+func (h *DelegateManager) GetErrorDelegate() IDelegate {
+	return h.defaults[errorDelegate]
+}
+
 // GetLoadDelegate returns default delegate when entity is loaded/created.
 func (h *DelegateManager) GetLoadDelegate() IDelegate {
 	return h.defaults[loadDelegate]
@@ -337,10 +422,10 @@ func (h *DelegateManager) TriggerDelegateFor(delegate IDelegate, entities []IEnt
 	for _, register := range h.registers {
 		if register.GetDelegate() != nil && register.GetDelegate().GetID() == delegate.GetID() {
 			// Check if the entity for the component in the register belongs
-			// to the active scene.
+			// to any scene being updated.
 			if source, ok := register.GetObject().(IComponent); ok {
 				entity := source.GetEntity()
-				if entity.GetScene().GetID() != GetSceneManager().GetActiveScene().GetID() {
+				if !GetSceneManager().IsSceneUpdated(entity.GetScene()) {
 					continue
 				}
 				matched := (len(entities) == 0)
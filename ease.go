@@ -0,0 +1,205 @@
+package engosdl
+
+import "math"
+
+// EaseFunc represents an easing function. It maps the normalized time, from
+// 0 to 1, to the normalized progress. Progress can be out of range for
+// functions overshooting like elastic or back.
+type EaseFunc func(float64) float64
+
+// Easing function names to be used in configuration files.
+const (
+	EaseNameLinear       string = "linear"
+	EaseNameInQuad       string = "in-quad"
+	EaseNameOutQuad      string = "out-quad"
+	EaseNameInOutQuad    string = "in-out-quad"
+	EaseNameInCubic      string = "in-cubic"
+	EaseNameOutCubic     string = "out-cubic"
+	EaseNameInOutCubic   string = "in-out-cubic"
+	EaseNameInSine       string = "in-sine"
+	EaseNameOutSine      string = "out-sine"
+	EaseNameInOutSine    string = "in-out-sine"
+	EaseNameInElastic    string = "in-elastic"
+	EaseNameOutElastic   string = "out-elastic"
+	EaseNameInOutElastic string = "in-out-elastic"
+	EaseNameInBounce     string = "in-bounce"
+	EaseNameOutBounce    string = "out-bounce"
+	EaseNameInOutBounce  string = "in-out-bounce"
+	EaseNameInBack       string = "in-back"
+	EaseNameOutBack      string = "out-back"
+	EaseNameInOutBack    string = "in-out-back"
+)
+
+// Overshoot values used by back easing functions.
+const (
+	easeBackOvershoot      float64 = 1.70158
+	easeBackInOutOvershoot float64 = easeBackOvershoot * 1.525
+)
+
+// easeFunctions contains all easing functions by name.
+var easeFunctions = map[string]EaseFunc{
+	EaseNameLinear:       EaseLinear,
+	EaseNameInQuad:       EaseInQuad,
+	EaseNameOutQuad:      EaseOutQuad,
+	EaseNameInOutQuad:    EaseInOutQuad,
+	EaseNameInCubic:      EaseInCubic,
+	EaseNameOutCubic:     EaseOutCubic,
+	EaseNameInOutCubic:   EaseInOutCubic,
+	EaseNameInSine:       EaseInSine,
+	EaseNameOutSine:      EaseOutSine,
+	EaseNameInOutSine:    EaseInOutSine,
+	EaseNameInElastic:    EaseInElastic,
+	EaseNameOutElastic:   EaseOutElastic,
+	EaseNameInOutElastic: EaseInOutElastic,
+	EaseNameInBounce:     EaseInBounce,
+	EaseNameOutBounce:    EaseOutBounce,
+	EaseNameInOutBounce:  EaseInOutBounce,
+	EaseNameInBack:       EaseInBack,
+	EaseNameOutBack:      EaseOutBack,
+	EaseNameInOutBack:    EaseInOutBack,
+}
+
+// GetEase returns the easing function with the given name. It returns linear
+// easing if there is not any function with that name.
+func GetEase(name string) EaseFunc {
+	if ease, ok := easeFunctions[name]; ok {
+		return ease
+	}
+	return EaseLinear
+}
+
+// EaseLinear returns progress at constant speed.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad accelerates from zero speed.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero speed.
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad accelerates until halfway and then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInCubic accelerates from zero speed.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero speed.
+func EaseOutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseInOutCubic accelerates until halfway and then decelerates.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// EaseInSine accelerates from zero speed following a sine curve.
+func EaseInSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// EaseOutSine decelerates to zero speed following a sine curve.
+func EaseOutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// EaseInOutSine accelerates until halfway and then decelerates following a
+// sine curve.
+func EaseInOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// EaseInElastic oscillates with increasing amplitude at the start.
+func EaseInElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*2*math.Pi/3)
+}
+
+// EaseOutElastic oscillates with decreasing amplitude at the end.
+func EaseOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*2*math.Pi/3) + 1
+}
+
+// EaseInOutElastic oscillates at the start and at the end.
+func EaseInOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	if t < 0.5 {
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*2*math.Pi/4.5)) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*2*math.Pi/4.5)/2 + 1
+}
+
+// EaseInBounce bounces at the start.
+func EaseInBounce(t float64) float64 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// EaseOutBounce bounces at the end.
+func EaseOutBounce(t float64) float64 {
+	switch {
+	case t < 1/2.75:
+		return 7.5625 * t * t
+	case t < 2/2.75:
+		t -= 1.5 / 2.75
+		return 7.5625*t*t + 0.75
+	case t < 2.5/2.75:
+		t -= 2.25 / 2.75
+		return 7.5625*t*t + 0.9375
+	}
+	t -= 2.625 / 2.75
+	return 7.5625*t*t + 0.984375
+}
+
+// EaseInOutBounce bounces at the start and at the end.
+func EaseInOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - EaseOutBounce(1-2*t)) / 2
+	}
+	return (1 + EaseOutBounce(2*t-1)) / 2
+}
+
+// EaseInBack moves backwards before moving forward.
+func EaseInBack(t float64) float64 {
+	return t * t * ((easeBackOvershoot+1)*t - easeBackOvershoot)
+}
+
+// EaseOutBack overshoots the end before moving back.
+func EaseOutBack(t float64) float64 {
+	t--
+	return t*t*((easeBackOvershoot+1)*t+easeBackOvershoot) + 1
+}
+
+// EaseInOutBack moves backwards at the start and overshoots at the end.
+func EaseInOutBack(t float64) float64 {
+	t *= 2
+	if t < 1 {
+		return t * t * ((easeBackInOutOvershoot+1)*t - easeBackInOutOvershoot) / 2
+	}
+	t -= 2
+	return (t*t*((easeBackInOutOvershoot+1)*t+easeBackInOutOvershoot) + 2) / 2
+}
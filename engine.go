@@ -3,6 +3,7 @@ package engosdl
 import (
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/gorilla/mux"
 	"github.com/veandco/go-sdl2/img"
@@ -18,19 +19,25 @@ type Engine struct {
 	height          int32
 	active          bool
 	window          *sdl.Window
-	renderer        *sdl.Renderer
+	renderer        IRenderer
+	headless        bool
 	delegateManager IDelegateManager
 	eventManager    IEventManager
 	fontManager     IFontManager
+	loadManager     ILoadManager
 	resourceManager IResourceManager
 	sceneManager    ISceneManager
 	soundManager    ISoundManager
 	gameManager     IGameManager
 	cursorManager   ICursorManager
+	timeManager     ITimeManager
+	tweenManager    ITweenManager
 	debugServer     bool
 }
 
-// NewEngine creates a new engine instance.
+// NewEngine creates a new engine instance. Engine is a singleton, so the
+// existing engine is returned if it has already been created and it has not
+// been cleaned up.
 func NewEngine(name string, w, h int32, gameManager IGameManager) *Engine {
 	Logger.Trace().Str("engine", name).Msg("new engine")
 	if GetEngine() == nil {
@@ -44,10 +51,13 @@ func NewEngine(name string, w, h int32, gameManager IGameManager) *Engine {
 			delegateManager: NewDelegateManager("engine-delegate-manager"),
 			eventManager:    NewEventManager("engine-event-manager"),
 			fontManager:     NewFontManager("engine-font-manager"),
+			loadManager:     NewLoadManager("engine-load-manager"),
 			resourceManager: NewResourceManager("engine-resource-manager"),
 			sceneManager:    NewSceneManager("engine-scene-manager"),
 			soundManager:    NewSoundManager("engine-sound-manager"),
 			cursorManager:   NewCursorManager("engine-cursor-manager"),
+			timeManager:     NewTimeManager("engine-time-manager"),
+			tweenManager:    NewTweenManager("engine-tween-manager"),
 			gameManager:     gameManager,
 			debugServer:     false,
 		}
@@ -55,14 +65,31 @@ func NewEngine(name string, w, h int32, gameManager IGameManager) *Engine {
 	return gameEngine
 }
 
+// NewHeadlessEngine creates a new engine instance that does not require any
+// display. It renders using a headless renderer, which records all draw
+// calls, and it uses dummy video and audio drivers. It returns an error if
+// there is already an engine, which has to be cleaned up before.
+func NewHeadlessEngine(name string, w, h int32, gameManager IGameManager) (*Engine, error) {
+	if engine := GetEngine(); engine != nil {
+		return nil, fmt.Errorf("engine %s already exists", engine.name)
+	}
+	engine := NewEngine(name, w, h, gameManager)
+	engine.SetHeadless(true)
+	return engine, nil
+}
+
 // AddScene adds a new scene to the engine.
 func (engine *Engine) AddScene(scene IScene) bool {
 	Logger.Trace().Str("engine", engine.name).Msg("AddScene")
 	return engine.GetSceneManager().AddScene(scene)
 }
 
-// DestroyEntity removes the given entity from the game.
+// DestroyEntity removes the given entity from the game. Entities belonging to
+// a pool are released to the pool instead of being destroyed.
 func (engine *Engine) DestroyEntity(entity IEntity) bool {
+	if pool := entity.GetPool(); pool != nil {
+		return pool.Release(entity)
+	}
 	scene := entity.GetScene()
 	scene.DeleteEntity(entity)
 	entity.SetActive(false)
@@ -70,16 +97,25 @@ func (engine *Engine) DestroyEntity(entity IEntity) bool {
 	return true
 }
 
-// DoCleanup clean-ups all graphical resources created by teh engine.
+// DoCleanup clean-ups all graphical resources created by teh engine. Engine
+// is released, so a new engine can be created, or it can be initialized and
+// started again.
 func (engine *Engine) DoCleanup() {
 	Logger.Trace().Str("engine", engine.name).Msg("end engine")
+	if gameEngine == engine {
+		gameEngine = nil
+	}
 	defer ttf.Quit()
 	defer img.Quit()
 	defer mix.CloseAudio()
 	defer mix.Quit()
 	defer sdl.Quit()
-	defer engine.window.Destroy()
-	defer engine.renderer.Destroy()
+	if engine.window != nil {
+		defer engine.window.Destroy()
+	}
+	if engine.renderer != nil {
+		defer engine.renderer.Destroy()
+	}
 }
 
 // DoFrameEnd calls all methods to run at the end of a tick frame.
@@ -88,15 +124,45 @@ func (engine *Engine) DoFrameEnd() {
 	engine.GetSceneManager().DoFrameEnd()
 }
 
+// DoFrame runs a complete engine frame for the given elapsed time in
+// seconds. It runs as many fixed update steps as time accumulated allows,
+// and renders once.
+func (engine *Engine) DoFrame(elapsed float64) {
+	engine.GetTimeManager().Tick(elapsed)
+
+	// Execute everything required at the start of a tick frame.
+	engine.DoFrameStart()
+
+	engine.DoPollEvents()
+
+	for engine.GetTimeManager().NextStep() {
+		engine.DoUpdate()
+	}
+
+	engine.renderer.SetDrawColor(255, 255, 255, 255)
+	engine.renderer.Clear()
+
+	engine.DoRender()
+
+	engine.renderer.Present()
+
+	// Execute everything required at the end of the tick frame.
+	engine.DoFrameEnd()
+}
+
 // DoFrameStart calls all methods to run at the start of a tick frame.
 func (engine *Engine) DoFrameStart() {
 	engine.GetGameManager().DoFrameStart()
 	engine.GetSceneManager().DoFrameStart()
 }
 
-// DoInit initializes basic engine resources.
+// DoInit initializes basic engine resources. Engine released by a previous
+// cleanup is set as the game engine again.
 func (engine *Engine) DoInit() {
 	Logger.Trace().Str("engine", engine.name).Msg("DoInit")
+	if gameEngine == nil {
+		gameEngine = engine
+	}
 	engine.DoInitSdl()
 	engine.DoInitResources()
 	engine.GetGameManager().DoInit()
@@ -132,8 +198,11 @@ func (engine *Engine) DoInitDebugServer() {
 // event handler.
 func (engine *Engine) DoInitResources() {
 	Logger.Trace().Str("engine", engine.name).Msg("init resources")
+	engine.GetTimeManager().DoInit()
 	engine.GetEventManager().DoInit()
 	engine.GetDelegateManager().DoInit()
+	engine.GetTweenManager().DoInit()
+	engine.GetLoadManager().DoInit()
 	engine.GetResourceManager().DoInit()
 	engine.GetFontManager().DoInit()
 	engine.GetSoundManager().DoInit()
@@ -145,6 +214,12 @@ func (engine *Engine) DoInitResources() {
 func (engine *Engine) DoInitSdl() {
 	var err error
 
+	if engine.headless {
+		Logger.Trace().Str("engine", engine.name).Msg("init headless mode")
+		os.Setenv("SDL_VIDEODRIVER", "dummy")
+		os.Setenv("SDL_AUDIODRIVER", "dummy")
+	}
+
 	Logger.Trace().Str("engine", engine.name).Msg("init sdl module")
 	if err = sdl.Init(sdl.INIT_EVERYTHING); err != nil {
 		Logger.Error().Err(err).Msg("sdl.Init error")
@@ -175,6 +250,11 @@ func (engine *Engine) DoInitSdl() {
 		panic(err)
 	}
 
+	if engine.headless {
+		engine.renderer = NewHeadlessRenderer(engine.width, engine.height)
+		return
+	}
+
 	engine.window, err = sdl.CreateWindow(engine.name,
 		sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED,
 		engine.width, engine.height,
@@ -184,54 +264,61 @@ func (engine *Engine) DoInitSdl() {
 		panic(err)
 	}
 
-	engine.renderer, err = sdl.CreateRenderer(engine.window, -1, sdl.RENDERER_ACCELERATED)
+	renderer, err := sdl.CreateRenderer(engine.window, -1, sdl.RENDERER_ACCELERATED)
 	if err != nil {
 		Logger.Error().Err(err).Msg("CreateRenderer error")
 		panic(err)
 	}
+	engine.renderer = NewSdlRenderer(renderer)
+}
+
+// DoPollEvents reads all sdl events pending. Headless engine does not read
+// any event.
+func (engine *Engine) DoPollEvents() {
+	if engine.headless {
+		return
+	}
+	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+		switch event.(type) {
+		case *sdl.QuitEvent:
+			Logger.Trace().Str("engine", engine.name).Msg("exit engine")
+			engine.active = false
+			break
+		}
+	}
 }
 
-// DoRun runs the engine.
+// DoRun runs the engine. Every frame adds the real elapsed time to the time
+// manager, runs as many fixed update steps as time accumulated allows, and
+// renders once.
 func (engine *Engine) DoRun() {
 	Logger.Trace().Str("engine", engine.name).Msg("run engine")
 
+	lastFrame := sdl.GetTicks()
 	for engine.active {
 
 		frameStart := sdl.GetTicks()
-
-		// Execute everything required at the start of a tick frame.
-		engine.DoFrameStart()
-
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-			switch event.(type) {
-			case *sdl.QuitEvent:
-				Logger.Trace().Str("engine", engine.name).Msg("exit engine")
-				engine.active = false
-				break
-			}
-		}
-
-		engine.DoUpdate()
-
-		engine.renderer.SetDrawColor(255, 255, 255, 255)
-		engine.renderer.Clear()
-
-		engine.DoRender()
-
-		engine.renderer.Present()
-
-		// Execute everything required at the end of the tick frame.
-		engine.DoFrameEnd()
+		engine.DoFrame(float64(frameStart-lastFrame) / 1000)
+		lastFrame = frameStart
 
 		frameTime := sdl.GetTicks() - frameStart
 
 		if frameTime < _delay {
-			// fmt.Println(_delay - frameTime)
 			sdl.Delay(_delay - frameTime)
 		}
 	}
 }
 
+// DoRunFrames runs the engine for the given number of frames. Every frame
+// runs one fixed update step and it does not wait for real time to elapse,
+// so it can be used to run scenes in tests.
+func (engine *Engine) DoRunFrames(frames int) {
+	Logger.Trace().Str("engine", engine.name).Int("frames", frames).Msg("run engine frames")
+	for i := 0; i < frames && engine.active; i++ {
+		engine.DoFrame(engine.GetTimeManager().GetFixedDeltaTime())
+	}
+}
+
 // DoRender calls on OnRender methods to run.
 func (engine *Engine) DoRender() {
 	// Call game manager render.
@@ -245,8 +332,11 @@ func (engine *Engine) DoRender() {
 func (engine *Engine) DoStart(scene IScene) {
 	Logger.Trace().Str("engine", engine.name).Msg("DoStart")
 	engine.active = true
+	engine.GetTimeManager().OnStart()
 	engine.GetEventManager().OnStart()
 	engine.GetDelegateManager().OnStart()
+	engine.GetTweenManager().OnStart()
+	engine.GetLoadManager().OnStart()
 	engine.GetResourceManager().OnStart()
 	engine.GetFontManager().OnStart()
 	engine.GetSoundManager().OnStart()
@@ -268,6 +358,12 @@ func (engine *Engine) DoUpdate() {
 	engine.GetGameManager().OnUpdate()
 	// Execute all update calls.
 	engine.GetSceneManager().OnUpdate()
+	// Call update for all tweens.
+	engine.GetTweenManager().OnUpdate()
+	// Create all assets loaded in the background.
+	engine.GetLoadManager().OnUpdate()
+	// Reload any prefab file changed.
+	GetPrefabManager().OnUpdate()
 	// Call update for delegate handler.
 	engine.GetDelegateManager().OnUpdate()
 	// Execute any post updates behavior.
@@ -308,8 +404,18 @@ func (engine *Engine) GetHeight() int32 {
 	return engine.height
 }
 
+// GetHeadless returns if the engine runs without a display.
+func (engine *Engine) GetHeadless() bool {
+	return engine.headless
+}
+
+// GetLoadManager returns the engine load manager.
+func (engine *Engine) GetLoadManager() ILoadManager {
+	return engine.loadManager
+}
+
 // GetRenderer returns the engine renderer.
-func (engine *Engine) GetRenderer() *sdl.Renderer {
+func (engine *Engine) GetRenderer() IRenderer {
 	return engine.renderer
 }
 
@@ -328,11 +434,33 @@ func (engine *Engine) GetSoundManager() ISoundManager {
 	return engine.soundManager
 }
 
+// GetTimeManager returns the engine time manager.
+func (engine *Engine) GetTimeManager() ITimeManager {
+	return engine.timeManager
+}
+
+// GetTweenManager returns the engine tween manager.
+func (engine *Engine) GetTweenManager() ITweenManager {
+	return engine.tweenManager
+}
+
 // GetWidth returns engine window width.
 func (engine *Engine) GetWidth() int32 {
 	return engine.width
 }
 
+// RunEngineFrames initializes and starts the game engine with the given
+// scene, and it runs the given number of frames. Engine keeps running with
+// DoRunFrames until DoCleanup is called, which cleans up and releases the
+// engine.
+func (engine *Engine) RunEngineFrames(scene IScene, frames int) bool {
+	engine.DoInit()
+	engine.GetGameManager().CreateAssets()
+	engine.DoStart(scene)
+	engine.DoRunFrames(frames)
+	return true
+}
+
 // RunEngine runs the game engine.
 func (engine *Engine) RunEngine(scene IScene) bool {
 	engine.DoInit()
@@ -343,3 +471,9 @@ func (engine *Engine) RunEngine(scene IScene) bool {
 	engine.DoCleanup()
 	return true
 }
+
+// SetHeadless sets if the engine runs without a display. It has to be set
+// before the engine is initialized.
+func (engine *Engine) SetHeadless(headless bool) {
+	engine.headless = headless
+}
@@ -0,0 +1,93 @@
+package engosdl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// newTestEngine creates a headless engine for the given test. Engine is
+// cleaned up and released when the test ends, if it was not released before.
+func newTestEngine(t testing.TB) *engosdl.Engine {
+	engine, err := engosdl.NewHeadlessEngine("test-engine", 400, 300, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if engosdl.GetEngine() == engine {
+			engine.DoCleanup()
+		}
+	})
+	return engine
+}
+
+func TestEngine_Headless(t *testing.T) {
+	engine := newTestEngine(t)
+	if !engine.GetHeadless() {
+		t.Errorf("error creating headless engine")
+	}
+	if other, err := engosdl.NewHeadlessEngine("other-engine", 100, 100, nil); err == nil || other != nil {
+		t.Errorf("error creating headless engine when engine already exists")
+	}
+	var box engosdl.IEntity
+	scene := engosdl.NewScene("test-headless-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		box = engosdl.NewEntity("box")
+		box.GetTransform().SetPositionXY(10, 10)
+		box.AddComponent(components.NewBox("box/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{R: 255, A: 255}, true))
+		box.AddComponent(components.NewMoveTo("box/move-to", engosdl.NewVector(30, 0)))
+		scene.AddEntity(box)
+		return true
+	})
+	engine.AddScene(scene)
+	// First frame activates the scene, next 30 frames run one second.
+	engine.RunEngineFrames(scene, 31)
+
+	renderer, ok := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	if !ok {
+		t.Fatalf("error getting headless renderer")
+	}
+	if renderer.GetFrameCount() != 31 {
+		t.Errorf("error getting number of frames\nexp: %d\ngot: %d\n", 31, renderer.GetFrameCount())
+	}
+	if x := box.GetTransform().GetPosition().X; math.Abs(x-40) > 0.001 {
+		t.Errorf("error moving entity\nexp: %f\ngot: %f\n", 40.0, x)
+	}
+	found := false
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect && drawCall.Dst.W == 20 && drawCall.Color.R == 255 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("error rendering box in headless renderer")
+	}
+}
+
+func TestEngine_HeadlessRestart(t *testing.T) {
+	engine := newTestEngine(t)
+	scene := engosdl.NewScene("test-restart-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		line := engosdl.NewEntity("line")
+		line.AddComponent(components.NewLine("line/line", engosdl.NewVector(10, 10), sdl.Color{A: 255}))
+		scene.AddEntity(line)
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	engine.DoCleanup()
+	engine.RunEngineFrames(scene, 2)
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	lines := 0
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawLine {
+			lines++
+		}
+	}
+	if lines != 4 {
+		t.Errorf("error rendering line in headless renderer\nexp: %d\ngot: %d\n", 4, lines)
+	}
+}
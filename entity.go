@@ -8,8 +8,9 @@ import (
 // ComponentToMarshal identifies component information being marshaled to be
 // saved in JSON format.
 type ComponentToMarshal struct {
-	ComponentName string     `json:"component-type"`
-	Component     IComponent `json:"component-data"`
+	ComponentName string          `json:"component-type"`
+	Component     IComponent      `json:"component-data"`
+	Delegates     []*DelegateLink `json:"delegates,omitempty"`
 }
 
 // EntityToMarshal identifies entity information being marshaled to be saved
@@ -17,6 +18,7 @@ type ComponentToMarshal struct {
 type EntityToMarshal struct {
 	Entity     IEntity               `json:"entity-data"`
 	Components []*ComponentToMarshal `json:"components"`
+	Children   []*EntityToMarshal    `json:"children,omitempty"`
 }
 
 // ComponentToUnmarshal identifies component information from a JSON file
@@ -24,13 +26,17 @@ type EntityToMarshal struct {
 type ComponentToUnmarshal struct {
 	ComponentName string                 `json:"component-type"`
 	Component     map[string]interface{} `json:"component-data"`
+	Delegates     []*DelegateLink        `json:"delegates"`
 }
 
 // EntityToUnmarshal identifies entity information from a JSON file required
-// to build a new entity instance.
+// to build a new entity instance. If prefab is set, entity is created from
+// that prefab, and entity data and components override prefab values.
 type EntityToUnmarshal struct {
+	Prefab     string                  `json:"prefab"`
 	Entity     interface{}             `json:"entity-data"`
 	Components []*ComponentToUnmarshal `json:"components"`
+	Children   []*EntityToUnmarshal    `json:"children"`
 }
 
 // IEntity represents the interface for any entity. Any object in the
@@ -40,6 +46,8 @@ type IEntity interface {
 	AddChild(IEntity) bool
 	AddComponent(IComponent) IEntity
 	AddComponentExt(IComponent, IEntity) IEntity
+	AttachComponent(IComponent) error
+	AttachComponentExt(IComponent, IEntity) error
 	DeleteChild(string) bool
 	DeleteChildByName(string) bool
 	DoDestroy()
@@ -54,23 +62,30 @@ type IEntity interface {
 	GetChildByName(string) IEntity
 	GetChildren() []IEntity
 	GetComponent(IComponent) IComponent
-	GetComponents() []IComponent
+	GetComponentByID(string) IComponent
+	GetComponentByName(IComponent, string) IComponent
+	GetComponents(...IComponent) []IComponent
 	GetDelegateForComponent(IComponent) IDelegate
 	GetDieOnCollision() bool
 	GetDieOnOutOfBounds() bool
 	GetLayer() int
 	GetParent() IEntity
+	GetPool() IEntityPool
 	GetRenderable() bool
 	GetScene() IScene
 	GetTag() string
 	GetTransform() ITransform
 	IsInside(*Vector) bool
+	IsInsideScreen(*Vector) bool
+	OnDespawn()
 	OnRender()
 	OnEnable()
+	OnSpawn()
 	OnStart()
 	OnUpdate()
 	RemoveComponent(IComponent) bool
 	RemoveComponents() bool
+	ResolveDependencies() error
 	SetActive(bool) IEntity
 	SetCache(string, interface{})
 	SetCustomOnUpdate(func(IEntity))
@@ -78,6 +93,7 @@ type IEntity interface {
 	SetDieOnOutOfBounds(bool) IEntity
 	SetLayer(int) IEntity
 	SetParent(IEntity) IEntity
+	SetPool(IEntityPool) IEntity
 	SetRenderable(bool)
 	SetScene(IScene) IEntity
 	SetTag(string) IEntity
@@ -102,6 +118,7 @@ type Entity struct {
 	DieOnOutOfBounds   bool `json:"die-on-out-of-bounds"`
 	customOnUpdate     func(IEntity)
 	cache              map[string]interface{}
+	pool               IEntityPool
 }
 
 var _ IEntity = (*Entity)(nil)
@@ -126,13 +143,18 @@ func NewEntity(name string) *Entity {
 		DieOnOutOfBounds:   false,
 		customOnUpdate:     nil,
 		cache:              make(map[string]interface{}),
+		pool:               nil,
 	}
 }
 
-// AddChild adds a new child to entity children.
+// AddChild adds a new child to entity children. Child transform is linked
+// to the entity transform, so child position, rotation and scale are
+// relative to the entity. Call GetTransform().SetParent(nil) in the child to
+// keep it in world coordinates.
 func (entity *Entity) AddChild(child IEntity) bool {
 	entity.children = append(entity.children, child)
 	child.SetParent(entity)
+	child.GetTransform().SetParent(entity.GetTransform())
 	// Child entity inherits layer from parent.
 	child.SetLayer(entity.GetLayer())
 	return true
@@ -145,29 +167,63 @@ func (entity *Entity) AddComponent(component IComponent) IEntity {
 
 // AddComponentExt adds a new component to the entity, but it provides the
 // entity as an additional parameter. Required for custom entities with
-// methods not defined in IEntity interface.
+// methods not defined in IEntity interface. Entity can have many components
+// with the same type, but the same component can not be added twice. If the
+// entity is already in a scene, component dependencies are resolved. Any
+// error is reported.
 func (entity *Entity) AddComponentExt(component IComponent, extEntity IEntity) IEntity {
+	if err := entity.AttachComponentExt(component, extEntity); err != nil {
+		ReportError(err)
+	}
+	return extEntity
+}
+
+// addComponent adds a new component to the entity without resolving any
+// component dependency. It returns an error if component has already been
+// added.
+func (entity *Entity) addComponent(component IComponent, extEntity IEntity) error {
 	Logger.Trace().Str("entity", extEntity.GetName()).
 		Str("component", component.GetName()).
 		Str("type", reflect.TypeOf(component).String()).
 		Msg("add component")
 	for _, comp := range extEntity.GetComponents() {
-		if reflect.TypeOf(comp) == reflect.TypeOf(component) {
-			err := fmt.Errorf("component type %s already exist", reflect.TypeOf(component))
-			Logger.Error().Err(err).Msg("AddComponent error")
-			panic(err)
+		if comp == component {
+			return fmt.Errorf("component %s with type %s already exist", component.GetName(), reflect.TypeOf(component))
 		}
 	}
 	component.SetEntity(extEntity)
 	entity.components = append(entity.components, component)
 	entity.unloadedComponents = append(entity.unloadedComponents, component)
-	return extEntity
+	entity.refreshQueries()
+	return nil
+}
+
+// AttachComponent adds a new component to the entity. It returns an error if
+// component can not be added.
+func (entity *Entity) AttachComponent(component IComponent) error {
+	return entity.AttachComponentExt(component, entity)
+}
+
+// AttachComponentExt adds a new component to the entity, but it provides the
+// entity as an additional parameter, like AddComponentExt. It returns an
+// error if component has already been added, or if the entity is in a scene
+// and component dependencies can not be resolved. Component is kept in the
+// entity if only dependencies failed.
+func (entity *Entity) AttachComponentExt(component IComponent, extEntity IEntity) error {
+	if err := entity.addComponent(component, extEntity); err != nil {
+		return err
+	}
+	if entity.scene != nil {
+		return entity.ResolveDependencies()
+	}
+	return nil
 }
 
 // DeleteChild removes a child from entity children using child ID.
 func (entity *Entity) DeleteChild(id string) bool {
 	if child, i := entity.getChild(id); child != nil {
 		entity.children = append(entity.children[:i], entity.children[i+1:]...)
+		unlinkTransform(child.GetTransform())
 		return true
 	}
 	return false
@@ -177,12 +233,14 @@ func (entity *Entity) DeleteChild(id string) bool {
 func (entity *Entity) DeleteChildByName(name string) bool {
 	if child, i := entity.getChildByName(name); child != nil {
 		entity.children = append(entity.children[:i], entity.children[i+1:]...)
+		unlinkTransform(child.GetTransform())
 		return true
 	}
 	return false
 }
 
-// DoDestroy calls all methods to clean up entity.
+// DoDestroy calls all methods to clean up entity. Tweens owned by the entity
+// are deleted.
 func (entity *Entity) DoDestroy() {
 	Logger.Trace().Str("entity", entity.GetName()).Msg("DoDestroy")
 	for _, component := range entity.GetComponents() {
@@ -191,6 +249,11 @@ func (entity *Entity) DoDestroy() {
 	entity.components = []IComponent{}
 	entity.loadedComponents = []IComponent{}
 	entity.unloadedComponents = []IComponent{}
+	// Entity destroyed can not be reused by its pool.
+	entity.pool = nil
+	if tweenManager := GetTweenManager(); tweenManager != nil {
+		tweenManager.DeleteTweensFor(entity)
+	}
 
 	// for _, component := range entity.GetComponents() {
 	// 	if !component.GetRemoveOnDestroy() {
@@ -212,8 +275,12 @@ func (entity *Entity) DoDump() *EntityToMarshal {
 		toDump.Components = append(toDump.Components, &ComponentToMarshal{
 			ComponentName: reflect.TypeOf(component).String(),
 			Component:     component,
+			Delegates:     component.GetDelegateLinks(),
 		})
 	}
+	for _, child := range entity.GetChildren() {
+		toDump.Children = append(toDump.Children, child.DoDump())
+	}
 	// }
 	// result, err := json.MarshalIndent(toDump, "", "    ")
 	// if err != nil {
@@ -318,19 +385,71 @@ func (entity *Entity) GetChildren() []IEntity {
 	return entity.children
 }
 
-// GetComponent returns the given component from the entity.
+// GetComponent returns the given component from the entity. If the given
+// component does not belong to the entity, it returns the first component
+// with the same type.
 func (entity *Entity) GetComponent(typ IComponent) IComponent {
+	if index := entity.getComponentIndex(typ); index != -1 {
+		return entity.components[index]
+	}
+	return nil
+}
+
+// GetComponentByID returns the entity component with the given ID.
+func (entity *Entity) GetComponentByID(id string) IComponent {
 	for _, component := range entity.GetComponents() {
-		if reflect.TypeOf(component) == reflect.TypeOf(typ) {
+		if component.GetID() == id {
 			return component
 		}
 	}
 	return nil
 }
 
-// GetComponents returns all entity components.
-func (entity *Entity) GetComponents() []IComponent {
-	return entity.components
+// GetComponentByName returns the entity component with the given type and
+// name. Any component type is matched if type is nil.
+func (entity *Entity) GetComponentByName(typ IComponent, name string) IComponent {
+	for _, component := range entity.GetComponents(typ) {
+		if component.GetName() == name {
+			return component
+		}
+	}
+	return nil
+}
+
+// getComponentIndex returns the index for the given component in the entity
+// components, or for the first component with the same type if the given
+// component does not belong to the entity. It returns -1 if there is not any
+// component found.
+func (entity *Entity) getComponentIndex(typ IComponent) int {
+	for i, component := range entity.components {
+		if component == typ {
+			return i
+		}
+	}
+	for i, component := range entity.components {
+		if reflect.TypeOf(component) == reflect.TypeOf(typ) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetComponents returns all entity components. If any component type is
+// given, it returns only components with any of the given types.
+func (entity *Entity) GetComponents(types ...IComponent) []IComponent {
+	if len(types) == 0 || (len(types) == 1 && types[0] == nil) {
+		return entity.components
+	}
+	result := []IComponent{}
+	for _, component := range entity.components {
+		for _, typ := range types {
+			if reflect.TypeOf(component) == reflect.TypeOf(typ) {
+				result = append(result, component)
+				break
+			}
+		}
+	}
+	return result
 }
 
 // GetDelegateForComponent returns the delegate for the given component.
@@ -365,6 +484,12 @@ func (entity *Entity) GetParent() IEntity {
 	return entity.parent
 }
 
+// GetPool returns the pool the entity belongs to, or nil if the entity does
+// not belong to any pool.
+func (entity *Entity) GetPool() IEntityPool {
+	return entity.pool
+}
+
 // GetRenderable return entity renderable attribute. Entity is not being
 // rendered if this is false.
 func (entity *Entity) GetRenderable() bool {
@@ -392,6 +517,16 @@ func (entity *Entity) IsInside(pos *Vector) bool {
 	return pos.InRect(rect)
 }
 
+// IsInsideScreen returns if the given screen position is inside the entity
+// rectangle. Screen position is converted to world position using the scene
+// camera.
+func (entity *Entity) IsInsideScreen(pos *Vector) bool {
+	if scene := entity.GetScene(); scene != nil && scene.GetCamera() != nil {
+		pos = scene.GetCamera().ScreenToWorld(pos)
+	}
+	return entity.IsInside(pos)
+}
+
 // loadUnloadedComponents proceeds to load any unloaded component.
 func (entity *Entity) loadUnloadedComponents() {
 	unloaded := []IComponent{}
@@ -405,6 +540,10 @@ func (entity *Entity) loadUnloadedComponents() {
 			unloaded = append(unloaded, component)
 		}
 	}
+	if len(unloaded) != len(entity.unloadedComponents) {
+		// Loaded components keep the entity components order.
+		entity.loadedComponents = orderComponents(entity.loadedComponents, entity.components)
+	}
 	entity.unloadedComponents = unloaded
 }
 
@@ -419,6 +558,17 @@ func (entity *Entity) OnRender() {
 	}
 }
 
+// OnDespawn calls all component OnDespawn methods for the entity and its
+// children. It is called when the entity is released to its pool.
+func (entity *Entity) OnDespawn() {
+	for _, component := range entity.GetComponents() {
+		component.OnDespawn()
+	}
+	for _, child := range entity.GetChildren() {
+		child.OnDespawn()
+	}
+}
+
 // OnEnable calls all component OnEnable methods.
 func (entity *Entity) OnEnable() {
 	for _, component := range entity.GetComponents() {
@@ -426,6 +576,17 @@ func (entity *Entity) OnEnable() {
 	}
 }
 
+// OnSpawn calls all component OnSpawn methods for the entity and its
+// children. It is called when the entity is acquired from its pool.
+func (entity *Entity) OnSpawn() {
+	for _, component := range entity.GetComponents() {
+		component.OnSpawn()
+	}
+	for _, child := range entity.GetChildren() {
+		child.OnSpawn()
+	}
+}
+
 // OnStart calls all component OnStart methods.
 func (entity *Entity) OnStart() {
 	Logger.Trace().Str("entity", entity.GetName()).Msg("OnStart")
@@ -448,20 +609,42 @@ func (entity *Entity) OnUpdate() {
 	}
 }
 
-// RemoveComponent removes the given component.
+// refreshQueries updates scene component queries for the entity.
+func (entity *Entity) refreshQueries() {
+	if entity.scene != nil {
+		entity.scene.RefreshQueries(entity)
+	}
+}
+
+// RemoveComponent removes the given component. If the given component does
+// not belong to the entity, the first component with the same type is
+// removed.
 func (entity *Entity) RemoveComponent(component IComponent) bool {
 	Logger.Trace().Str("entity", entity.GetName()).
 		Str("component", component.GetName()).
 		Str("type", reflect.TypeOf(component).String()).
 		Msg("remove component")
-	for i, comp := range entity.GetComponents() {
-		if reflect.TypeOf(comp) == reflect.TypeOf(component) {
-			comp.DoUnLoad()
-			entity.components = append(entity.components[:i], entity.components[i+1:]...)
-			return true
+	i := entity.getComponentIndex(component)
+	if i == -1 {
+		return false
+	}
+	comp := entity.components[i]
+	comp.DoUnLoad()
+	entity.components = append(entity.components[:i], entity.components[i+1:]...)
+	entity.loadedComponents = removeComponentFrom(entity.loadedComponents, comp)
+	entity.unloadedComponents = removeComponentFrom(entity.unloadedComponents, comp)
+	entity.refreshQueries()
+	return true
+}
+
+// removeComponentFrom returns given components without the given component.
+func removeComponentFrom(components []IComponent, component IComponent) []IComponent {
+	for i, comp := range components {
+		if comp == component {
+			return append(components[:i], components[i+1:]...)
 		}
 	}
-	return false
+	return components
 }
 
 // RemoveComponents removes all components.
@@ -472,9 +655,46 @@ func (entity *Entity) RemoveComponents() bool {
 		comp.DoUnLoad()
 	}
 	entity.components = []IComponent{}
+	entity.refreshQueries()
 	return true
 }
 
+// ResolveDependencies checks all component dependencies. Any required
+// dependency missing is added using the constructor registered in the
+// component manager, with its default delegates to register. Components are
+// sorted, so they are placed after all their dependencies, and by priority.
+// It returns an error if any required dependency can not be added or there
+// is any dependency cycle.
+func (entity *Entity) ResolveDependencies() error {
+	for i := 0; i < len(entity.components); i++ {
+		component := entity.components[i]
+		for _, dependency := range component.GetDependencies() {
+			if !dependency.Required || entity.GetComponent(dependency.Component) != nil {
+				continue
+			}
+			componentName := reflect.TypeOf(dependency.Component).String()
+			constructor, ok := GetComponentManager().Constructors[componentName]
+			if !ok {
+				return fmt.Errorf("component %s required by %s not found", componentName, component.GetName())
+			}
+			required := constructor()
+			required.SetName(entity.GetName() + "/" + reflect.TypeOf(dependency.Component).Elem().Name())
+			required.DefaultAddDelegateToRegister()
+			if err := entity.addComponent(required, component.GetEntity()); err != nil {
+				return err
+			}
+		}
+	}
+	sorted, err := sortComponents(entity.components)
+	if err != nil {
+		return err
+	}
+	entity.components = sorted
+	entity.loadedComponents = orderComponents(entity.loadedComponents, sorted)
+	entity.unloadedComponents = orderComponents(entity.unloadedComponents, sorted)
+	return nil
+}
+
 // SetActive sets if the entity is active (enable) or not (disable).
 func (entity *Entity) SetActive(active bool) IEntity {
 	entity.active = active
@@ -523,6 +743,12 @@ func (entity *Entity) SetParent(parent IEntity) IEntity {
 	return entity
 }
 
+// SetPool sets the pool the entity belongs to.
+func (entity *Entity) SetPool(pool IEntityPool) IEntity {
+	entity.pool = pool
+	return entity
+}
+
 // SetRenderable sets entity renderable attribute. Entity is not being
 // rendered if this attribute is false.
 func (entity *Entity) SetRenderable(renderable bool) {
@@ -541,9 +767,31 @@ func (entity *Entity) SetTag(tag string) IEntity {
 	return entity
 }
 
+// unlinkTransform removes the parent from the given transform, keeping its
+// world position, rotation and scale.
+func unlinkTransform(transform ITransform) {
+	position := transform.GetWorldPosition()
+	rotation := transform.GetWorldRotation()
+	scale := transform.GetWorldScale()
+	transform.SetParent(nil)
+	transform.SetPosition(position)
+	transform.SetRotation(rotation)
+	transform.SetScale(scale)
+}
+
 // Unmarshal takes a EntityToMarshal instance and  creates a new entity
-// instance.
+// instance. Children entities and component delegate links are created too.
+// Components without any constructor registered are skipped. Entities with a
+// prefab are created from the prefab registered in the prefab manager.
 func (entity *Entity) Unmarshal(instance *EntityToUnmarshal) {
+	if instance.Prefab != "" {
+		resolved, err := GetPrefabManager().resolve(instance, map[string]bool{})
+		if err != nil {
+			Logger.Error().Err(err).Str("prefab", instance.Prefab).Msg("unmarshal error")
+			return
+		}
+		instance = resolved
+	}
 	obj := instance.Entity.(map[string]interface{})
 	entity.SetName(obj["name"].(string))
 	entity.SetTag(obj["tag"].(string))
@@ -560,9 +808,21 @@ func (entity *Entity) Unmarshal(instance *EntityToUnmarshal) {
 	entity.GetTransform().SetDim(NewVector(dimension["X"].(float64), dimension["Y"].(float64)))
 	entity.GetTransform().SetRotation(rotation.(float64))
 	for _, comp := range instance.Components {
-		constructor := GetComponentManager().Constructors[comp.ComponentName]
+		constructor, ok := GetComponentManager().Constructors[comp.ComponentName]
+		if !ok {
+			Logger.Error().Err(fmt.Errorf("component %s not found", comp.ComponentName)).Str("entity", entity.GetName()).Msg("unmarshal error")
+			continue
+		}
 		component := constructor()
 		component.Unmarshal(comp.Component)
+		for _, link := range comp.Delegates {
+			component.AddDelegateLink(link)
+		}
 		entity.AddComponent(component)
 	}
+	for _, instanceChild := range instance.Children {
+		child := NewEntity("")
+		child.Unmarshal(instanceChild)
+		entity.AddChild(child)
+	}
 }
@@ -0,0 +1,99 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestEntity_MultipleComponents(t *testing.T) {
+	calls := map[string]int{}
+	engosdl.GetComponentManager().RegisterHandler("test-multiple-on-timer", func(component engosdl.IComponent) engosdl.TDelegateSignature {
+		return func(...interface{}) bool {
+			calls["link"]++
+			return true
+		}
+	})
+	entity := engosdl.NewEntity("entity")
+	never := components.NewTimer("entity/never", 0, 0)
+	once := components.NewTimer("entity/once", 0, 1)
+	stats := components.NewEntityStats("entity/stats", 10)
+	entity.AddComponent(never)
+	entity.AddComponent(once)
+	entity.AddComponent(stats)
+	if len(entity.GetComponents()) != 3 || len(entity.GetComponents(&components.Timer{})) != 2 || len(entity.GetComponents(&components.Timer{}, &components.EntityStats{})) != 3 {
+		t.Errorf("error getting components by type")
+	}
+	if entity.GetComponent(&components.Timer{}) != never || entity.GetComponent(once) != once {
+		t.Errorf("error getting component")
+	}
+	if entity.GetComponentByName(&components.Timer{}, "entity/once") != once || entity.GetComponentByName(nil, "entity/stats") != stats || entity.GetComponentByName(&components.Timer{}, "entity/stats") != nil {
+		t.Errorf("error getting component by name")
+	}
+	if entity.GetComponentByID(once.GetID()) != once || entity.GetComponentByID("unknown") != nil {
+		t.Errorf("error getting component by ID")
+	}
+	if err := entity.AttachComponent(once); err == nil || len(entity.GetComponents()) != 3 {
+		t.Errorf("error adding the same component twice")
+	}
+
+	// Delegate registers target the given component instance, or the first
+	// component with the same type.
+	listener := engosdl.NewComponent("entity/listener")
+	listener.AddDelegateToRegister(nil, nil, once, func(...interface{}) bool {
+		calls["once"]++
+		return true
+	})
+	listener.AddDelegateToRegister(nil, nil, &components.Timer{}, func(...interface{}) bool {
+		calls["type"]++
+		return true
+	})
+	link := engosdl.NewDelegateLink("", "", components.ComponentNameTimer, "test-multiple-on-timer")
+	link.ComponentName = "entity/once"
+	listener.AddDelegateLink(link)
+	entity.AddComponent(listener)
+	scene := engosdl.NewScene("multiple", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		scene.AddEntity(entity)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 4)
+	if calls["once"] != 1 || calls["type"] != 0 || calls["link"] != 1 {
+		t.Errorf("error registering component instance delegate\nexp: %d %d %d\ngot: %d %d %d\n", 1, 0, 1, calls["once"], calls["type"], calls["link"])
+	}
+
+	// Component removed is the given component instance.
+	if !entity.RemoveComponent(once) || entity.GetComponentByName(nil, "entity/once") != nil || entity.GetComponent(&components.Timer{}) != never {
+		t.Errorf("error removing component")
+	}
+}
+
+func TestEntity_MultipleColliders(t *testing.T) {
+	var entity engosdl.IEntity
+	scene := engosdl.NewScene("multiple-colliders", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity = engosdl.NewEntity("entity")
+		entity.GetTransform().SetPositionXY(100, 100)
+		entity.AddComponent(components.NewBox("entity/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{A: 255}, true))
+		entity.AddComponent(components.NewCollider2D("entity/collider-2D"))
+		entity.AddComponent(components.NewCollider2D("entity/other-collider-2D"))
+		scene.AddEntity(entity)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	if hits := scene.QueryPoint(engosdl.NewVector(105, 105), nil); len(hits) != 2 {
+		t.Fatalf("error querying entity with two colliders\nexp: %d\ngot: %d\n", 2, len(hits))
+	}
+
+	// All colliders are removed when the entity is deleted.
+	scene.DeleteEntity(entity)
+	if hits := scene.QueryPoint(engosdl.NewVector(105, 105), nil); len(hits) != 0 {
+		t.Errorf("error removing entity colliders\nexp: %d\ngot: %d\n", 0, len(hits))
+	}
+}
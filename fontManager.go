@@ -1,6 +1,8 @@
 package engosdl
 
 import (
+	"fmt"
+
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
@@ -10,10 +12,12 @@ import (
 type IFont interface {
 	IObject
 	Clear()
+	CreateTexture(string, sdl.Color) (ITexture, error)
 	Delete() int
 	GetFilename() string
 	GetFont() *ttf.Font
-	GetTextureFromFont(string, sdl.Color) *sdl.Texture
+	GetFontSize() int
+	GetTextureFromFont(string, sdl.Color) ITexture
 	New()
 }
 
@@ -28,22 +32,41 @@ type Font struct {
 
 var _ IFont = (*Font)(nil)
 
-// NewFont creates a new font instance.
+// NewFont creates a new font instance. It returns nil and the error is
+// reported if font file can not be opened.
 func NewFont(name string, filename string, fontSize int) *Font {
-	var err error
+	result, err := NewFontFromFile(name, filename, fontSize)
+	if err != nil {
+		ReportError(err)
+		return nil
+	}
+	return result
+}
+
+// NewFontFromFile creates a new font instance. It returns an error if font
+// file can not be opened.
+func NewFontFromFile(name string, filename string, fontSize int) (*Font, error) {
 	Logger.Trace().Str("font", name).Str("filename", filename).Msg("new font")
-	result := &Font{
+	font, err := ttf.OpenFont(filename, fontSize)
+	if err != nil {
+		return nil, fmt.Errorf("font %s open error: %w", filename, err)
+	}
+	result := NewFontFromTTF(name, filename, fontSize, font)
+	result.counter = 1
+	return result, nil
+}
+
+// NewFontFromTTF creates a new font instance for a font already opened. Font
+// is not being used yet, so counter starts at zero.
+func NewFontFromTTF(name string, filename string, fontSize int, font *ttf.Font) *Font {
+	Logger.Trace().Str("font", name).Str("filename", filename).Msg("new font from ttf")
+	return &Font{
 		Object:   NewObject(name),
 		filename: filename,
 		fontSize: fontSize,
+		font:     font,
 		counter:  0,
 	}
-	result.font, err = ttf.OpenFont(filename, fontSize)
-	if err != nil {
-		Logger.Error().Err(err).Msg("OpenFont error")
-		panic(err)
-	}
-	return result
 }
 
 // Clear deletes font even if counter is not zero.
@@ -53,6 +76,24 @@ func (r *Font) Clear() {
 	r.Delete()
 }
 
+// CreateTexture returns a texture from the font surface for the given
+// message. It returns an error if texture can not be created.
+func (r *Font) CreateTexture(message string, color sdl.Color) (ITexture, error) {
+	Logger.Trace().Str("font", r.GetName()).Str("filename", r.GetFilename()).Msg("create texture")
+	// surface, err := r.font.RenderUTF8Solid(message, color)
+	surface, err := r.font.RenderUTF8BlendedWrapped(message, color, len(message)*r.fontSize)
+	if err != nil {
+		return nil, fmt.Errorf("font %s render error: %w", r.GetFilename(), err)
+	}
+	defer surface.Free()
+
+	texture, err := GetRenderer().CreateTextureFromSurface(surface)
+	if err != nil {
+		return nil, fmt.Errorf("font %s texture error: %w", r.GetFilename(), err)
+	}
+	return texture, nil
+}
+
 // Delete deletes font and relese all memory.
 func (r *Font) Delete() int {
 	Logger.Trace().Str("font", r.GetName()).Str("filename", r.GetFilename()).Msg("delete font")
@@ -73,21 +114,18 @@ func (r *Font) GetFont() *ttf.Font {
 	return r.font
 }
 
-// GetTextureFromFont returns a texture from the font surface.
-func (r *Font) GetTextureFromFont(message string, color sdl.Color) *sdl.Texture {
-	Logger.Trace().Str("font", r.GetName()).Str("filename", r.GetFilename()).Msg("get texture from font")
-	// surface, err := r.font.RenderUTF8Solid(message, color)
-	surface, err := r.font.RenderUTF8BlendedWrapped(message, color, len(message)*r.fontSize)
-	if err != nil {
-		Logger.Error().Err(err).Msg("RenderUTF8Solid error")
-		panic(err)
-	}
-	defer surface.Free()
+// GetFontSize returns font size.
+func (r *Font) GetFontSize() int {
+	return r.fontSize
+}
 
-	texture, err := GetRenderer().CreateTextureFromSurface(surface)
+// GetTextureFromFont returns a texture from the font surface. It returns nil
+// and the error is reported if texture can not be created.
+func (r *Font) GetTextureFromFont(message string, color sdl.Color) ITexture {
+	texture, err := r.CreateTexture(message, color)
 	if err != nil {
-		Logger.Error().Err(err).Msg("CreateTextureFromSurface error")
-		panic(err)
+		ReportError(err)
+		return nil
 	}
 	return texture
 }
@@ -101,14 +139,17 @@ func (r *Font) New() {
 // fonts.
 type IFontManager interface {
 	IObject
+	AddFont(IFont) bool
 	Clear()
 	CreateFont(string, string, int) IFont
 	DoInit()
 	DeleteFont(IFont) bool
 	GetFont(string) IFont
 	GetFontByFilename(string) IFont
+	GetFontByFilenameAndSize(string, int) IFont
 	GetFontByName(string) IFont
 	GetFonts() []IFont
+	LoadFont(string, string, int) (IFont, error)
 	OnStart()
 }
 
@@ -130,6 +171,17 @@ func NewFontManager(name string) *FontManager {
 
 }
 
+// AddFont adds a font already created to the font handler. It returns false
+// if there is already a font with the same filename and font size.
+func (h *FontManager) AddFont(font IFont) bool {
+	Logger.Trace().Str("font-manager", h.GetName()).Str("name", font.GetName()).Str("filename", font.GetFilename()).Msg("AddFont")
+	if h.GetFontByFilenameAndSize(font.GetFilename(), font.GetFontSize()) != nil {
+		return false
+	}
+	h.fonts = append(h.fonts, font)
+	return true
+}
+
 // Clear removes all fonts from the font handler.
 func (h *FontManager) Clear() {
 	Logger.Trace().Str("font-manager", h.GetName()).Msg("Clear")
@@ -140,17 +192,15 @@ func (h *FontManager) Clear() {
 }
 
 // CreateFont creates a new font. If the same font has already
-// been created with the same filename, existing font is returned.
+// been created with the same filename and font size, existing font is
+// returned. It returns
+// nil and the error is reported if font can not be opened.
 func (h *FontManager) CreateFont(name string, filename string, fontSize int) IFont {
-	Logger.Trace().Str("font-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("CreateFont")
-	for _, font := range h.fonts {
-		if font.GetFilename() == filename {
-			font.New()
-			return font
-		}
+	font, err := h.LoadFont(name, filename, fontSize)
+	if err != nil {
+		ReportError(err)
+		return nil
 	}
-	font := NewFont(name, filename, fontSize)
-	h.fonts = append(h.fonts, font)
 	return font
 }
 
@@ -195,6 +245,17 @@ func (h *FontManager) GetFontByFilename(filename string) IFont {
 	return nil
 }
 
+// GetFontByFilenameAndSize returns the font with the given filename and font
+// size.
+func (h *FontManager) GetFontByFilenameAndSize(filename string, fontSize int) IFont {
+	for _, font := range h.fonts {
+		if font.GetFilename() == filename && font.GetFontSize() == fontSize {
+			return font
+		}
+	}
+	return nil
+}
+
 // GetFontByName returns the font with the given name.
 func (h *FontManager) GetFontByName(name string) IFont {
 	for _, font := range h.fonts {
@@ -210,6 +271,23 @@ func (h *FontManager) GetFonts() []IFont {
 	return h.fonts
 }
 
+// LoadFont creates a new font. If the same font has already been created
+// with the same filename and font size, existing font is returned. It
+// returns an error if font can not be opened.
+func (h *FontManager) LoadFont(name string, filename string, fontSize int) (IFont, error) {
+	Logger.Trace().Str("font-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("LoadFont")
+	if font := h.GetFontByFilenameAndSize(filename, fontSize); font != nil {
+		font.New()
+		return font, nil
+	}
+	font, err := NewFontFromFile(name, filename, fontSize)
+	if err != nil {
+		return nil, err
+	}
+	h.fonts = append(h.fonts, font)
+	return font, nil
+}
+
 // OnStart initializes all font handler structure.
 func (h *FontManager) OnStart() {
 	Logger.Trace().Str("font-manager", h.GetName()).Msg("OnStart")
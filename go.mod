@@ -1,6 +1,6 @@
 module github.com/jrecuero/engosdl
 
-go 1.15
+go 1.18
 
 require (
 	github.com/gorilla/mux v1.8.0
@@ -0,0 +1,246 @@
+package engosdl
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Draw call operations recorded by the headless renderer.
+const (
+	// DrawClear identifies a clear operation.
+	DrawClear string = "clear"
+	// DrawCopy identifies a texture copy operation.
+	DrawCopy string = "copy"
+	// DrawLine identifies a line operation.
+	DrawLine string = "line"
+	// DrawPoint identifies a point operation.
+	DrawPoint string = "point"
+	// DrawRect identifies a rectangle operation.
+	DrawRect string = "rect"
+	// DrawFillRect identifies a filled rectangle operation.
+	DrawFillRect string = "fill-rect"
+)
+
+// DrawCall represents a draw operation recorded by the headless renderer.
+type DrawCall struct {
+	Op        string
+	Texture   ITexture
+	Src       *sdl.Rect
+	Dst       *sdl.Rect
+	Angle     float64
+	Flip      sdl.RendererFlip
+	Color     sdl.Color
+	BlendMode sdl.BlendMode
+	Alpha     uint8
+	Target    ITexture
+}
+
+// HeadlessTexture is the texture implementation for the headless renderer.
+// It only keeps texture dimensions and modulation values.
+type HeadlessTexture struct {
+	width     int32
+	height    int32
+	alpha     uint8
+	color     sdl.Color
+	blendMode sdl.BlendMode
+	destroyed bool
+}
+
+var _ ITexture = (*HeadlessTexture)(nil)
+
+// NewHeadlessTexture creates a new headless texture instance.
+func NewHeadlessTexture(width int32, height int32) *HeadlessTexture {
+	return &HeadlessTexture{
+		width:  width,
+		height: height,
+		alpha:  255,
+		color:  sdl.Color{R: 255, G: 255, B: 255, A: 255},
+	}
+}
+
+// Destroy destroys the texture.
+func (t *HeadlessTexture) Destroy() error {
+	t.destroyed = true
+	return nil
+}
+
+// GetAlphaMod returns the texture alpha modulation.
+func (t *HeadlessTexture) GetAlphaMod() uint8 {
+	return t.alpha
+}
+
+// GetColorMod returns the texture color modulation.
+func (t *HeadlessTexture) GetColorMod() sdl.Color {
+	return t.color
+}
+
+// IsDestroyed returns if the texture has been destroyed.
+func (t *HeadlessTexture) IsDestroyed() bool {
+	return t.destroyed
+}
+
+// Query returns texture format, access, width and height.
+func (t *HeadlessTexture) Query() (uint32, int, int32, int32, error) {
+	return 0, 0, t.width, t.height, nil
+}
+
+// SetAlphaMod sets the alpha value multiplied into render copy operations.
+func (t *HeadlessTexture) SetAlphaMod(alpha uint8) error {
+	t.alpha = alpha
+	return nil
+}
+
+// SetBlendMode sets the blend mode used for texture copy operations.
+func (t *HeadlessTexture) SetBlendMode(blendMode sdl.BlendMode) error {
+	t.blendMode = blendMode
+	return nil
+}
+
+// SetColorMod sets the color value multiplied into render copy operations.
+func (t *HeadlessTexture) SetColorMod(r uint8, g uint8, b uint8) error {
+	t.color = sdl.Color{R: r, G: g, B: b, A: 255}
+	return nil
+}
+
+// HeadlessRenderer is the renderer implementation that does not require any
+// display. It records all draw calls for every frame, so they can be
+// inspected by tests.
+type HeadlessRenderer struct {
+	width      int32
+	height     int32
+	color      sdl.Color
+	blendMode  sdl.BlendMode
+	target     ITexture
+	drawCalls  []*DrawCall
+	lastFrame  []*DrawCall
+	frameCount int
+}
+
+var _ IRenderer = (*HeadlessRenderer)(nil)
+
+// NewHeadlessRenderer creates a new headless renderer instance.
+func NewHeadlessRenderer(width int32, height int32) *HeadlessRenderer {
+	Logger.Trace().Str("renderer", "headless").Msg("new headless renderer")
+	return &HeadlessRenderer{
+		width:     width,
+		height:    height,
+		drawCalls: []*DrawCall{},
+		lastFrame: []*DrawCall{},
+	}
+}
+
+// copyRect returns a copy of the given rectangle, so recorded draw calls are
+// not modified by callers reusing rectangles.
+func copyRect(rect *sdl.Rect) *sdl.Rect {
+	if rect == nil {
+		return nil
+	}
+	result := *rect
+	return &result
+}
+
+// record adds a new draw call for the running frame. Copy operations keep
+// the texture alpha modulation at the time of the copy.
+func (r *HeadlessRenderer) record(drawCall *DrawCall) error {
+	drawCall.Color = r.color
+	drawCall.BlendMode = r.blendMode
+	drawCall.Target = r.target
+	if texture, ok := drawCall.Texture.(*HeadlessTexture); ok {
+		drawCall.Alpha = texture.alpha
+	}
+	r.drawCalls = append(r.drawCalls, drawCall)
+	return nil
+}
+
+// Clear clears the current rendering target with the drawing color.
+func (r *HeadlessRenderer) Clear() error {
+	return r.record(&DrawCall{Op: DrawClear})
+}
+
+// Copy copies a portion of the texture to the current rendering target.
+func (r *HeadlessRenderer) Copy(texture ITexture, src *sdl.Rect, dst *sdl.Rect) error {
+	return r.record(&DrawCall{Op: DrawCopy, Texture: texture, Src: copyRect(src), Dst: copyRect(dst)})
+}
+
+// CopyEx copies a portion of the texture to the current rendering target,
+// with the given rotation and flip.
+func (r *HeadlessRenderer) CopyEx(texture ITexture, src *sdl.Rect, dst *sdl.Rect, angle float64, center *sdl.Point, flip sdl.RendererFlip) error {
+	return r.record(&DrawCall{Op: DrawCopy, Texture: texture, Src: copyRect(src), Dst: copyRect(dst), Angle: angle, Flip: flip})
+}
+
+// CreateRenderTexture creates a texture that can be used as rendering
+// target.
+func (r *HeadlessRenderer) CreateRenderTexture(width int32, height int32) (ITexture, error) {
+	return NewHeadlessTexture(width, height), nil
+}
+
+// CreateTextureFromSurface creates a texture with the surface dimensions.
+func (r *HeadlessRenderer) CreateTextureFromSurface(surface *sdl.Surface) (ITexture, error) {
+	return NewHeadlessTexture(surface.W, surface.H), nil
+}
+
+// Destroy destroys the renderer. Draw calls for the last frame are kept, so
+// they can be inspected after the engine has finished.
+func (r *HeadlessRenderer) Destroy() error {
+	r.drawCalls = []*DrawCall{}
+	return nil
+}
+
+// DrawLine draws a line on the current rendering target.
+func (r *HeadlessRenderer) DrawLine(x1 int32, y1 int32, x2 int32, y2 int32) error {
+	return r.record(&DrawCall{Op: DrawLine, Dst: &sdl.Rect{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}})
+}
+
+// DrawPoint draws a point on the current rendering target.
+func (r *HeadlessRenderer) DrawPoint(x int32, y int32) error {
+	return r.record(&DrawCall{Op: DrawPoint, Dst: &sdl.Rect{X: x, Y: y, W: 1, H: 1}})
+}
+
+// DrawRect draws a rectangle on the current rendering target.
+func (r *HeadlessRenderer) DrawRect(rect *sdl.Rect) error {
+	return r.record(&DrawCall{Op: DrawRect, Dst: copyRect(rect)})
+}
+
+// FillRect fills a rectangle on the current rendering target.
+func (r *HeadlessRenderer) FillRect(rect *sdl.Rect) error {
+	return r.record(&DrawCall{Op: DrawFillRect, Dst: copyRect(rect)})
+}
+
+// GetDrawCalls returns all draw calls recorded for the last presented frame.
+func (r *HeadlessRenderer) GetDrawCalls() []*DrawCall {
+	return r.lastFrame
+}
+
+// GetFrameCount returns the number of frames presented.
+func (r *HeadlessRenderer) GetFrameCount() int {
+	return r.frameCount
+}
+
+// GetOutputSize returns the output size in pixels of the rendering context.
+func (r *HeadlessRenderer) GetOutputSize() (int32, int32, error) {
+	return r.width, r.height, nil
+}
+
+// Present closes the running frame, draw calls recorded are moved to the
+// last frame.
+func (r *HeadlessRenderer) Present() {
+	r.lastFrame = r.drawCalls
+	r.drawCalls = []*DrawCall{}
+	r.frameCount++
+}
+
+// SetDrawBlendMode sets the blend mode used for drawing operations.
+func (r *HeadlessRenderer) SetDrawBlendMode(blendMode sdl.BlendMode) error {
+	r.blendMode = blendMode
+	return nil
+}
+
+// SetDrawColor sets the color used for drawing operations.
+func (r *HeadlessRenderer) SetDrawColor(red uint8, green uint8, blue uint8, alpha uint8) error {
+	r.color = sdl.Color{R: red, G: green, B: blue, A: alpha}
+	return nil
+}
+
+// SetRenderTarget sets the texture to be used as rendering target. Draw
+// calls record the rendering target, nil target is the display.
+func (r *HeadlessRenderer) SetRenderTarget(texture ITexture) error {
+	r.target = texture
+	return nil
+}
@@ -0,0 +1,225 @@
+package engosdl
+
+import (
+	"github.com/veandco/go-sdl2/mix"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// LoadProgressName represents on load progress delegate.
+const LoadProgressName = "on-load-progress"
+
+// loadWorkers is the maximum number of goroutines loading assets.
+const loadWorkers int = 4
+
+// assetData contains the data loaded for an asset before any engine object
+// is created for it.
+type assetData struct {
+	asset   *Asset
+	surface *sdl.Surface
+	font    *ttf.Font
+	sound   *mix.Music
+	chunk   *mix.Chunk
+	err     error
+}
+
+// ILoadManager represents the interface for the load manager. Load manager
+// loads assets in the background while the engine keeps running.
+type ILoadManager interface {
+	IObject
+	DoInit()
+	GetDelegate() IDelegate
+	GetErrors() []error
+	GetProgress() float64
+	IsLoading() bool
+	LoadAssets(...*Asset) bool
+	LoadScene(IScene, ...ITransition) bool
+	OnStart()
+	OnUpdate()
+}
+
+// LoadManager is the default implementation for the load manager interface.
+// Asset files are read and decoded in worker goroutines, and resources,
+// fonts and sounds are created in their managers in the main thread, so
+// textures are always created in the main thread. Font assets are keyed by
+// filename and font size. Load manager delegate is
+// triggered every update step any asset has been loaded, with the number of
+// assets loaded and the total number of assets.
+type LoadManager struct {
+	*Object
+	delegate   IDelegate
+	results    chan *assetData
+	loaded     int
+	total      int
+	errors     []error
+	scene      IScene
+	transition ITransition
+}
+
+var _ ILoadManager = (*LoadManager)(nil)
+
+// NewLoadManager creates a new load manager instance.
+func NewLoadManager(name string) *LoadManager {
+	Logger.Trace().Str("load-manager", name).Msg("new load-manager")
+	return &LoadManager{
+		Object:     NewObject(name),
+		delegate:   nil,
+		results:    nil,
+		loaded:     0,
+		total:      0,
+		errors:     []error{},
+		scene:      nil,
+		transition: nil,
+	}
+}
+
+// createAsset creates the resource, font or sound for the given asset data
+// in its manager. Fonts are opened here, so they are always opened in the
+// main thread. Data is released if the asset has been created by any other
+// way while it was being loaded.
+func (h *LoadManager) createAsset(data *assetData) {
+	asset := data.asset
+	if asset.Kind == AssetFont && data.err == nil {
+		data.font, data.err = ttf.OpenFont(asset.Filename, asset.FontSize)
+	}
+	if data.err != nil {
+		Logger.Error().Err(data.err).Str("filename", asset.Filename).Msg("load asset error")
+		h.errors = append(h.errors, data.err)
+		return
+	}
+	switch asset.Kind {
+	case AssetImage:
+		if resource := NewResourceFromSurface(asset.Name, asset.Filename, asset.Format, data.surface); !GetResourceManager().AddResource(resource) {
+			resource.Clear()
+		}
+	case AssetFont:
+		if font := NewFontFromTTF(asset.Name, asset.Filename, asset.FontSize, data.font); !GetFontManager().AddFont(font) {
+			font.Clear()
+		}
+	case AssetSound:
+		if sound := NewSoundFromMix(asset.Name, asset.Filename, asset.Format, data.sound, data.chunk); !GetSoundManager().AddSound(sound) {
+			sound.Clear()
+		}
+	}
+}
+
+// DoInit initializes all load manager resources. It creates the load manager
+// delegate, so delegate manager has to be initialized before.
+func (h *LoadManager) DoInit() {
+	Logger.Trace().Str("load-manager", h.GetName()).Msg("DoInit")
+	h.results = nil
+	h.loaded, h.total = 0, 0
+	h.errors = []error{}
+	h.scene, h.transition = nil, nil
+	h.delegate = GetDelegateManager().CreateDelegate(h, LoadProgressName)
+}
+
+// GetDelegate returns the delegate triggered when assets are loaded.
+func (h *LoadManager) GetDelegate() IDelegate {
+	return h.delegate
+}
+
+// GetErrors returns all errors found loading assets.
+func (h *LoadManager) GetErrors() []error {
+	return h.errors
+}
+
+// GetProgress returns the loading progress from 0 to 1.
+func (h *LoadManager) GetProgress() float64 {
+	if h.total == 0 {
+		return 1
+	}
+	return float64(h.loaded) / float64(h.total)
+}
+
+// IsLoading returns if there are assets being loaded.
+func (h *LoadManager) IsLoading() bool {
+	return h.results != nil
+}
+
+// LoadAssets starts loading the given assets in the background. Assets
+// already created in their managers are not loaded again, and assets with the
+// same key are loaded only once. It returns false
+// if there are assets being loaded.
+func (h *LoadManager) LoadAssets(assets ...*Asset) bool {
+	Logger.Trace().Str("load-manager", h.GetName()).Int("assets", len(assets)).Msg("LoadAssets")
+	if h.IsLoading() {
+		return false
+	}
+	pending := []*Asset{}
+	keys := map[string]bool{}
+	for _, asset := range assets {
+		if !keys[asset.key()] && !asset.isLoaded() {
+			keys[asset.key()] = true
+			pending = append(pending, asset)
+		}
+	}
+	h.loaded, h.total = 0, len(pending)
+	h.errors = []error{}
+	results := make(chan *assetData, len(pending))
+	jobs := make(chan *Asset, len(pending))
+	for _, asset := range pending {
+		jobs <- asset
+	}
+	close(jobs)
+	for i := 0; i < loadWorkers && i < len(pending); i++ {
+		go func() {
+			for asset := range jobs {
+				results <- asset.load()
+			}
+		}()
+	}
+	h.results = results
+	return true
+}
+
+// LoadScene starts loading all assets required by the given scene, and the
+// scene is set as active when all of them have been loaded. Optional
+// transition is used to change to the scene. Any loading screen scene should
+// be active while assets are being loaded.
+func (h *LoadManager) LoadScene(scene IScene, transitions ...ITransition) bool {
+	Logger.Trace().Str("load-manager", h.GetName()).Str("scene", scene.GetName()).Msg("LoadScene")
+	if !h.LoadAssets(scene.GetAssets()...) {
+		return false
+	}
+	h.scene = scene
+	h.transition = getTransition(transitions)
+	return true
+}
+
+// OnStart is called when the engine starts.
+func (h *LoadManager) OnStart() {
+	Logger.Trace().Str("load-manager", h.GetName()).Msg("OnStart")
+}
+
+// OnUpdate creates all assets loaded since the previous update step and it
+// triggers load manager delegate. When all assets have been loaded, the
+// scene being loaded is set as active.
+func (h *LoadManager) OnUpdate() {
+	if h.results == nil {
+		return
+	}
+	progress := false
+loop:
+	for h.loaded < h.total {
+		select {
+		case data := <-h.results:
+			h.createAsset(data)
+			h.loaded++
+			progress = true
+		default:
+			break loop
+		}
+	}
+	finished := h.loaded == h.total
+	if (progress || finished) && h.delegate != nil {
+		GetDelegateManager().TriggerDelegate(h.delegate, false, h.loaded, h.total)
+	}
+	if finished {
+		h.results = nil
+		if h.scene != nil {
+			GetSceneManager().SetActiveScene(h.scene, h.transition)
+		}
+		h.scene, h.transition = nil, nil
+	}
+}
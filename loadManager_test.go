@@ -0,0 +1,151 @@
+package engosdl_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/engosdl"
+)
+
+// fontFilename is a font file used as a test fixture.
+const fontFilename = "apps/flier/fonts/lato.ttf"
+
+// writePNG writes a PNG image with the given size.
+func writePNG(t *testing.T, filename string, width int, height int) {
+	file, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeWAV writes a 16 bits mono PCM WAV file with the given number of
+// silent samples.
+func writeWAV(t *testing.T, filename string, samples int) {
+	var buffer bytes.Buffer
+	buffer.WriteString("RIFF")
+	binary.Write(&buffer, binary.LittleEndian, uint32(36+samples*2))
+	buffer.WriteString("WAVEfmt ")
+	binary.Write(&buffer, binary.LittleEndian, []uint32{16})
+	binary.Write(&buffer, binary.LittleEndian, []uint16{1, 1})
+	binary.Write(&buffer, binary.LittleEndian, []uint32{22050, 22050 * 2})
+	binary.Write(&buffer, binary.LittleEndian, []uint16{2, 16})
+	buffer.WriteString("data")
+	binary.Write(&buffer, binary.LittleEndian, uint32(samples*2))
+	buffer.Write(make([]byte, samples*2))
+	if err := ioutil.WriteFile(filename, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadManager_LoadScene(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engosdl-load")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filenames := map[string]string{}
+	for _, name := range []string{"player.png", "enemy.bmp", "broken.ttf", "shot.wav"} {
+		filenames[name] = filepath.Join(dir, name)
+	}
+	writePNG(t, filenames["player.png"], 16, 16)
+	writeBMP(t, filenames["enemy.bmp"], 16, 16)
+	writeWAV(t, filenames["shot.wav"], 100)
+	if err := ioutil.WriteFile(filenames["broken.ttf"], []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	game := engosdl.NewScene("game", "test")
+	game.AddAssets(
+		engosdl.NewImageAsset("player", filenames["player.png"]),
+		engosdl.NewImageAsset("enemy", filenames["enemy.bmp"]),
+		engosdl.NewImageAsset("player-again", filenames["player.png"]),
+		engosdl.NewFontAsset("font", fontFilename, 24),
+		engosdl.NewFontAsset("font-again", fontFilename, 24),
+		engosdl.NewFontAsset("font-small", fontFilename, 12),
+		engosdl.NewFontAsset("broken", filenames["broken.ttf"], 24),
+		engosdl.NewSoundAsset("shot", filenames["shot.wav"], engosdl.SoundWAV),
+		engosdl.NewSoundAsset("missing", filepath.Join(dir, "missing.wav"), engosdl.SoundWAV))
+	game.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool { return true })
+	progress := [][]int{}
+	loading := engosdl.NewScene("loading", "test")
+	loading.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity := engosdl.NewEntity("loading-screen")
+		listener := engosdl.NewComponent("loading-screen/listener")
+		listener.AddDelegateToRegister(engosdl.GetLoadManager().GetDelegate(), nil, nil, func(params ...interface{}) bool {
+			progress = append(progress, []int{params[0].(int), params[1].(int)})
+			return true
+		})
+		entity.AddComponent(listener)
+		scene.AddEntity(entity)
+		if !engosdl.GetLoadManager().LoadScene(game) {
+			t.Errorf("error starting to load scene")
+		}
+		if engosdl.GetLoadManager().LoadScene(game) {
+			t.Errorf("error loading while there are assets being loaded")
+		}
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(loading)
+	engine.AddScene(game)
+	engine.RunEngineFrames(loading, 1)
+	// Font fixture is not a temporary file, so fonts are released to be
+	// loaded again in any other run.
+	defer engosdl.GetFontManager().Clear()
+	for i := 0; i < 100 && engosdl.GetSceneManager().GetActiveScene() != game; i++ {
+		time.Sleep(time.Millisecond)
+		engine.DoRunFrames(1)
+	}
+	if engosdl.GetSceneManager().GetActiveScene() != game {
+		t.Fatalf("error setting loaded scene as active")
+	}
+	if engosdl.GetLoadManager().IsLoading() || engosdl.GetLoadManager().GetProgress() != 1 {
+		t.Errorf("error finishing load\nexp: %f\ngot: %f\n", 1.0, engosdl.GetLoadManager().GetProgress())
+	}
+	if len(progress) == 0 || progress[len(progress)-1][0] != 7 || progress[len(progress)-1][1] != 7 {
+		t.Fatalf("error triggering load progress\nexp: %v\ngot: %v\n", []int{7, 7}, progress)
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i][0] <= progress[i-1][0] {
+			t.Errorf("error triggering load progress\nexp: %d\ngot: %d\n", progress[i-1][0]+1, progress[i][0])
+		}
+	}
+	// Broken font and missing sound are not loaded.
+	if errors := engosdl.GetLoadManager().GetErrors(); len(errors) != 2 {
+		t.Errorf("error loading broken and missing assets\nexp: %d\ngot: %d\n", 2, len(errors))
+	}
+	if engosdl.GetFontManager().GetFontByFilename(filenames["broken.ttf"]) != nil {
+		t.Errorf("error creating broken font")
+	}
+	font, small := engosdl.GetFontManager().GetFontByFilenameAndSize(fontFilename, 24), engosdl.GetFontManager().GetFontByFilenameAndSize(fontFilename, 12)
+	if font == nil || small == nil || font.GetName() != "font" || small.GetName() != "font-small" {
+		t.Errorf("error creating loaded fonts for every font size")
+	}
+	if engosdl.GetSoundManager().GetSoundByFilename(filenames["shot.wav"]) == nil {
+		t.Errorf("error creating loaded sound")
+	}
+
+	// Loaded resources are used when they are created, and released when
+	// they are not used anymore.
+	resource := engosdl.GetResourceManager().GetResourceByFilename(filenames["player.png"])
+	if resource == nil || resource.GetName() != "player" || engosdl.GetResourceManager().GetResourceByFilename(filenames["enemy.bmp"]) == nil {
+		t.Fatalf("error creating loaded resources")
+	}
+	if got := engosdl.GetResourceManager().CreateResource("player", filenames["player.png"], engosdl.FormatPNG); got.GetID() != resource.GetID() {
+		t.Errorf("error using loaded resource\nexp: %s\ngot: %s\n", resource.GetID(), got.GetID())
+	}
+	engosdl.GetResourceManager().DeleteResource(resource)
+	if engosdl.GetResourceManager().GetResourceByFilename(filenames["player.png"]) != nil {
+		t.Errorf("error releasing loaded resource")
+	}
+}
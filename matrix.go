@@ -0,0 +1,69 @@
+package engosdl
+
+import "math"
+
+// Matrix represents a 2-Dimensional affine transformation. It maps any
+// point (x, y) to (A*x + C*y + Tx, B*x + D*y + Ty).
+type Matrix struct {
+	A  float64
+	B  float64
+	C  float64
+	D  float64
+	Tx float64
+	Ty float64
+}
+
+// NewIdentityMatrix creates a new matrix instance that does not change any
+// point.
+func NewIdentityMatrix() *Matrix {
+	return &Matrix{A: 1, D: 1}
+}
+
+// NewTRSMatrix creates a new matrix instance that scales, then rotates and
+// then translates any point. Rotation is given in degrees.
+func NewTRSMatrix(position *Vector, rotation float64, scale *Vector) *Matrix {
+	sin, cos := math.Sincos(rotation * math.Pi / 180)
+	return &Matrix{
+		A:  cos * scale.X,
+		B:  sin * scale.X,
+		C:  -sin * scale.Y,
+		D:  cos * scale.Y,
+		Tx: position.X,
+		Ty: position.Y,
+	}
+}
+
+// Apply returns the given point transformed by the matrix.
+func (m *Matrix) Apply(v *Vector) *Vector {
+	return NewVector(m.A*v.X+m.C*v.Y+m.Tx, m.B*v.X+m.D*v.Y+m.Ty)
+}
+
+// Inverse returns the inverse matrix. It returns nil if matrix can not be
+// inverted.
+func (m *Matrix) Inverse() *Matrix {
+	det := m.A*m.D - m.B*m.C
+	if det == 0 {
+		return nil
+	}
+	return &Matrix{
+		A:  m.D / det,
+		B:  -m.B / det,
+		C:  -m.C / det,
+		D:  m.A / det,
+		Tx: (m.C*m.Ty - m.D*m.Tx) / det,
+		Ty: (m.B*m.Tx - m.A*m.Ty) / det,
+	}
+}
+
+// Multiply returns the matrix resulting of applying first the other matrix
+// and then the matrix.
+func (m *Matrix) Multiply(other *Matrix) *Matrix {
+	return &Matrix{
+		A:  m.A*other.A + m.C*other.B,
+		B:  m.B*other.A + m.D*other.B,
+		C:  m.A*other.C + m.C*other.D,
+		D:  m.B*other.C + m.D*other.D,
+		Tx: m.A*other.Tx + m.C*other.Ty + m.Tx,
+		Ty: m.B*other.Tx + m.D*other.Ty + m.Ty,
+	}
+}
@@ -0,0 +1,226 @@
+package engosdl
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// CurveKey represents a key in a curve. Time is the normalized particle age,
+// from 0 to 1, and Value is the blend factor between start and end values at
+// that time.
+type CurveKey struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+}
+
+// NewCurveKey creates a new curve key instance.
+func NewCurveKey(time float64, value float64) *CurveKey {
+	return &CurveKey{
+		Time:  time,
+		Value: value,
+	}
+}
+
+// EvaluateCurve returns the curve value at the given time. Keys have to be
+// sorted by time and values are linearly interpolated between keys. Empty
+// curve is linear from 0 to 1.
+func EvaluateCurve(keys []*CurveKey, time float64) float64 {
+	if len(keys) == 0 {
+		return clampFloat(time, 0, 1)
+	}
+	if time <= keys[0].Time {
+		return keys[0].Value
+	}
+	for i := 1; i < len(keys); i++ {
+		if time < keys[i].Time {
+			prev := keys[i-1]
+			factor := (time - prev.Time) / (keys[i].Time - prev.Time)
+			return prev.Value + (keys[i].Value-prev.Value)*factor
+		}
+	}
+	return keys[len(keys)-1].Value
+}
+
+// LerpColor returns the color between from and to colors for the given
+// factor, where 0 is the from color and 1 is the to color.
+func LerpColor(from sdl.Color, to sdl.Color, factor float64) sdl.Color {
+	lerp := func(a uint8, b uint8) uint8 {
+		return uint8(clampFloat(float64(a)+(float64(b)-float64(a))*factor, 0, 255) + 0.5)
+	}
+	return sdl.Color{R: lerp(from.R, to.R), G: lerp(from.G, to.G), B: lerp(from.B, to.B), A: lerp(from.A, to.A)}
+}
+
+// clampFloat returns the given value limited to the given range.
+func clampFloat(value float64, min float64, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// ParticleBurst represents a number of particles emitted at once. Time is
+// given in seconds from the start of the emitter cycle.
+type ParticleBurst struct {
+	Time  float64 `json:"time"`
+	Count int     `json:"count"`
+}
+
+// NewParticleBurst creates a new particle burst instance.
+func NewParticleBurst(time float64, count int) *ParticleBurst {
+	return &ParticleBurst{
+		Time:  time,
+		Count: count,
+	}
+}
+
+// ParticleConfig represents how particles are emitted, updated and
+// displayed by a particle emitter.
+//
+// Rate is the number of particles emitted per second and Bursts are emitted
+// at a given time in the emitter cycle. Duration is the emitter cycle length
+// in seconds, emitter stops at the end of the cycle unless Loop is set, zero
+// duration emits forever. MaxParticles is the size of the particle pool, no
+// particles are emitted while the pool is full.
+//
+// Lifetime, Speed and Angle are randomized with their spread, so every
+// particle value is in the range value-spread to value+spread. Angle is given
+// in degrees, zero angle points right and positive angles are clockwise, and
+// it is relative to the entity rotation. Gravity is the acceleration applied
+// to all particles in pixels per second squared.
+//
+// Color and size change from start to end values through the particle
+// lifetime, ColorCurve and SizeCurve give the blend factor for the particle
+// age, default curves are linear. If Texture is not empty, particles display
+// the texture, otherwise particles are displayed as filled squares. Additive
+// particles are blended adding colors.
+type ParticleConfig struct {
+	Rate           float64          `json:"rate"`
+	Bursts         []*ParticleBurst `json:"bursts"`
+	Duration       float64          `json:"duration"`
+	Loop           bool             `json:"loop"`
+	MaxParticles   int              `json:"max-particles"`
+	Lifetime       float64          `json:"lifetime"`
+	LifetimeSpread float64          `json:"lifetime-spread"`
+	Speed          float64          `json:"speed"`
+	SpeedSpread    float64          `json:"speed-spread"`
+	Angle          float64          `json:"angle"`
+	AngleSpread    float64          `json:"angle-spread"`
+	Gravity        *Vector          `json:"gravity"`
+	StartColor     sdl.Color        `json:"start-color"`
+	EndColor       sdl.Color        `json:"end-color"`
+	ColorCurve     []*CurveKey      `json:"color-curve"`
+	StartSize      float64          `json:"start-size"`
+	EndSize        float64          `json:"end-size"`
+	SizeCurve      []*CurveKey      `json:"size-curve"`
+	Texture        string           `json:"texture"`
+	Format         int              `json:"format"`
+	Additive       bool             `json:"additive"`
+}
+
+// NewParticleConfig creates a new particle configuration instance with
+// white particles emitted in all directions and fading out.
+func NewParticleConfig() *ParticleConfig {
+	return &ParticleConfig{
+		Rate:           10,
+		Bursts:         []*ParticleBurst{},
+		Duration:       0,
+		Loop:           false,
+		MaxParticles:   100,
+		Lifetime:       1,
+		LifetimeSpread: 0,
+		Speed:          50,
+		SpeedSpread:    0,
+		Angle:          0,
+		AngleSpread:    180,
+		Gravity:        NewVector(0, 0),
+		StartColor:     sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		EndColor:       sdl.Color{R: 255, G: 255, B: 255, A: 0},
+		ColorCurve:     []*CurveKey{},
+		StartSize:      4,
+		EndSize:        4,
+		SizeCurve:      []*CurveKey{},
+		Texture:        "",
+		Format:         FormatBMP,
+		Additive:       false,
+	}
+}
+
+// unmarshalColor returns a color from the given data.
+func unmarshalColor(data map[string]interface{}) sdl.Color {
+	return sdl.Color{
+		R: uint8(data["R"].(float64)),
+		G: uint8(data["G"].(float64)),
+		B: uint8(data["B"].(float64)),
+		A: uint8(data["A"].(float64)),
+	}
+}
+
+// unmarshalCurve returns curve keys from the given data.
+func unmarshalCurve(data []interface{}) []*CurveKey {
+	result := []*CurveKey{}
+	for _, k := range data {
+		key := k.(map[string]interface{})
+		result = append(result, NewCurveKey(key["time"].(float64), key["value"].(float64)))
+	}
+	return result
+}
+
+// UnmarshalParticleConfig returns a particle configuration from the given
+// data. Missing values keep their default value.
+func UnmarshalParticleConfig(data map[string]interface{}) *ParticleConfig {
+	config := NewParticleConfig()
+	floats := map[string]*float64{
+		"rate":            &config.Rate,
+		"duration":        &config.Duration,
+		"lifetime":        &config.Lifetime,
+		"lifetime-spread": &config.LifetimeSpread,
+		"speed":           &config.Speed,
+		"speed-spread":    &config.SpeedSpread,
+		"angle":           &config.Angle,
+		"angle-spread":    &config.AngleSpread,
+		"start-size":      &config.StartSize,
+		"end-size":        &config.EndSize,
+	}
+	for key, value := range floats {
+		if v, ok := data[key].(float64); ok {
+			*value = v
+		}
+	}
+	if bursts, ok := data["bursts"].([]interface{}); ok {
+		for _, b := range bursts {
+			burst := b.(map[string]interface{})
+			config.Bursts = append(config.Bursts, NewParticleBurst(burst["time"].(float64), int(burst["count"].(float64))))
+		}
+	}
+	if loop, ok := data["loop"].(bool); ok {
+		config.Loop = loop
+	}
+	if maxParticles, ok := data["max-particles"].(float64); ok {
+		config.MaxParticles = int(maxParticles)
+	}
+	if gravity, ok := data["gravity"].(map[string]interface{}); ok {
+		config.Gravity = NewVector(gravity["X"].(float64), gravity["Y"].(float64))
+	}
+	if color, ok := data["start-color"].(map[string]interface{}); ok {
+		config.StartColor = unmarshalColor(color)
+	}
+	if color, ok := data["end-color"].(map[string]interface{}); ok {
+		config.EndColor = unmarshalColor(color)
+	}
+	if curve, ok := data["color-curve"].([]interface{}); ok {
+		config.ColorCurve = unmarshalCurve(curve)
+	}
+	if curve, ok := data["size-curve"].([]interface{}); ok {
+		config.SizeCurve = unmarshalCurve(curve)
+	}
+	if texture, ok := data["texture"].(string); ok {
+		config.Texture = texture
+	}
+	if format, ok := data["format"].(float64); ok {
+		config.Format = int(format)
+	}
+	if additive, ok := data["additive"].(bool); ok {
+		config.Additive = additive
+	}
+	return config
+}
@@ -0,0 +1,144 @@
+package engosdl_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// runParticleEmitter runs the given particle configuration for the given
+// number of frames. It returns the engine and the number of particles alive
+// after every update.
+func runParticleEmitter(t *testing.T, config *engosdl.ParticleConfig, frames int) (*engosdl.Engine, *components.ParticleEmitter, []int) {
+	var emitter *components.ParticleEmitter
+	counts := []int{}
+	scene := engosdl.NewScene("test-particle-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity := engosdl.NewEntity("explosion")
+		entity.GetTransform().SetPositionXY(100, 100)
+		emitter = components.NewParticleEmitter("explosion/particle-emitter", config)
+		emitter.SetSeed(1)
+		entity.AddComponent(emitter)
+		listener := engosdl.NewComponent("explosion/listener")
+		listener.SetCustomOnUpdate(func(engosdl.IComponent) {
+			counts = append(counts, emitter.GetParticleCount())
+		})
+		entity.AddComponent(listener)
+		scene.AddEntity(entity)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, frames)
+	engine.DoCleanup()
+	return engine, emitter, counts
+}
+
+func TestEvaluateCurve(t *testing.T) {
+	curve := []*engosdl.CurveKey{engosdl.NewCurveKey(0, 0), engosdl.NewCurveKey(0.5, 1), engosdl.NewCurveKey(1, 0)}
+	cases := []struct {
+		keys []*engosdl.CurveKey
+		time float64
+		exp  float64
+	}{
+		{nil, -1, 0},
+		{nil, 0.25, 0.25},
+		{nil, 2, 1},
+		{curve, 0.25, 0.5},
+		{curve, 0.5, 1},
+		{curve, 0.75, 0.5},
+		{curve, 1.5, 0},
+	}
+	for i, c := range cases {
+		if got := engosdl.EvaluateCurve(c.keys, c.time); got != c.exp {
+			t.Errorf("[%d] error evaluating curve\nexp: %f\ngot: %f\n", i, c.exp, got)
+		}
+	}
+	from, to := sdl.Color{R: 255, G: 0, B: 100, A: 255}, sdl.Color{R: 255, G: 255, B: 0, A: 0}
+	if exp, got := (sdl.Color{R: 255, G: 128, B: 50, A: 128}), engosdl.LerpColor(from, to, 0.5); exp != got {
+		t.Errorf("error interpolating color\nexp: %v\ngot: %v\n", exp, got)
+	}
+}
+
+func TestParticleConfig_Unmarshal(t *testing.T) {
+	exp := engosdl.NewParticleConfig()
+	exp.Bursts = []*engosdl.ParticleBurst{engosdl.NewParticleBurst(0.5, 20)}
+	exp.Loop = true
+	exp.Gravity = engosdl.NewVector(0, 98)
+	exp.EndColor = sdl.Color{R: 255, G: 128, B: 0, A: 0}
+	exp.SizeCurve = []*engosdl.CurveKey{engosdl.NewCurveKey(0, 0), engosdl.NewCurveKey(1, 1)}
+	exp.Texture = "spark.png"
+	exp.Additive = true
+	data := map[string]interface{}{}
+	bytes, _ := json.Marshal(exp)
+	json.Unmarshal(bytes, &data)
+	if got := engosdl.UnmarshalParticleConfig(data); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error unmarshaling particle config\nexp: %+v\ngot: %+v\n", exp, got)
+	}
+}
+
+func TestParticleEmitter_Emission(t *testing.T) {
+	config := engosdl.NewParticleConfig()
+	config.Rate = 0
+	config.Bursts = []*engosdl.ParticleBurst{engosdl.NewParticleBurst(0, 5), engosdl.NewParticleBurst(0.09, 3)}
+	config.Duration = 0.2
+	config.MaxParticles = 6
+	config.Lifetime = 0.25
+	_, emitter, got := runParticleEmitter(t, config, 13)
+	// Second burst is limited by the pool size.
+	exp := []int{5, 5, 6, 6, 6, 6, 6, 6, 1, 1, 0, 0}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("error emitting bursts\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if emitter.IsPlaying() {
+		t.Errorf("error stopping emitter\nexp: %v\ngot: %v\n", false, emitter.IsPlaying())
+	}
+
+	config = engosdl.NewParticleConfig()
+	config.Rate = 45
+	config.Duration = 0.1
+	config.Loop = true
+	_, emitter, got = runParticleEmitter(t, config, 7)
+	// One particle and a half per update.
+	exp = []int{1, 3, 4, 6, 7, 9}
+	if !reflect.DeepEqual(exp, got) || !emitter.IsPlaying() {
+		t.Errorf("error emitting with rate\nexp: %v\ngot: %v\n", exp, got)
+	}
+}
+
+func TestParticleEmitter_Render(t *testing.T) {
+	config := engosdl.NewParticleConfig()
+	config.Rate = 0
+	config.Bursts = []*engosdl.ParticleBurst{engosdl.NewParticleBurst(0, 4)}
+	config.Lifetime = 0.5
+	config.Speed = 30
+	config.AngleSpread = 0
+	config.Gravity = engosdl.NewVector(0, 60)
+	config.StartColor = sdl.Color{R: 255, G: 0, B: 0, A: 255}
+	config.EndColor = sdl.Color{R: 255, G: 0, B: 0, A: 0}
+	config.StartSize = 10
+	config.EndSize = 0
+	engine, _, _ := runParticleEmitter(t, config, 4)
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	fills := []*engosdl.DrawCall{}
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect {
+			fills = append(fills, drawCall)
+		}
+	}
+	if len(fills) != 4 {
+		t.Fatalf("error rendering particles\nexp: %d\ngot: %d\n", 4, len(fills))
+	}
+	// Particles moved right and down for two updates.
+	exp := &sdl.Rect{X: 98, Y: 96, W: 9, H: 9}
+	if got := fills[0].Dst; *got != *exp {
+		t.Errorf("error rendering particle position\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if got := fills[0].Color; got.R != 255 || got.A != 221 {
+		t.Errorf("error rendering particle color\nexp: %d\ngot: %d\n", 221, got.A)
+	}
+}
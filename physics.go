@@ -0,0 +1,152 @@
+package engosdl
+
+import "math"
+
+// Rigid body types.
+const (
+	// BodyStatic is a body that never moves and it has infinite mass.
+	BodyStatic int = 0
+	// BodyKinematic is a body moved only by its velocity, it is not affected
+	// by forces or collisions.
+	BodyKinematic int = 1
+	// BodyDynamic is a body affected by forces, gravity and collisions.
+	BodyDynamic int = 2
+)
+
+// Penetration resolution constants.
+const (
+	// penetrationSlop is the penetration allowed without any correction, it
+	// avoids jittering for bodies resting on each other.
+	penetrationSlop float64 = 0.01
+	// penetrationPercent is the percentage of the penetration corrected in
+	// every step.
+	penetrationPercent float64 = 0.8
+)
+
+// IRigidBody represents the interface for any rigid body component. Scene
+// uses rigid bodies to resolve collisions between colliders.
+type IRigidBody interface {
+	IComponent
+	AddForce(*Vector)
+	AddImpulse(*Vector)
+	GetBodyType() int
+	GetFriction() float64
+	GetInverseMass() float64
+	GetRestitution() float64
+	GetVelocity() *Vector
+	SetVelocity(*Vector)
+}
+
+// getRigidBody returns the active rigid body component for the given entity.
+// It returns nil if entity does not have any rigid body.
+func getRigidBody(entity IEntity) IRigidBody {
+	for _, component := range entity.GetComponents() {
+		if body, ok := component.(IRigidBody); ok && component.GetActive() {
+			return body
+		}
+	}
+	return nil
+}
+
+// getInverseMass returns the inverse mass for the given rigid body. Entities
+// without rigid body and bodies that are not dynamic have infinite mass.
+func getInverseMass(body IRigidBody) float64 {
+	if body == nil || body.GetBodyType() != BodyDynamic {
+		return 0
+	}
+	return body.GetInverseMass()
+}
+
+// getVelocity returns the velocity for the given rigid body. Entities without
+// rigid body do not move.
+func getVelocity(body IRigidBody) *Vector {
+	if body == nil {
+		return NewVector(0, 0)
+	}
+	return body.GetVelocity()
+}
+
+// resolveContact moves entities in contact to remove the penetration, and it
+// applies impulses for restitution and friction to their rigid bodies.
+// Contact normal points from the first entity to the second one.
+func resolveContact(one IEntity, two IEntity, contact *Contact) {
+	bodyOne, bodyTwo := getRigidBody(one), getRigidBody(two)
+	inverseOne, inverseTwo := getInverseMass(bodyOne), getInverseMass(bodyTwo)
+	total := inverseOne + inverseTwo
+	if total == 0 {
+		return
+	}
+	normal := contact.Normal
+
+	// Positional correction.
+	correction := math.Max(contact.Depth-penetrationSlop, 0) / total * penetrationPercent
+	if correction > 0 {
+		translate(one, -normal.X*correction*inverseOne, -normal.Y*correction*inverseOne)
+		translate(two, normal.X*correction*inverseTwo, normal.Y*correction*inverseTwo)
+	}
+
+	// Impulse along the normal. Bodies already moving apart are not changed.
+	velocityOne, velocityTwo := getVelocity(bodyOne), getVelocity(bodyTwo)
+	relativeX, relativeY := velocityTwo.X-velocityOne.X, velocityTwo.Y-velocityOne.Y
+	alongNormal := relativeX*normal.X + relativeY*normal.Y
+	if alongNormal > 0 {
+		return
+	}
+	restitution := math.Max(getRestitution(bodyOne), getRestitution(bodyTwo))
+	impulse := -(1 + restitution) * alongNormal / total
+	applyImpulse(bodyOne, bodyTwo, normal.X*impulse, normal.Y*impulse, inverseOne, inverseTwo)
+
+	// Friction impulse along the tangent.
+	velocityOne, velocityTwo = getVelocity(bodyOne), getVelocity(bodyTwo)
+	relativeX, relativeY = velocityTwo.X-velocityOne.X, velocityTwo.Y-velocityOne.Y
+	alongNormal = relativeX*normal.X + relativeY*normal.Y
+	tangent := normalize(NewVector(relativeX-normal.X*alongNormal, relativeY-normal.Y*alongNormal))
+	if tangent == nil {
+		return
+	}
+	friction := math.Sqrt(getFriction(bodyOne, bodyTwo) * getFriction(bodyTwo, bodyOne))
+	tangentImpulse := -(relativeX*tangent.X + relativeY*tangent.Y) / total
+	tangentImpulse = math.Max(-impulse*friction, math.Min(impulse*friction, tangentImpulse))
+	applyImpulse(bodyOne, bodyTwo, tangent.X*tangentImpulse, tangent.Y*tangentImpulse, inverseOne, inverseTwo)
+}
+
+// applyImpulse changes velocities for both rigid bodies using the given
+// impulse. Impulse is applied to the second body and the opposite impulse to
+// the first one.
+func applyImpulse(one IRigidBody, two IRigidBody, x float64, y float64, inverseOne float64, inverseTwo float64) {
+	if inverseOne > 0 {
+		velocity := one.GetVelocity()
+		one.SetVelocity(NewVector(velocity.X-x*inverseOne, velocity.Y-y*inverseOne))
+	}
+	if inverseTwo > 0 {
+		velocity := two.GetVelocity()
+		two.SetVelocity(NewVector(velocity.X+x*inverseTwo, velocity.Y+y*inverseTwo))
+	}
+}
+
+// getFriction returns the friction for the given rigid body. Entities
+// without rigid body use the friction from the other body in contact.
+func getFriction(body IRigidBody, other IRigidBody) float64 {
+	if body != nil {
+		return body.GetFriction()
+	}
+	if other != nil {
+		return other.GetFriction()
+	}
+	return 0
+}
+
+// getRestitution returns the restitution for the given rigid body.
+func getRestitution(body IRigidBody) float64 {
+	if body == nil {
+		return 0
+	}
+	return body.GetRestitution()
+}
+
+// translate moves the given entity in world coordinates.
+func translate(entity IEntity, x float64, y float64) {
+	transform := entity.GetTransform()
+	position := transform.GetWorldPosition()
+	transform.SetWorldPosition(NewVector(position.X+x, position.Y+y))
+}
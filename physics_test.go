@@ -0,0 +1,94 @@
+package engosdl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func newPhysicsScene(name string, restitution float64, body **components.RigidBody2D, ball *engosdl.IEntity) *engosdl.Scene {
+	scene := engosdl.NewScene(name, "test")
+	scene.SetCollisionMode(engosdl.ModeBox)
+	scene.SetGravity(engosdl.NewVector(0, 300))
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		*ball = engosdl.NewEntity("ball")
+		(*ball).GetTransform().SetPositionXY(100, 100)
+		(*ball).AddComponent(components.NewBox("ball/box", &engosdl.Rect{W: 10, H: 10}, sdl.Color{A: 255}, true))
+		(*ball).AddComponent(components.NewCollider2D("ball/collider-2D"))
+		*body = components.NewRigidBody2D("ball/rigid-body-2D", engosdl.BodyDynamic, 1)
+		(*body).Restitution = restitution
+		(*ball).AddComponent(*body)
+		scene.AddEntity(*ball)
+		floor := engosdl.NewEntity("floor")
+		floor.GetTransform().SetPositionXY(0, 200)
+		floor.AddComponent(components.NewBox("floor/box", &engosdl.Rect{W: 400, H: 20}, sdl.Color{A: 255}, true))
+		floor.AddComponent(components.NewCollider2D("floor/collider-2D"))
+		floor.AddComponent(components.NewRigidBody2D("floor/rigid-body-2D", engosdl.BodyStatic, 0))
+		scene.AddEntity(floor)
+		return true
+	})
+	return scene
+}
+
+func TestRigidBody2D_RestOnFloor(t *testing.T) {
+	engine := newTestEngine(t)
+	var body *components.RigidBody2D
+	var ball engosdl.IEntity
+	scene := newPhysicsScene("test-rest-scene", 0, &body, &ball)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 90)
+	if y := ball.GetTransform().GetPosition().Y; math.Abs(y-190) > 1 {
+		t.Errorf("error resting on floor\nexp: %f\ngot: %f\n", 190.0, y)
+	}
+	if vy := body.GetVelocity().Y; math.Abs(vy) > 10 {
+		t.Errorf("error stopping body on floor\nexp: %f\ngot: %f\n", 0.0, vy)
+	}
+}
+
+func TestRigidBody2D_Bounce(t *testing.T) {
+	engine := newTestEngine(t)
+	var body *components.RigidBody2D
+	var ball engosdl.IEntity
+	scene := newPhysicsScene("test-bounce-scene", 1, &body, &ball)
+	engine.AddScene(scene)
+	// Body reaches the floor after 24 frames.
+	engine.RunEngineFrames(scene, 30)
+	if vy := body.GetVelocity().Y; vy > -100 {
+		t.Errorf("error bouncing body on floor\nexp: < %f\ngot: %f\n", -100.0, vy)
+	}
+	if y := ball.GetTransform().GetPosition().Y; y > 190 {
+		t.Errorf("error resolving penetration\nexp: <= %f\ngot: %f\n", 190.0, y)
+	}
+}
+
+func TestRigidBody2D_Trigger(t *testing.T) {
+	engine := newTestEngine(t)
+	var body *components.RigidBody2D
+	var ball engosdl.IEntity
+	var enter int
+	scene := newPhysicsScene("test-trigger-scene", 0, &body, &ball)
+	sceneCode := scene.GetSceneCode()
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		sceneCode(engine, scene)
+		floor := scene.GetEntityByName("floor")
+		floor.GetComponent(&components.Collider2D{}).(*components.Collider2D).SetTrigger(true)
+		counter := engosdl.NewComponent("floor/counter")
+		counter.AddDelegateToRegister(engosdl.GetDelegateManager().GetCollisionEnterDelegate(), nil, nil, func(params ...interface{}) bool {
+			enter++
+			return true
+		})
+		floor.AddComponent(counter)
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 60)
+	if enter != 1 {
+		t.Errorf("error triggering enter for trigger collider\nexp: %d\ngot: %d\n", 1, enter)
+	}
+	if y := ball.GetTransform().GetPosition().Y; y < 220 {
+		t.Errorf("error resolving contact with trigger collider\nexp: > %f\ngot: %f\n", 220.0, y)
+	}
+}
@@ -0,0 +1,135 @@
+package engosdl
+
+// _poolSize is the default maximum number of entities kept in a pool.
+const _poolSize int = 64
+
+// IEntityPool represents the interface for any entity pool. Entity pool keeps
+// entities created from a prefab, so they can be reused instead of being
+// created and destroyed every time.
+type IEntityPool interface {
+	IObject
+	Acquire() IEntity
+	Clear()
+	GetFree() int
+	GetPrefab() string
+	GetSize() int
+	Release(IEntity) bool
+	SetSize(int)
+}
+
+// EntityPool is the default implementation for the entity pool interface.
+// Entities released are removed from their scene without being destroyed, so
+// components keep all delegate registrations. Component OnSpawn and OnDespawn
+// methods are called when entities are acquired and released. Entities
+// released when the pool is full are destroyed.
+type EntityPool struct {
+	*Object
+	prefab string
+	size   int
+	free   []IEntity
+}
+
+var _ IEntityPool = (*EntityPool)(nil)
+
+// NewEntityPool creates a new entity pool instance for the given prefab.
+func NewEntityPool(name string, prefab string, size int) *EntityPool {
+	Logger.Trace().Str("entity-pool", name).Str("prefab", prefab).Msg("new entity-pool")
+	return &EntityPool{
+		Object: NewObject(name),
+		prefab: prefab,
+		size:   size,
+		free:   []IEntity{},
+	}
+}
+
+// Acquire returns an entity from the pool, or a new entity created from the
+// prefab if there is not any entity available. Entities released are
+// available once they have been removed from their scene. Entity is active
+// and it has to be added to any scene. It returns nil if entity can not be
+// created from the prefab.
+func (pool *EntityPool) Acquire() IEntity {
+	var entity IEntity
+	for i, traverse := range pool.free {
+		if traverse.GetScene() == nil {
+			entity = traverse
+			pool.free = append(pool.free[:i], pool.free[i+1:]...)
+			break
+		}
+	}
+	if entity == nil {
+		if entity = GetPrefabManager().CreateEntity(pool.prefab, nil); entity == nil {
+			return nil
+		}
+		entity.SetPool(pool)
+	}
+	entity.SetActive(true)
+	entity.OnSpawn()
+	return entity
+}
+
+// Clear destroys all entities released in the pool. Entities not removed
+// from their scene yet are kept in the pool.
+func (pool *EntityPool) Clear() {
+	Logger.Trace().Str("entity-pool", pool.GetName()).Msg("Clear")
+	free := []IEntity{}
+	for _, entity := range pool.free {
+		if entity.GetScene() != nil {
+			free = append(free, entity)
+			continue
+		}
+		entity.SetPool(nil)
+		entity.DoDestroy()
+	}
+	pool.free = free
+}
+
+// GetFree returns the number of entities released in the pool.
+func (pool *EntityPool) GetFree() int {
+	return len(pool.free)
+}
+
+// GetPrefab returns the prefab used to create pool entities.
+func (pool *EntityPool) GetPrefab() string {
+	return pool.prefab
+}
+
+// GetSize returns the maximum number of entities kept in the pool.
+func (pool *EntityPool) GetSize() int {
+	return pool.size
+}
+
+// Release returns the given entity to the pool. Entity is removed from its
+// scene and it is not active anymore. If pool is full, entity is destroyed.
+// It returns false if entity does not belong to the pool or it has already
+// been released.
+func (pool *EntityPool) Release(entity IEntity) bool {
+	Logger.Trace().Str("entity-pool", pool.GetName()).Str("entity", entity.GetName()).Msg("Release")
+	if entity.GetPool() != IEntityPool(pool) {
+		return false
+	}
+	for _, traverse := range pool.free {
+		if traverse == entity {
+			return false
+		}
+	}
+	if len(pool.free) >= pool.size {
+		entity.SetPool(nil)
+		if scene := entity.GetScene(); scene != nil {
+			return GetEngine().DestroyEntity(entity)
+		}
+		entity.DoDestroy()
+		return true
+	}
+	entity.OnDespawn()
+	entity.SetActive(false)
+	if scene := entity.GetScene(); scene != nil && !scene.RemoveEntity(entity) {
+		entity.SetScene(nil)
+	}
+	pool.free = append(pool.free, entity)
+	return true
+}
+
+// SetSize sets the maximum number of entities kept in the pool.
+func (pool *EntityPool) SetSize(size int) {
+	pool.size = size
+}
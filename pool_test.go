@@ -0,0 +1,115 @@
+package engosdl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+)
+
+func TestEntityPool_AcquireRelease(t *testing.T) {
+	calls := map[string]int{}
+	engosdl.GetComponentManager().RegisterHandler("test-pool-on-timer", func(component engosdl.IComponent) engosdl.TDelegateSignature {
+		return func(...interface{}) bool {
+			calls[component.GetID()]++
+			return true
+		}
+	})
+	prefabs := `[{"name": "test-pool-bullet", "entity-data": {"name": "pooled"},
+		"components": [
+			{"component-type": "*components.Box", "component-data": {"name": "pooled/box", "border": {"X": 0, "Y": 0, "W": 4, "H": 4}},
+			 "delegates": [{"component": "*components.Timer", "handler": "test-pool-on-timer"}]},
+			{"component-type": "*components.Timer", "component-data": {"name": "pooled/timer", "times": 1}}]}]`
+	if err := engosdl.GetPrefabManager().LoadPrefabs(strings.NewReader(prefabs)); err != nil {
+		t.Fatal(err)
+	}
+	pool := engosdl.NewEntityPool("test-pool", "test-pool-bullet", 1)
+	scene := engosdl.NewScene("pool", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool { return true })
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 1)
+
+	bullet := pool.Acquire()
+	if bullet == nil || bullet.GetPool() != engosdl.IEntityPool(pool) {
+		t.Fatalf("error acquiring entity from pool")
+	}
+	box := bullet.GetComponents()[0]
+	scene.AddEntity(bullet)
+	engine.DoRunFrames(3)
+	if calls[box.GetID()] != 1 {
+		t.Errorf("error triggering pooled entity delegate\nexp: %d\ngot: %d\n", 1, calls[box.GetID()])
+	}
+
+	// Entity destroyed is released to the pool, and it is removed from the
+	// scene without destroying any component.
+	if !engine.DestroyEntity(bullet) || pool.GetFree() != 1 || bullet.GetActive() {
+		t.Errorf("error releasing entity to pool")
+	}
+	if pool.Release(bullet) {
+		t.Errorf("error releasing entity already released")
+	}
+	engine.DoRunFrames(1)
+	if scene.GetEntityByName("pooled") != nil || bullet.GetScene() != nil || len(bullet.GetComponents()) != 2 {
+		t.Errorf("error removing released entity from the scene")
+	}
+
+	// Entity acquired again keeps delegate registrations, and component
+	// state is reset.
+	again := pool.Acquire()
+	if again != bullet || pool.GetFree() != 0 || !again.GetActive() {
+		t.Fatalf("error acquiring released entity\nexp: %s\ngot: %s\n", bullet.GetID(), again.GetID())
+	}
+	scene.AddEntity(again)
+	engine.DoRunFrames(3)
+	if calls[box.GetID()] != 2 || len(calls) != 1 {
+		t.Errorf("error triggering reused entity delegate\nexp: %d\ngot: %d\n", 2, calls[box.GetID()])
+	}
+
+	// Entity released with the pool full is destroyed.
+	other := pool.Acquire()
+	if other == nil || other == again {
+		t.Fatalf("error creating new entity for the pool")
+	}
+	scene.AddEntity(other)
+	engine.DoRunFrames(1)
+	pool.Release(again)
+	pool.Release(other)
+	engine.DoRunFrames(1)
+	if pool.GetFree() != 1 || other.GetPool() != nil || len(other.GetComponents()) != 0 {
+		t.Errorf("error destroying entity released to full pool")
+	}
+	if pool.Release(engosdl.NewEntity("other")) {
+		t.Errorf("error releasing entity not belonging to the pool")
+	}
+}
+
+func TestEntityPool_SceneDestroy(t *testing.T) {
+	prefabs := `[{"name": "test-pool-destroy", "entity-data": {"name": "pooled"},
+		"components": [{"component-type": "*components.Box", "component-data": {"name": "pooled/box", "border": {"X": 0, "Y": 0, "W": 4, "H": 4}}}]}]`
+	if err := engosdl.GetPrefabManager().LoadPrefabs(strings.NewReader(prefabs)); err != nil {
+		t.Fatal(err)
+	}
+	pool := engosdl.NewEntityPool("test-pool", "test-pool-destroy", 4)
+	scene := engosdl.NewScene("pool-destroy", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool { return true })
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 1)
+
+	bullet := pool.Acquire()
+	scene.AddEntity(bullet)
+	engine.DoRunFrames(1)
+	engine.DestroyEntity(bullet)
+	engine.DoRunFrames(1)
+	if pool.GetFree() != 1 || len(bullet.GetComponents()) != 1 {
+		t.Fatalf("error releasing entity to pool")
+	}
+
+	// Entities released to a pool used by the scene are destroyed with the
+	// scene.
+	scene.DoDestroy()
+	if pool.GetFree() != 0 || bullet.GetPool() != nil || len(bullet.GetComponents()) != 0 {
+		t.Errorf("error destroying released entities with the scene\nexp: %d\ngot: %d\n", 0, pool.GetFree())
+	}
+}
@@ -0,0 +1,327 @@
+package engosdl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// _prefabReloadPeriod is the period in seconds prefab files are checked for
+// changes when hot reload is enabled.
+const _prefabReloadPeriod float64 = 1.0
+
+// Prefab represents a reusable entity template. It contains entity data,
+// components and children like any entity in a scene file, but entity and
+// component data only require values different from default ones. Prefab can
+// be based on any other prefab, and children can be prefabs too.
+type Prefab struct {
+	Name string `json:"name"`
+	*EntityToUnmarshal
+}
+
+// NewPrefab creates a new prefab instance with the given entity data.
+func NewPrefab(name string, instance *EntityToUnmarshal) *Prefab {
+	Logger.Trace().Str("prefab", name).Msg("new prefab")
+	if instance == nil {
+		instance = &EntityToUnmarshal{}
+	}
+	return &Prefab{
+		Name:              name,
+		EntityToUnmarshal: instance,
+	}
+}
+
+// PrefabManager is in charge of storing all prefabs registered. Entities are
+// created from prefabs using component constructors registered in the
+// component manager. Prefabs loaded from a file can be reloaded when the
+// file changes, and only new instances use the reloaded prefab. Entity pools
+// for prefabs are stored too.
+type PrefabManager struct {
+	*Object
+	Prefabs   map[string]*Prefab
+	pools     map[string]IEntityPool
+	files     map[string]time.Time
+	hotReload bool
+	elapsed   float64
+}
+
+// NewPrefabManager creates a new prefab manager instance.
+func NewPrefabManager(name string) *PrefabManager {
+	Logger.Trace().Str("prefab-manager", name).Msg("new prefab-manager")
+	return &PrefabManager{
+		Object:    NewObject(name),
+		Prefabs:   make(map[string]*Prefab),
+		pools:     make(map[string]IEntityPool),
+		files:     make(map[string]time.Time),
+		hotReload: false,
+		elapsed:   0,
+	}
+}
+
+// copyData returns a deep copy of the given JSON data.
+func copyData(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = copyData(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = copyData(v)
+		}
+		return result
+	}
+	return data
+}
+
+// mergeData returns a copy of the given JSON data with all values in
+// overrides. Maps are merged at every level.
+func mergeData(data map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	result := copyData(data).(map[string]interface{})
+	for k, v := range overrides {
+		if dataMap, ok := result[k].(map[string]interface{}); ok {
+			if overrideMap, ok := v.(map[string]interface{}); ok {
+				result[k] = mergeData(dataMap, overrideMap)
+				continue
+			}
+		}
+		result[k] = copyData(v)
+	}
+	return result
+}
+
+// toData returns the JSON data for the given object.
+func toData(obj interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if data, err := json.Marshal(obj); err == nil {
+		json.Unmarshal(data, &result)
+	}
+	delete(result, "id")
+	return result
+}
+
+// defaultComponent returns the component data with default values from the
+// given component constructor merged with the given data.
+func defaultComponent(comp *ComponentToUnmarshal) (*ComponentToUnmarshal, error) {
+	constructor, ok := GetComponentManager().Constructors[comp.ComponentName]
+	if !ok {
+		return nil, fmt.Errorf("component %s not found", comp.ComponentName)
+	}
+	return &ComponentToUnmarshal{
+		ComponentName: comp.ComponentName,
+		Component:     mergeData(toData(constructor()), comp.Component),
+		Delegates:     append([]*DelegateLink{}, comp.Delegates...),
+	}, nil
+}
+
+// overridePrefab returns a copy of the given resolved instance with all
+// values in overrides. Every override component is merged with the first
+// component not merged yet with the same type, and with the same name if
+// override component has a name, or it is added if there is not any.
+// Override children are added to instance children.
+func overridePrefab(instance *EntityToUnmarshal, overrides *EntityToUnmarshal) (*EntityToUnmarshal, error) {
+	result := &EntityToUnmarshal{
+		Entity:     instance.Entity,
+		Components: append([]*ComponentToUnmarshal{}, instance.Components...),
+		Children:   append([]*EntityToUnmarshal{}, instance.Children...),
+	}
+	if overrideData, ok := overrides.Entity.(map[string]interface{}); ok {
+		result.Entity = mergeData(instance.Entity.(map[string]interface{}), overrideData)
+	}
+	merged := map[int]bool{}
+	for _, comp := range overrides.Components {
+		index := -1
+		for i, instanceComp := range result.Components {
+			if merged[i] || instanceComp.ComponentName != comp.ComponentName {
+				continue
+			}
+			if name, ok := comp.Component["name"]; ok && name != instanceComp.Component["name"] {
+				continue
+			}
+			index = i
+			break
+		}
+		if index != -1 {
+			instanceComp := result.Components[index]
+			result.Components[index] = &ComponentToUnmarshal{
+				ComponentName: comp.ComponentName,
+				Component:     mergeData(instanceComp.Component, comp.Component),
+				Delegates:     append(append([]*DelegateLink{}, instanceComp.Delegates...), comp.Delegates...),
+			}
+			merged[index] = true
+		} else {
+			defaultComp, err := defaultComponent(comp)
+			if err != nil {
+				return nil, err
+			}
+			result.Components = append(result.Components, defaultComp)
+			merged[len(result.Components)-1] = true
+		}
+	}
+	result.Children = append(result.Children, overrides.Children...)
+	return result, nil
+}
+
+// AddPrefabFile loads all prefabs from the given JSON file. File is tracked
+// so prefabs can be reloaded when it changes.
+func (h *PrefabManager) AddPrefabFile(filename string) error {
+	Logger.Trace().Str("prefab-manager", h.GetName()).Str("filename", filename).Msg("AddPrefabFile")
+	info, err := os.Stat(filename)
+	if err != nil {
+		Logger.Error().Err(err).Str("filename", filename).Msg("AddPrefabFile error")
+		return err
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		Logger.Error().Err(err).Str("filename", filename).Msg("AddPrefabFile error")
+		return err
+	}
+	defer file.Close()
+	if err := h.LoadPrefabs(file); err != nil {
+		return err
+	}
+	h.files[filename] = info.ModTime()
+	return nil
+}
+
+// CreateEntity creates a new entity from the given prefab. Any value in
+// overrides replaces the value in the prefab, and override components and
+// children are added to the entity. Overrides can be nil.
+func (h *PrefabManager) CreateEntity(prefabName string, overrides *EntityToUnmarshal) IEntity {
+	Logger.Trace().Str("prefab-manager", h.GetName()).Str("prefab", prefabName).Msg("CreateEntity")
+	instance := &EntityToUnmarshal{}
+	if overrides != nil {
+		*instance = *overrides
+	}
+	instance.Prefab = prefabName
+	resolved, err := h.resolve(instance, map[string]bool{})
+	if err != nil {
+		Logger.Error().Err(err).Str("prefab", prefabName).Msg("CreateEntity error")
+		return nil
+	}
+	entity := NewEntity("")
+	entity.Unmarshal(resolved)
+	return entity
+}
+
+// GetPool returns the entity pool for the given prefab. Pool is created with
+// default size if there is not any pool for the prefab.
+func (h *PrefabManager) GetPool(prefabName string) IEntityPool {
+	if pool, ok := h.pools[prefabName]; ok {
+		return pool
+	}
+	pool := NewEntityPool(h.GetName()+"/"+prefabName, prefabName, _poolSize)
+	h.pools[prefabName] = pool
+	return pool
+}
+
+// GetPrefab returns the prefab with the given name.
+func (h *PrefabManager) GetPrefab(name string) *Prefab {
+	return h.Prefabs[name]
+}
+
+// LoadPrefabs reads a list of prefabs from the given reader in JSON format
+// and registers all of them. No prefab is registered if there is any error.
+func (h *PrefabManager) LoadPrefabs(reader io.Reader) error {
+	Logger.Trace().Str("prefab-manager", h.GetName()).Msg("LoadPrefabs")
+	prefabs := []*Prefab{}
+	if err := json.NewDecoder(reader).Decode(&prefabs); err != nil {
+		Logger.Error().Err(err).Str("prefab-manager", h.GetName()).Msg("LoadPrefabs error")
+		return err
+	}
+	for _, prefab := range prefabs {
+		if prefab.Name == "" || prefab.EntityToUnmarshal == nil {
+			err := fmt.Errorf("prefab name or data not found")
+			Logger.Error().Err(err).Str("prefab-manager", h.GetName()).Msg("LoadPrefabs error")
+			return err
+		}
+	}
+	for _, prefab := range prefabs {
+		h.RegisterPrefab(prefab)
+	}
+	return nil
+}
+
+// OnUpdate checks prefab files for changes when hot reload is enabled.
+func (h *PrefabManager) OnUpdate() {
+	if !h.hotReload {
+		return
+	}
+	h.elapsed += GetDeltaTime()
+	if h.elapsed >= _prefabReloadPeriod {
+		h.elapsed = 0
+		h.ReloadPrefabs()
+	}
+}
+
+// RegisterPrefab registers a new prefab. Any prefab with the same name is
+// replaced.
+func (h *PrefabManager) RegisterPrefab(prefab *Prefab) {
+	Logger.Trace().Str("prefab-manager", h.GetName()).Str("prefab", prefab.Name).Msg("register prefab")
+	h.Prefabs[prefab.Name] = prefab
+}
+
+// ReloadPrefabs loads again all prefab files changed since they were loaded.
+func (h *PrefabManager) ReloadPrefabs() error {
+	for filename, modTime := range h.files {
+		info, err := os.Stat(filename)
+		if err != nil {
+			Logger.Error().Err(err).Str("filename", filename).Msg("ReloadPrefabs error")
+			return err
+		}
+		if info.ModTime().After(modTime) {
+			Logger.Trace().Str("prefab-manager", h.GetName()).Str("filename", filename).Msg("reload prefabs")
+			if err := h.AddPrefabFile(filename); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the entity data for the given instance with all prefab
+// values. Default values are taken from a new entity and from component
+// constructors, and all children are resolved too. Visited prefabs are
+// used to find any prefab nested in itself.
+func (h *PrefabManager) resolve(instance *EntityToUnmarshal, visited map[string]bool) (*EntityToUnmarshal, error) {
+	result := &EntityToUnmarshal{}
+	if instance.Prefab == "" {
+		result.Entity = toData(NewEntity(""))
+	} else {
+		prefab, ok := h.Prefabs[instance.Prefab]
+		if !ok {
+			return nil, fmt.Errorf("prefab %s not found", instance.Prefab)
+		}
+		if visited[prefab.Name] {
+			return nil, fmt.Errorf("prefab %s nested in itself", prefab.Name)
+		}
+		visited[prefab.Name] = true
+		defer delete(visited, prefab.Name)
+		base, err := h.resolve(prefab.EntityToUnmarshal, visited)
+		if err != nil {
+			return nil, err
+		}
+		result = base
+	}
+	result, err := overridePrefab(result, instance)
+	if err != nil {
+		return nil, err
+	}
+	for i, child := range result.Children {
+		if result.Children[i], err = h.resolve(child, visited); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// SetHotReload sets if prefab files are reloaded when they change.
+func (h *PrefabManager) SetHotReload(hotReload bool) {
+	h.hotReload = hotReload
+	h.elapsed = 0
+}
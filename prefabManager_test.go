@@ -0,0 +1,171 @@
+package engosdl_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+const testPrefabs = `[
+	{"name": "test-bullet", "entity-data": {"name": "bullet", "tag": "bullet", "transform": {"dimension": {"X": 4, "Y": 4}}},
+	 "components": [
+		{"component-type": "*components.Box", "component-data": {"name": "bullet/box", "border": {"X": 0, "Y": 0, "W": 4, "H": 4}, "color": {"R": 255, "G": 0, "B": 0, "A": 255}, "filled": true}},
+		{"component-type": "*components.MoveTo", "component-data": {"name": "bullet/move-to"}}]},
+	{"name": "test-fast-bullet", "prefab": "test-bullet", "entity-data": {"name": "fast-bullet"},
+	 "components": [{"component-type": "*components.MoveTo", "component-data": {"speed": {"X": 300}}}]},
+	{"name": "test-turret", "entity-data": {"name": "turret", "tag": "turret"},
+	 "children": [{"prefab": "test-fast-bullet", "entity-data": {"transform": {"position": {"X": 5}}}}]},
+	{"name": "test-loop", "children": [{"prefab": "test-loop"}]}
+]`
+
+func TestPrefabManager_CreateEntity(t *testing.T) {
+	prefabManager := engosdl.GetPrefabManager()
+	if err := prefabManager.LoadPrefabs(strings.NewReader(testPrefabs)); err != nil {
+		t.Fatal(err)
+	}
+	if err := prefabManager.LoadPrefabs(strings.NewReader(`[{"entity-data": {}}]`)); err == nil {
+		t.Errorf("error loading prefab without name")
+	}
+
+	// Prefab based on other prefab takes all values from the base prefab,
+	// and default values from component constructors.
+	bullet := prefabManager.CreateEntity("test-fast-bullet", &engosdl.EntityToUnmarshal{
+		Entity: map[string]interface{}{"layer": float64(engosdl.LayerTop)},
+		Components: []*engosdl.ComponentToUnmarshal{
+			{ComponentName: components.ComponentNameTimer, Component: map[string]interface{}{"tick": 2.0}},
+		},
+	})
+	if bullet == nil {
+		t.Fatalf("error creating entity from prefab")
+	}
+	if bullet.GetName() != "fast-bullet" || bullet.GetTag() != "bullet" || bullet.GetLayer() != engosdl.LayerTop {
+		t.Errorf("error creating entity from prefab\nexp: %s %s %d\ngot: %s %s %d\n", "fast-bullet", "bullet", engosdl.LayerTop, bullet.GetName(), bullet.GetTag(), bullet.GetLayer())
+	}
+	if w, h := bullet.GetTransform().GetDim().Get(); w != 4 || h != 4 {
+		t.Errorf("error creating entity dimension\nexp: %f %f\ngot: %f %f\n", 4.0, 4.0, w, h)
+	}
+	if len(bullet.GetComponents()) != 3 {
+		t.Fatalf("error creating prefab components\nexp: %d\ngot: %d\n", 3, len(bullet.GetComponents()))
+	}
+	box := bullet.GetComponent(&components.Box{}).(*components.Box)
+	if box.GetName() != "bullet/box" || !box.Filled || box.Color.R != 255 {
+		t.Errorf("error creating prefab box component")
+	}
+	moveTo := bullet.GetComponent(&components.MoveTo{}).(*components.MoveTo)
+	if moveTo.GetName() != "bullet/move-to" || moveTo.Speed.X != 300 || moveTo.Speed.Y != 0 {
+		t.Errorf("error overriding prefab component\nexp: %f %f\ngot: %f %f\n", 300.0, 0.0, moveTo.Speed.X, moveTo.Speed.Y)
+	}
+	if timer := bullet.GetComponent(&components.Timer{}).(*components.Timer); timer.Tick != 2 || timer.Times != 0 {
+		t.Errorf("error adding override component")
+	}
+
+	// Prefab definitions are not changed by any instance.
+	bullet = prefabManager.CreateEntity("test-bullet", nil)
+	if bullet.GetName() != "bullet" || bullet.GetLayer() != engosdl.LayerMiddle || len(bullet.GetComponents()) != 2 {
+		t.Errorf("error creating entity from base prefab")
+	}
+	if moveTo := bullet.GetComponent(&components.MoveTo{}).(*components.MoveTo); moveTo.Speed.X != 0 {
+		t.Errorf("error changing prefab definition\nexp: %f\ngot: %f\n", 0.0, moveTo.Speed.X)
+	}
+
+	// Nested prefabs.
+	turret := prefabManager.CreateEntity("test-turret", nil)
+	if turret == nil || len(turret.GetChildren()) != 1 {
+		t.Fatalf("error creating nested prefab")
+	}
+	if child := turret.GetChildren()[0]; child.GetName() != "fast-bullet" || child.GetTransform().GetPosition().X != 5 || len(child.GetComponents()) != 2 {
+		t.Errorf("error creating nested prefab child")
+	}
+	if prefabManager.CreateEntity("test-loop", nil) != nil {
+		t.Errorf("error creating prefab nested in itself")
+	}
+	if prefabManager.CreateEntity("unknown", nil) != nil {
+		t.Errorf("error creating unknown prefab")
+	}
+}
+
+func TestPrefabManager_Scene(t *testing.T) {
+	prefabManager := engosdl.GetPrefabManager()
+	if err := prefabManager.LoadPrefabs(strings.NewReader(testPrefabs)); err != nil {
+		t.Fatal(err)
+	}
+	invalid := engosdl.NewScene("invalid", "test")
+	if err := invalid.LoadScene(strings.NewReader(`{"name": "level", "entities": [{"prefab": "unknown"}]}`)); err == nil || invalid.GetName() != "invalid" {
+		t.Errorf("error loading unknown prefab")
+	}
+
+	// Entities in the scene and bullets shoot refer to prefabs.
+	var loadErr error
+	scene := engosdl.NewScene("", "")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		data := `{"name": "level", "entities": [{"prefab": "test-turret", "entity-data": {"name": "player"},
+			"components": [{"component-type": "*components.Shooter", "component-data": {"name": "player/shooter", "prefab": "test-bullet"}}]}]}`
+		loadErr = scene.LoadScene(strings.NewReader(data))
+		return loadErr == nil
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	player := scene.GetEntityByName("player")
+	if player == nil || player.GetTag() != "turret" || scene.GetEntityByName("fast-bullet") == nil {
+		t.Fatalf("error loading prefab in scene")
+	}
+	shooter := player.GetComponent(&components.Shooter{}).(*components.Shooter)
+	if shooter.Prefab != "test-bullet" {
+		t.Errorf("error loading shooter prefab\nexp: %s\ngot: %s\n", "test-bullet", shooter.Prefab)
+	}
+	shooter.Cooldown = 0
+	shooter.ShooterSignature(sdl.SCANCODE_SPACE)
+	engine.DoRunFrames(2)
+	if bullet := scene.GetEntityByName("bullet"); bullet == nil || bullet.GetParent() != player {
+		t.Errorf("error shooting prefab bullet")
+	}
+}
+
+func TestPrefabManager_ReloadPrefabs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engosdl-prefab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "prefabs.json")
+	write := func(tag string, modTime time.Time) {
+		data := `[{"name": "test-reload", "entity-data": {"name": "reload", "tag": "` + tag + `"}}]`
+		if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filename, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prefabManager := engosdl.GetPrefabManager()
+	now := time.Now()
+	write("old", now)
+	if err := prefabManager.AddPrefabFile(filename); err != nil {
+		t.Fatal(err)
+	}
+	old := prefabManager.CreateEntity("test-reload", nil)
+	if old.GetTag() != "old" {
+		t.Errorf("error loading prefab file\nexp: %s\ngot: %s\n", "old", old.GetTag())
+	}
+	write("new", now.Add(time.Second))
+	if err := prefabManager.ReloadPrefabs(); err != nil {
+		t.Fatal(err)
+	}
+	if tag := prefabManager.CreateEntity("test-reload", nil).GetTag(); tag != "new" || old.GetTag() != "old" {
+		t.Errorf("error reloading prefab file\nexp: %s\ngot: %s\n", "new", tag)
+	}
+	if err := prefabManager.AddPrefabFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("error loading missing prefab file")
+	}
+}
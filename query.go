@@ -0,0 +1,111 @@
+package engosdl
+
+import (
+	"math"
+	"sort"
+)
+
+// QueryFilter selects colliders returned by scene queries. Tag selects only
+// entities with the given tag, any entity is selected if it is empty. Mask
+// selects only colliders with any category bit in the mask, collision matrix
+// GetLayers method can be used to build the mask for given layer names.
+type QueryFilter struct {
+	Tag  string
+	Mask uint32
+}
+
+// NewQueryFilter creates a new query filter instance that selects any
+// collider.
+func NewQueryFilter() *QueryFilter {
+	return &QueryFilter{
+		Tag:  "",
+		Mask: CollisionMaskAll,
+	}
+}
+
+// NewQueryFilterWithTag creates a new query filter instance that selects
+// colliders for entities with the given tag.
+func NewQueryFilterWithTag(tag string) *QueryFilter {
+	filter := NewQueryFilter()
+	filter.Tag = tag
+	return filter
+}
+
+// NewQueryFilterWithMask creates a new query filter instance that selects
+// colliders with any category in the given mask.
+func NewQueryFilterWithMask(mask uint32) *QueryFilter {
+	filter := NewQueryFilter()
+	filter.Mask = mask
+	return filter
+}
+
+// Match returns if the given collider is selected by the filter. A nil
+// filter selects any collider.
+func (f *QueryFilter) Match(collider ICollider) bool {
+	if f == nil {
+		return true
+	}
+	if f.Tag != "" && collider.GetEntity().GetTag() != f.Tag {
+		return false
+	}
+	return collider.GetCategory()&f.Mask != 0
+}
+
+// QueryHit represents a collider found by a scene query. For raycasts, Point
+// is where the ray hits the collider, Normal is the collider surface normal
+// at that point and Distance is measured from the ray origin. For point and
+// shape queries, Point is the center of the overlapping area, Normal points
+// from the query shape to the collider and Distance is measured between
+// shape centers.
+type QueryHit struct {
+	Entity   IEntity
+	Collider ICollider
+	Point    *Vector
+	Normal   *Vector
+	Distance float64
+}
+
+// NewQueryHit creates a new query hit instance.
+func NewQueryHit(collider ICollider, point *Vector, normal *Vector, distance float64) *QueryHit {
+	return &QueryHit{
+		Entity:   collider.GetEntity(),
+		Collider: collider,
+		Point:    point,
+		Normal:   normal,
+		Distance: distance,
+	}
+}
+
+// sortHits sorts hits by distance. Hits at the same distance keep the order
+// they were found.
+func sortHits(hits []*QueryHit) []*QueryHit {
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Distance < hits[j].Distance
+	})
+	return hits
+}
+
+// getRayBounds returns the axis aligned rectangle containing the ray with the
+// given origin, unit direction and distance.
+func getRayBounds(origin *Vector, direction *Vector, distance float64) *Rect {
+	x, y := origin.X+direction.X*distance, origin.Y+direction.Y*distance
+	minX, minY := math.Min(origin.X, x), math.Min(origin.Y, y)
+	return &Rect{X: minX, Y: minY, W: math.Max(origin.X, x) - minX, H: math.Max(origin.Y, y) - minY}
+}
+
+// overlapShape returns the hit for the given query shape overlapping the
+// given collider shape. Hit point is the center of the area shared by both
+// shape bounds.
+func overlapShape(query ICollisionShape, collider ICollider, shape ICollisionShape) (*QueryHit, bool) {
+	contact, ok := Collide(query, shape)
+	if !ok {
+		return nil, false
+	}
+	a, b := query.GetBounds(), shape.GetBounds()
+	minX, minY := math.Max(a.X, b.X), math.Max(a.Y, b.Y)
+	maxX, maxY := math.Min(a.X+a.W, b.X+b.W), math.Min(a.Y+a.H, b.Y+b.H)
+	point := NewVector((minX+maxX)/2, (minY+maxY)/2)
+	one, two := query.GetCenter(), shape.GetCenter()
+	distance := math.Hypot(two.X-one.X, two.Y-one.Y)
+	return NewQueryHit(collider, point, contact.Normal, distance), true
+}
@@ -0,0 +1,113 @@
+package engosdl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func newQueryScene(name string) *engosdl.Scene {
+	scene := engosdl.NewScene(name, "test")
+	scene.SetCollisionMode(engosdl.ModeBox)
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		for i, tag := range []string{"enemy", "wall", "enemy"} {
+			entity := engosdl.NewEntity("box")
+			entity.SetTag(tag)
+			entity.GetTransform().SetPositionXY(float64(100+i*100), 100)
+			entity.AddComponent(components.NewBox("box/box", &engosdl.Rect{W: 20, H: 20}, sdl.Color{A: 255}, true))
+			entity.AddComponent(components.NewCollider2D("box/collider-2D"))
+			scene.AddEntity(entity)
+		}
+		return true
+	})
+	return scene
+}
+
+func TestScene_Raycast(t *testing.T) {
+	engine := newTestEngine(t)
+	scene := newQueryScene("test-raycast-scene")
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	origin := engosdl.NewVector(0, 110)
+	hit := scene.Raycast(origin, engosdl.NewVector(1, 0), 400, nil)
+	if hit == nil {
+		t.Fatalf("error getting raycast hit")
+	}
+	if hit.Distance != 100 || !equalVector(hit.Point, engosdl.NewVector(100, 110)) || !equalVector(hit.Normal, engosdl.NewVector(-1, 0)) {
+		t.Errorf("error getting raycast hit\nexp: %f %v %v\ngot: %f %v %v\n", 100.0, engosdl.NewVector(100, 110), engosdl.NewVector(-1, 0), hit.Distance, hit.Point, hit.Normal)
+	}
+	if hits := scene.RaycastAll(origin, engosdl.NewVector(1, 0), 400, nil); len(hits) != 3 || hits[2].Distance != 300 {
+		t.Errorf("error getting all raycast hits\nexp: %d\ngot: %d\n", 3, len(hits))
+	}
+	if hits := scene.RaycastAll(origin, engosdl.NewVector(1, 0), 250, engosdl.NewQueryFilterWithTag("enemy")); len(hits) != 1 {
+		t.Errorf("error filtering raycast hits\nexp: %d\ngot: %d\n", 1, len(hits))
+	}
+	if hit := scene.Raycast(origin, engosdl.NewVector(0, 1), 400, nil); hit != nil {
+		t.Errorf("error getting raycast miss\nexp: nil\ngot: %v\n", hit)
+	}
+	if hits := scene.RaycastAll(origin, engosdl.NewVector(1, 0), 400, engosdl.NewQueryFilterWithMask(2)); len(hits) != 0 {
+		t.Errorf("error filtering raycast hits by mask\nexp: %d\ngot: %d\n", 0, len(hits))
+	}
+}
+
+func TestScene_Query(t *testing.T) {
+	engine := newTestEngine(t)
+	scene := newQueryScene("test-query-scene")
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	if hits := scene.QueryPoint(engosdl.NewVector(205, 105), nil); len(hits) != 1 || hits[0].Entity.GetTag() != "wall" {
+		t.Errorf("error querying point\nexp: %d\ngot: %d\n", 1, len(hits))
+	}
+	if hits := scene.QueryPoint(engosdl.NewVector(150, 105), nil); len(hits) != 0 {
+		t.Errorf("error querying empty point\nexp: %d\ngot: %d\n", 0, len(hits))
+	}
+	if hits := scene.QueryRect(engosdl.NewRect(110, 90, 100, 20), nil); len(hits) != 2 {
+		t.Errorf("error querying rectangle\nexp: %d\ngot: %d\n", 2, len(hits))
+	}
+	hits := scene.QueryCircle(engosdl.NewVector(250, 110), 55, nil)
+	if len(hits) != 2 || hits[0].Entity.GetTag() != "wall" {
+		t.Errorf("error querying circle\nexp: %d\ngot: %d\n", 2, len(hits))
+	} else if math.Abs(hits[1].Distance-60) > 1e-9 {
+		t.Errorf("error getting query distance\nexp: %f\ngot: %f\n", 60.0, hits[1].Distance)
+	}
+
+	// Colliders moved after the last collision check are found at their
+	// current position.
+	hits[0].Entity.GetTransform().SetPositionXY(200, 200)
+	if hits := scene.QueryPoint(engosdl.NewVector(205, 205), nil); len(hits) != 1 || hits[0].Entity.GetTag() != "wall" {
+		t.Errorf("error querying moved collider\nexp: %d\ngot: %d\n", 1, len(hits))
+	}
+	if hits := scene.QueryPoint(engosdl.NewVector(205, 105), nil); len(hits) != 0 {
+		t.Errorf("error querying moved collider old position\nexp: %d\ngot: %d\n", 0, len(hits))
+	}
+
+	// Colliders with a new shape are found with the new shape.
+	shape := engosdl.NewColliderShape(engosdl.ShapeAABB)
+	shape.Offset = engosdl.NewVector(100, 0)
+	hits[0].Collider.SetShape(shape)
+	if hits := scene.QueryPoint(engosdl.NewVector(305, 205), nil); len(hits) != 1 || hits[0].Entity.GetTag() != "wall" {
+		t.Errorf("error querying collider with new shape\nexp: %d\ngot: %d\n", 1, len(hits))
+	}
+}
+
+func TestShape_Raycast(t *testing.T) {
+	circle := engosdl.NewCircleShape(engosdl.NewVector(50, 0), 10)
+	distance, normal, ok := circle.Raycast(engosdl.NewVector(0, 0), engosdl.NewVector(1, 0), 100)
+	if !ok || distance != 40 || !equalVector(normal, engosdl.NewVector(-1, 0)) {
+		t.Errorf("error raycasting circle\nexp: %f %v\ngot: %f %v\n", 40.0, engosdl.NewVector(-1, 0), distance, normal)
+	}
+	if _, _, ok := circle.Raycast(engosdl.NewVector(0, 0), engosdl.NewVector(1, 0), 30); ok {
+		t.Errorf("error raycasting circle out of distance")
+	}
+	box := engosdl.NewBoxShape(engosdl.NewRect(40, -10, 20, 20), 45)
+	distance, _, ok = box.Raycast(engosdl.NewVector(0, 0), engosdl.NewVector(1, 0), 100)
+	if exp := 50 - 10*math.Sqrt2; !ok || math.Abs(distance-exp) > 1e-9 {
+		t.Errorf("error raycasting oriented box\nexp: %f\ngot: %f\n", exp, distance)
+	}
+	if !box.Contains(engosdl.NewVector(50, 12)) || box.Contains(engosdl.NewVector(42, -8)) {
+		t.Errorf("error checking point inside oriented box")
+	}
+}
@@ -59,3 +59,14 @@ func (r *Rect) Intersect(other *Rect) (*Rect, bool) {
 		H: float64(resultRect.H),
 	}, ok
 }
+
+// Lerp returns the rectangle between this rectangle and the given one for
+// the given factor, where 0 is this rectangle and 1 is the given one.
+func (r *Rect) Lerp(to *Rect, factor float64) *Rect {
+	return &Rect{
+		X: r.X + (to.X-r.X)*factor,
+		Y: r.Y + (to.Y-r.Y)*factor,
+		W: r.W + (to.W-r.W)*factor,
+		H: r.H + (to.H-r.H)*factor,
+	}
+}
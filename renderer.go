@@ -0,0 +1,193 @@
+package engosdl
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// ITexture represents any texture created by a renderer backend.
+type ITexture interface {
+	Destroy() error
+	Query() (uint32, int, int32, int32, error)
+	SetAlphaMod(uint8) error
+	SetBlendMode(sdl.BlendMode) error
+	SetColorMod(uint8, uint8, uint8) error
+}
+
+// IRenderer represents the renderer backend used by the engine and by all
+// components in order to draw in the display.
+type IRenderer interface {
+	Clear() error
+	Copy(ITexture, *sdl.Rect, *sdl.Rect) error
+	CopyEx(ITexture, *sdl.Rect, *sdl.Rect, float64, *sdl.Point, sdl.RendererFlip) error
+	CreateRenderTexture(int32, int32) (ITexture, error)
+	CreateTextureFromSurface(*sdl.Surface) (ITexture, error)
+	Destroy() error
+	DrawLine(int32, int32, int32, int32) error
+	DrawPoint(int32, int32) error
+	DrawRect(*sdl.Rect) error
+	FillRect(*sdl.Rect) error
+	GetOutputSize() (int32, int32, error)
+	Present()
+	SetDrawBlendMode(sdl.BlendMode) error
+	SetDrawColor(uint8, uint8, uint8, uint8) error
+	SetRenderTarget(ITexture) error
+}
+
+// SdlTexture is the texture implementation for the SDL renderer backend.
+type SdlTexture struct {
+	texture *sdl.Texture
+}
+
+var _ ITexture = (*SdlTexture)(nil)
+
+// NewSdlTexture creates a new sdl texture instance.
+func NewSdlTexture(texture *sdl.Texture) *SdlTexture {
+	return &SdlTexture{
+		texture: texture,
+	}
+}
+
+// Destroy destroys the texture.
+func (t *SdlTexture) Destroy() error {
+	return t.texture.Destroy()
+}
+
+// GetTexture returns the sdl texture.
+func (t *SdlTexture) GetTexture() *sdl.Texture {
+	return t.texture
+}
+
+// Query returns texture format, access, width and height.
+func (t *SdlTexture) Query() (uint32, int, int32, int32, error) {
+	return t.texture.Query()
+}
+
+// SetAlphaMod sets the alpha value multiplied into render copy operations.
+func (t *SdlTexture) SetAlphaMod(alpha uint8) error {
+	return t.texture.SetAlphaMod(alpha)
+}
+
+// SetBlendMode sets the blend mode used for texture copy operations.
+func (t *SdlTexture) SetBlendMode(blendMode sdl.BlendMode) error {
+	return t.texture.SetBlendMode(blendMode)
+}
+
+// SetColorMod sets the color value multiplied into render copy operations.
+func (t *SdlTexture) SetColorMod(r uint8, g uint8, b uint8) error {
+	return t.texture.SetColorMod(r, g, b)
+}
+
+// SdlRenderer is the renderer implementation using a SDL renderer.
+type SdlRenderer struct {
+	renderer *sdl.Renderer
+}
+
+var _ IRenderer = (*SdlRenderer)(nil)
+
+// NewSdlRenderer creates a new sdl renderer instance.
+func NewSdlRenderer(renderer *sdl.Renderer) *SdlRenderer {
+	Logger.Trace().Str("renderer", "sdl").Msg("new sdl renderer")
+	return &SdlRenderer{
+		renderer: renderer,
+	}
+}
+
+// getSdlTexture returns the sdl texture for the given texture.
+func getSdlTexture(texture ITexture) *sdl.Texture {
+	if t, ok := texture.(*SdlTexture); ok {
+		return t.texture
+	}
+	return nil
+}
+
+// Clear clears the current rendering target with the drawing color.
+func (r *SdlRenderer) Clear() error {
+	return r.renderer.Clear()
+}
+
+// Copy copies a portion of the texture to the current rendering target.
+func (r *SdlRenderer) Copy(texture ITexture, src *sdl.Rect, dst *sdl.Rect) error {
+	return r.renderer.Copy(getSdlTexture(texture), src, dst)
+}
+
+// CopyEx copies a portion of the texture to the current rendering target,
+// optionally rotating it by angle around the given center and also flipping
+// it top-bottom and/or left-right.
+func (r *SdlRenderer) CopyEx(texture ITexture, src *sdl.Rect, dst *sdl.Rect, angle float64, center *sdl.Point, flip sdl.RendererFlip) error {
+	return r.renderer.CopyEx(getSdlTexture(texture), src, dst, angle, center, flip)
+}
+
+// CreateRenderTexture creates a texture that can be used as rendering
+// target.
+func (r *SdlRenderer) CreateRenderTexture(width int32, height int32) (ITexture, error) {
+	texture, err := r.renderer.CreateTexture(sdl.PIXELFORMAT_RGBA8888, sdl.TEXTUREACCESS_TARGET, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return NewSdlTexture(texture), nil
+}
+
+// CreateTextureFromSurface creates a texture from an existing surface.
+func (r *SdlRenderer) CreateTextureFromSurface(surface *sdl.Surface) (ITexture, error) {
+	texture, err := r.renderer.CreateTextureFromSurface(surface)
+	if err != nil {
+		return nil, err
+	}
+	return NewSdlTexture(texture), nil
+}
+
+// Destroy destroys the renderer.
+func (r *SdlRenderer) Destroy() error {
+	return r.renderer.Destroy()
+}
+
+// DrawLine draws a line on the current rendering target.
+func (r *SdlRenderer) DrawLine(x1 int32, y1 int32, x2 int32, y2 int32) error {
+	return r.renderer.DrawLine(x1, y1, x2, y2)
+}
+
+// DrawPoint draws a point on the current rendering target.
+func (r *SdlRenderer) DrawPoint(x int32, y int32) error {
+	return r.renderer.DrawPoint(x, y)
+}
+
+// DrawRect draws a rectangle on the current rendering target.
+func (r *SdlRenderer) DrawRect(rect *sdl.Rect) error {
+	return r.renderer.DrawRect(rect)
+}
+
+// FillRect fills a rectangle on the current rendering target with the
+// drawing color.
+func (r *SdlRenderer) FillRect(rect *sdl.Rect) error {
+	return r.renderer.FillRect(rect)
+}
+
+// GetOutputSize returns the output size in pixels of the rendering context.
+func (r *SdlRenderer) GetOutputSize() (int32, int32, error) {
+	return r.renderer.GetOutputSize()
+}
+
+// GetRenderer returns the sdl renderer.
+func (r *SdlRenderer) GetRenderer() *sdl.Renderer {
+	return r.renderer
+}
+
+// Present updates the screen with any rendering performed since the previous
+// call.
+func (r *SdlRenderer) Present() {
+	r.renderer.Present()
+}
+
+// SetDrawBlendMode sets the blend mode used for drawing operations.
+func (r *SdlRenderer) SetDrawBlendMode(blendMode sdl.BlendMode) error {
+	return r.renderer.SetDrawBlendMode(blendMode)
+}
+
+// SetDrawColor sets the color used for drawing operations.
+func (r *SdlRenderer) SetDrawColor(red uint8, green uint8, blue uint8, alpha uint8) error {
+	return r.renderer.SetDrawColor(red, green, blue, alpha)
+}
+
+// SetRenderTarget sets the texture to be used as rendering target. Nil
+// texture sets the display as rendering target.
+func (r *SdlRenderer) SetRenderTarget(texture ITexture) error {
+	return r.renderer.SetRenderTarget(getSdlTexture(texture))
+}
@@ -12,11 +12,12 @@ import (
 type IResource interface {
 	IObject
 	Clear()
+	CreateTexture() (ITexture, error)
 	Delete() int
 	GetFilename() string
 	GetFormat() int
 	GetSurface() *sdl.Surface
-	GetTextureFromSurface() *sdl.Texture
+	GetTextureFromSurface() ITexture
 	New()
 }
 
@@ -31,36 +32,53 @@ type Resource struct {
 
 var _ IResource = (*Resource)(nil)
 
-// NewResource creates a new resource instance.
+// NewResource creates a new resource instance. It returns nil and the error
+// is reported if resource file can not be loaded.
 func NewResource(name string, filename string, format int) *Resource {
-	var err error
+	result, err := NewResourceFromFile(name, filename, format)
+	if err != nil {
+		ReportError(err)
+		return nil
+	}
+	return result
+}
+
+// NewResourceFromFile creates a new resource instance. It returns an error if
+// resource file can not be loaded.
+func NewResourceFromFile(name string, filename string, format int) (*Resource, error) {
 	Logger.Trace().Str("resource", name).Str("filename", filename).Msg("new resource")
-	result := &Resource{
+	surface, err := loadSurface(filename, format)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s load error: %w", filename, err)
+	}
+	result := NewResourceFromSurface(name, filename, format, surface)
+	result.counter = 1
+	return result, nil
+}
+
+// NewResourceFromSurface creates a new resource instance for a surface
+// already loaded. Resource is not being used yet, so counter starts at zero.
+func NewResourceFromSurface(name string, filename string, format int, surface *sdl.Surface) *Resource {
+	Logger.Trace().Str("resource", name).Str("filename", filename).Msg("new resource from surface")
+	return &Resource{
 		Object:   NewObject(name),
 		filename: filename,
+		surface:  surface,
 		counter:  0,
 		format:   format,
 	}
+}
+
+// loadSurface loads the surface for the given image file. It does not use
+// any engine resource, so it can be called from any goroutine.
+func loadSurface(filename string, format int) (*sdl.Surface, error) {
 	switch format {
 	case FormatBMP:
-		result.surface, err = sdl.LoadBMP(filename)
-		break
-	case FormatPNG:
-		result.surface, err = img.Load(filename)
-		break
-	case FormatJPG:
-		result.surface, err = img.Load(filename)
-		break
-	default:
-		err := fmt.Errorf("unknown format %d", format)
-		Logger.Error().Err(err)
-		panic(err)
+		return sdl.LoadBMP(filename)
+	case FormatPNG, FormatJPG:
+		return img.Load(filename)
 	}
-	if err != nil {
-		Logger.Error().Err(err).Msg("LoadBMP error")
-		panic(err)
-	}
-	return result
+	return nil, fmt.Errorf("unknown format %d", format)
 }
 
 // Clear deletes resource even if counter is not zero.
@@ -70,6 +88,17 @@ func (r *Resource) Clear() {
 	r.Delete()
 }
 
+// CreateTexture returns a texture from the resource surface. It returns an
+// error if texture can not be created.
+func (r *Resource) CreateTexture() (ITexture, error) {
+	Logger.Trace().Str("resource", r.GetName()).Str("filename", r.GetFilename()).Msg("create texture")
+	texture, err := GetRenderer().CreateTextureFromSurface(r.surface)
+	if err != nil {
+		return nil, fmt.Errorf("resource %s texture error: %w", r.GetFilename(), err)
+	}
+	return texture, nil
+}
+
 // Delete deletes resource and relese all memory.
 func (r *Resource) Delete() int {
 	Logger.Trace().Str("resource", r.GetName()).Str("filename", r.GetFilename()).Msg("delete resource")
@@ -95,13 +124,13 @@ func (r *Resource) GetSurface() *sdl.Surface {
 	return r.surface
 }
 
-// GetTextureFromSurface returns a texture from the resource surface.
-func (r *Resource) GetTextureFromSurface() *sdl.Texture {
-	Logger.Trace().Str("resource", r.GetName()).Str("filename", r.GetFilename()).Msg("get texture from surface")
-	texture, err := GetRenderer().CreateTextureFromSurface(r.surface)
+// GetTextureFromSurface returns a texture from the resource surface. It
+// returns nil and the error is reported if texture can not be created.
+func (r *Resource) GetTextureFromSurface() ITexture {
+	texture, err := r.CreateTexture()
 	if err != nil {
-		Logger.Error().Err(err).Msg("CreateTextureFromSurface error")
-		panic(err)
+		ReportError(err)
+		return nil
 	}
 	return texture
 }
@@ -115,14 +144,22 @@ func (r *Resource) New() {
 // resources.
 type IResourceManager interface {
 	IObject
+	AddResource(IResource) bool
 	Clear()
+	CreateAtlas(string, string) ITextureAtlas
 	CreateResource(string, string, int) IResource
+	DeleteAtlas(ITextureAtlas) bool
 	DeleteResource(IResource) bool
 	DoInit()
+	GetAtlasByFilename(string) ITextureAtlas
+	GetAtlasByName(string) ITextureAtlas
+	GetAtlases() []ITextureAtlas
 	GetResource(string) IResource
 	GetResourceByFilename(string) IResource
 	GetResourceByName(string) IResource
 	GetResources() []IResource
+	LoadAtlas(string, string) (ITextureAtlas, error)
+	LoadResource(string, string, int) (IResource, error)
 	OnStart()
 }
 
@@ -130,6 +167,7 @@ type IResourceManager interface {
 type ResourceManager struct {
 	*Object
 	resources []IResource
+	atlases   []ITextureAtlas
 }
 
 var _ IResourceManager = (*ResourceManager)(nil)
@@ -140,33 +178,76 @@ func NewResourceManager(name string) *ResourceManager {
 	return &ResourceManager{
 		Object:    NewObject(name),
 		resources: []IResource{},
+		atlases:   []ITextureAtlas{},
 	}
 }
 
+// AddResource adds a resource already created to the resource manager. It
+// returns false if there is already a resource with the same filename.
+func (h *ResourceManager) AddResource(resource IResource) bool {
+	Logger.Trace().Str("resource-manager", h.GetName()).Str("name", resource.GetName()).Str("filename", resource.GetFilename()).Msg("AddResource")
+	if h.GetResourceByFilename(resource.GetFilename()) != nil {
+		return false
+	}
+	h.resources = append(h.resources, resource)
+	return true
+}
+
 // Clear removes all resources from the resource manager.
 func (h *ResourceManager) Clear() {
 	Logger.Trace().Str("resource-manager", h.GetName()).Msg("Clear")
+	for _, atlas := range h.atlases {
+		atlas.Destroy()
+	}
+	h.atlases = []ITextureAtlas{}
 	for _, r := range h.resources {
 		r.Clear()
 	}
 	h.resources = []IResource{}
 }
 
+// CreateAtlas creates a new texture atlas from the given descriptor file. If
+// the same atlas has already been created with the same filename, existing
+// atlas is returned. Atlas image is loaded as a resource. It returns nil and
+// the error is reported if atlas can not be loaded.
+func (h *ResourceManager) CreateAtlas(name string, filename string) ITextureAtlas {
+	atlas, err := h.LoadAtlas(name, filename)
+	if err != nil {
+		ReportError(err)
+		return nil
+	}
+	return atlas
+}
+
 // CreateResource creates a new resource. If the same resource has already
-// been created with the same filename, existing resource is returned.
+// been created with the same filename, existing resource is returned. It
+// returns nil and the error is reported if resource can not be loaded.
 func (h *ResourceManager) CreateResource(name string, filename string, format int) IResource {
-	Logger.Trace().Str("resource-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("CreateResource")
-	for _, resource := range h.resources {
-		if resource.GetFilename() == filename {
-			resource.New()
-			return resource
-		}
+	resource, err := h.LoadResource(name, filename, format)
+	if err != nil {
+		ReportError(err)
+		return nil
 	}
-	resource := NewResource(name, filename, format)
-	h.resources = append(h.resources, resource)
 	return resource
 }
 
+// DeleteAtlas deletes atlas from the manager. Atlas texture is released
+// when the atlas resource is not used anymore.
+func (h *ResourceManager) DeleteAtlas(atlas ITextureAtlas) bool {
+	Logger.Trace().Str("resource-manager", h.GetName()).Str("name", atlas.GetName()).Str("filename", atlas.GetFilename()).Msg("DeleteAtlas")
+	for i := len(h.atlases) - 1; i >= 0; i-- {
+		if h.atlases[i].GetID() == atlas.GetID() {
+			resource := atlas.GetResource()
+			if h.DeleteResource(resource) && h.GetResource(resource.GetID()) == nil {
+				atlas.Destroy()
+				h.atlases = append(h.atlases[:i], h.atlases[i+1:]...)
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteResource deletes resource from the manager. Memory resources are
 // released from the given resource.
 func (h *ResourceManager) DeleteResource(resource IResource) bool {
@@ -188,6 +269,31 @@ func (h *ResourceManager) DoInit() {
 	Logger.Trace().Str("resource-manager", h.GetName()).Msg("DoInit")
 }
 
+// GetAtlasByFilename returns the atlas with the given descriptor filename.
+func (h *ResourceManager) GetAtlasByFilename(filename string) ITextureAtlas {
+	for _, atlas := range h.atlases {
+		if atlas.GetFilename() == filename {
+			return atlas
+		}
+	}
+	return nil
+}
+
+// GetAtlasByName returns the atlas with the given name.
+func (h *ResourceManager) GetAtlasByName(name string) ITextureAtlas {
+	for _, atlas := range h.atlases {
+		if atlas.GetName() == name {
+			return atlas
+		}
+	}
+	return nil
+}
+
+// GetAtlases returns all atlases.
+func (h *ResourceManager) GetAtlases() []ITextureAtlas {
+	return h.atlases
+}
+
 // GetResource returns a resource with the given resource ID.
 func (h *ResourceManager) GetResource(id string) IResource {
 	for _, resource := range h.resources {
@@ -223,6 +329,48 @@ func (h *ResourceManager) GetResources() []IResource {
 	return h.resources
 }
 
+// LoadAtlas creates a new texture atlas from the given descriptor file. If
+// the same atlas has already been created with the same filename, existing
+// atlas is returned. Atlas image is loaded as a resource. It returns an error
+// if atlas descriptor or atlas image can not be loaded.
+func (h *ResourceManager) LoadAtlas(name string, filename string) (ITextureAtlas, error) {
+	Logger.Trace().Str("resource-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("LoadAtlas")
+	if atlas := h.GetAtlasByFilename(filename); atlas != nil {
+		atlas.GetResource().New()
+		return atlas, nil
+	}
+	atlas, err := LoadTextureAtlas(name, filename)
+	if err != nil {
+		return nil, err
+	}
+	resource, err := h.LoadResource(name, atlas.GetImage(), GetFormatFromFilename(atlas.GetImage()))
+	if err != nil {
+		return nil, err
+	}
+	atlas.SetResource(resource)
+	h.atlases = append(h.atlases, atlas)
+	return atlas, nil
+}
+
+// LoadResource creates a new resource. If the same resource has already been
+// created with the same filename, existing resource is returned. It returns
+// an error if resource can not be loaded.
+func (h *ResourceManager) LoadResource(name string, filename string, format int) (IResource, error) {
+	Logger.Trace().Str("resource-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("LoadResource")
+	for _, resource := range h.resources {
+		if resource.GetFilename() == filename {
+			resource.New()
+			return resource, nil
+		}
+	}
+	resource, err := NewResourceFromFile(name, filename, format)
+	if err != nil {
+		return nil, err
+	}
+	h.resources = append(h.resources, resource)
+	return resource, nil
+}
+
 // OnStart initializes all resource handler structure.
 func (h *ResourceManager) OnStart() {
 	Logger.Trace().Str("resource-manager", h.GetName()).Msg("OnStart")
@@ -0,0 +1,71 @@
+package engosdl_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+func TestResourceManager_Errors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engosdl-resource")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "player.png")
+	writePNG(t, filename, 16, 16)
+	errors := []error{}
+	scene := engosdl.NewScene("errors", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		listener := engosdl.NewEntity("listener")
+		handler := engosdl.NewComponent("listener/handler")
+		handler.AddDelegateToRegister(engosdl.GetDelegateManager().GetErrorDelegate(), nil, nil, func(params ...interface{}) bool {
+			errors = append(errors, params[0].(error))
+			return true
+		})
+		listener.AddComponent(handler)
+		scene.AddEntity(listener)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+
+	// Error variants return the error, and nothing is created.
+	resources, sounds := len(engosdl.GetResourceManager().GetResources()), len(engosdl.GetSoundManager().GetSounds())
+	if resource, err := engosdl.GetResourceManager().LoadResource("unknown", filename, 0); err == nil || resource != nil {
+		t.Errorf("error loading resource with unknown format")
+	}
+	if atlas, err := engosdl.GetResourceManager().LoadAtlas("missing", filepath.Join(dir, "missing.json")); err == nil || atlas != nil {
+		t.Errorf("error loading missing atlas")
+	}
+	if sound, err := engosdl.GetSoundManager().LoadSound("missing", filepath.Join(dir, "missing.wav"), engosdl.SoundWAV); err == nil || sound != nil {
+		t.Errorf("error loading missing sound")
+	}
+	if len(engosdl.GetResourceManager().GetResources()) != resources || len(engosdl.GetSoundManager().GetSounds()) != sounds || len(errors) != 0 {
+		t.Errorf("error adding resources not loaded")
+	}
+	resource, err := engosdl.GetResourceManager().LoadResource("player", filename, engosdl.FormatPNG)
+	if err != nil || resource == nil {
+		t.Fatalf("error loading resource\nexp: %v\ngot: %v\n", nil, err)
+	}
+
+	// Errors are reported to the error delegate, and components are not
+	// displayed instead of crashing.
+	if engosdl.GetResourceManager().CreateResource("unknown", filepath.Join(dir, "enemy.png"), 0) != nil || len(errors) != 1 {
+		t.Errorf("error reporting resource error\nexp: %d\ngot: %d\n", 1, len(errors))
+	}
+	entity := engosdl.NewEntity("sprite")
+	entity.AddComponent(components.NewSprite("sprite/sprite", []string{filepath.Join(dir, "enemy.png")}, 1, 0))
+	entity.AddComponent(components.NewSound("sprite/sound", filepath.Join(dir, "missing.wav"), engosdl.SoundWAV))
+	scene.AddEntity(entity)
+	engine.DoRunFrames(2)
+	entity.GetComponent(&components.Sound{}).(*components.Sound).Play(1)
+	if len(errors) != 3 {
+		t.Errorf("error reporting component errors\nexp: %d\ngot: %d\n", 3, len(errors))
+	}
+}
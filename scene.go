@@ -3,8 +3,10 @@ package engosdl
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math"
+	"sort"
 )
 
 // Scene layer constants.
@@ -20,9 +22,37 @@ const (
 // a scene.
 type TSceneCodeSignature func(*Engine, IScene) bool
 
+// SceneToMarshal identifies scene information being marshaled to be saved
+// in JSON format. Only root entities are saved, children entities are saved
+// with their parent.
+type SceneToMarshal struct {
+	Name            string             `json:"name"`
+	Tag             string             `json:"tag"`
+	CollisionCheck  bool               `json:"collision-check"`
+	CollisionMode   int                `json:"collision-mode"`
+	CollisionMatrix *CollisionMatrix   `json:"collision-matrix"`
+	Gravity         *Vector            `json:"gravity"`
+	Assets          []*Asset           `json:"assets"`
+	Entities        []*EntityToMarshal `json:"entities"`
+}
+
+// SceneToUnmarshal identifies scene information from a JSON file required
+// to build a scene.
+type SceneToUnmarshal struct {
+	Name            string               `json:"name"`
+	Tag             string               `json:"tag"`
+	CollisionCheck  bool                 `json:"collision-check"`
+	CollisionMode   int                  `json:"collision-mode"`
+	CollisionMatrix *CollisionMatrix     `json:"collision-matrix"`
+	Gravity         *Vector              `json:"gravity"`
+	Assets          []*Asset             `json:"assets"`
+	Entities        []*EntityToUnmarshal `json:"entities"`
+}
+
 // IScene represents the interface for any game scene
 type IScene interface {
 	IObject
+	AddAssets(...*Asset)
 	AddEntity(IEntity) bool
 	AuditEntities()
 	DeleteEntity(IEntity) bool
@@ -34,21 +64,40 @@ type IScene interface {
 	DoSwapFrom()
 	DoSwapBack()
 	DoUnLoad()
+	GetAssets() []*Asset
+	GetBroadphase() IBroadphase
+	GetCamera() ICamera
 	GetCollisionCheck() bool
+	GetCollisionMatrix() *CollisionMatrix
 	GetCollisionMode() int
 	GetEntities() []IEntity
 	GetEntitiesByTag(string) []IEntity
 	GetEntity(string) IEntity
 	GetEntityByName(string) IEntity
+	GetGravity() *Vector
 	GetSceneCode() TSceneCodeSignature
 	GetTag() string
+	LoadScene(io.Reader) error
 	OnAfterUpdate()
 	OnRender()
 	OnEnable()
 	OnStart()
 	OnUpdate()
+	QueryCircle(*Vector, float64, *QueryFilter) []*QueryHit
+	QueryEntities(*ComponentQuery) []IEntity
+	QueryPoint(*Vector, *QueryFilter) []*QueryHit
+	QueryRect(*Rect, *QueryFilter) []*QueryHit
+	Raycast(*Vector, *Vector, float64, *QueryFilter) *QueryHit
+	RaycastAll(*Vector, *Vector, float64, *QueryFilter) []*QueryHit
+	RefreshQueries(IEntity)
+	RemoveEntity(IEntity) bool
+	SaveScene(io.Writer) error
+	SetBroadphase(IBroadphase)
+	SetCamera(ICamera)
 	SetCollisionCheck(bool)
+	SetCollisionMatrix(*CollisionMatrix)
 	SetCollisionMode(int)
+	SetGravity(*Vector)
 	SetSceneCode(TSceneCodeSignature)
 	SetTag(string)
 }
@@ -56,16 +105,28 @@ type IScene interface {
 // Scene is the default implementation for IScene interface.
 type Scene struct {
 	*Object
+	assets              []*Asset
 	entities            []IEntity
 	toDeleteEntities    []IEntity
+	toRemoveEntities    []IEntity
 	loadedEntities      []IEntity
 	unloadedEntities    []IEntity
 	layers              [][]IEntity
 	collisionCollection []ICollider
+	collisionIDs        map[string]bool
+	contacts            map[contactKey]*contactPair
+	broadphase          IBroadphase
+	queryColliders      []ICollider
+	queryVersion        uint64
+	queries             map[string]*queryCache
+	pools               map[string]IEntityPool
 	sceneCode           TSceneCodeSignature
 	tag                 string
+	camera              ICamera
+	collisionMatrix     *CollisionMatrix
 	collisionMode       int
 	collisionCheck      bool
+	gravity             *Vector
 }
 
 var _ IScene = (*Scene)(nil)
@@ -75,32 +136,67 @@ func NewScene(name string, tag string) *Scene {
 	Logger.Trace().Str("scene", name).Msg("new scene")
 	scene := &Scene{
 		Object:           NewObject(name),
+		assets:           []*Asset{},
 		entities:         []IEntity{},
 		toDeleteEntities: []IEntity{},
+		toRemoveEntities: []IEntity{},
 		loadedEntities:   []IEntity{},
 		unloadedEntities: []IEntity{},
 		layers:           make([][]IEntity, maxLayers),
+		collisionIDs:     make(map[string]bool),
 		sceneCode:        nil,
 		tag:              tag,
+		contacts:         make(map[contactKey]*contactPair),
+		queries:          make(map[string]*queryCache),
+		pools:            make(map[string]IEntityPool),
+		camera:           NewCamera(name + "/camera"),
+		broadphase:       NewSweepAndPruneBroadphase(),
+		collisionMatrix:  NewCollisionMatrix(),
 		collisionMode:    ModeCircle,
 		collisionCheck:   true,
+		gravity:          NewVector(0, 0),
 	}
 	return scene
 }
 
+// AddAssets adds assets required by the scene, so they can be loaded before
+// the scene is set as active.
+func (scene *Scene) AddAssets(assets ...*Asset) {
+	scene.assets = append(scene.assets, assets...)
+}
+
 // AddEntity adds a new entity to the scene. If entity has children entities,
-// all children are being added at this time in a recursive way.
+// all children are being added at this time in a recursive way. Entity
+// component dependencies are resolved, and entity is not added if they can
+// not be resolved, and the error is reported. Entity pool is kept, so it is cleared when the scene is
+// destroyed.
 func (scene *Scene) AddEntity(entity IEntity) bool {
 	Logger.Trace().Str("scene", scene.GetName()).Str("Entity", entity.GetName()).Msg("add entity")
+	if err := entity.ResolveDependencies(); err != nil {
+		ReportError(fmt.Errorf("entity %s can not be added to scene %s: %w", entity.GetName(), scene.GetName(), err))
+		return false
+	}
 	scene.entities = append(scene.entities, entity)
 	scene.unloadedEntities = append(scene.unloadedEntities, entity)
 	entity.SetScene(scene)
+	scene.RefreshQueries(entity)
+	if pool := entity.GetPool(); pool != nil {
+		scene.pools[pool.GetID()] = pool
+	}
 	for _, child := range entity.GetChildren() {
 		scene.AddEntity(child)
 	}
 	return true
 }
 
+// addToCollisionCollection adds the given collider to the collision
+// collection.
+func (scene *Scene) addToCollisionCollection(collider ICollider) {
+	scene.collisionCollection = append(scene.collisionCollection, collider)
+	scene.collisionIDs[collider.GetEntity().GetID()] = true
+	scene.queryColliders = nil
+}
+
 // AuditEntities displays all entities for audit purposes.
 func (scene *Scene) AuditEntities() {
 	for i, entity := range scene.GetEntities() {
@@ -109,45 +205,66 @@ func (scene *Scene) AuditEntities() {
 }
 
 // checkCollisions checks collisions between all entities in the scene.
+// Scene broadphase provides candidate pairs, pairs filtered out by the
+// collision matrix are skipped, and only remaining pairs are checked for
+// collision using collider shapes. Contacts are tracked across frames in
+// order to trigger collision enter, stay and exit delegates. Finally,
+// contacts are resolved for entities with rigid bodies, unless any collider
+// is a trigger. Broadphase is kept to be used by scene queries until next
+// check.
 func (scene *Scene) checkCollisions() {
+	scene.queryColliders = nil
 	if scene.collisionCheck {
-		for i := 0; i < len(scene.collisionCollection); i++ {
-			colliderI := scene.collisionCollection[i]
-			collisionBoxI := colliderI.GetCollisionBox()
-			centerI := collisionBoxI.GetCenter()
-			radiusI := collisionBoxI.GetRadius()
-			rectI := collisionBoxI.GetRect()
-			entityI := colliderI.GetEntity()
-			for j := i + 1; j < len(scene.collisionCollection); j++ {
-				colliderJ := scene.collisionCollection[j]
-				collisionBoxJ := colliderJ.GetCollisionBox()
-				centerJ := collisionBoxJ.GetCenter()
-				radiusJ := collisionBoxJ.GetRadius()
-				rectJ := collisionBoxJ.GetRect()
-				entityJ := colliderJ.GetEntity()
-				if scene.GetCollisionMode() == ModeCircle {
-					distance := math.Sqrt(math.Pow(centerI.X-centerJ.X, 2) + math.Pow(centerI.Y-centerJ.Y, 2))
-					if distance < (radiusI + radiusJ) {
-						// if rectI.HasIntersection(rectJ) {
-						// fmt.Printf("circular collision %s with %s\n", entityI.GetName(), entityJ.GetName())
-						delegate := GetDelegateManager().GetCollisionDelegate()
-						// GetDelegateManager().TriggerDelegate(delegate, true, entityI, entityJ)
-						GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityI, entityJ}, true, entityI, entityJ)
-					}
-				} else if scene.GetCollisionMode() == ModeBox {
-					if rectI.HasIntersection(rectJ) {
-						collisionRect, _ := rectI.Intersect(rectJ)
-						// fmt.Printf("box collision %s with %s\n", entityI.GetName(), entityJ.GetName())
-						delegate := GetDelegateManager().GetCollisionDelegate()
-						// GetDelegateManager().TriggerDelegate(delegate, true, entityI, entityJ)
-						GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityI, entityJ}, true, entityI, entityJ, collisionRect)
-					}
+		colliders := make([]ICollider, len(scene.collisionCollection))
+		copy(colliders, scene.collisionCollection)
+		shapes := make([]ICollisionShape, len(colliders))
+		rects := make([]*Rect, len(colliders))
+		for i, collider := range colliders {
+			shapes[i] = collider.GetCollisionShape()
+			rects[i] = shapes[i].GetBounds()
+		}
+		contacts := make(map[contactKey]*contactPair)
+		toResolve := []*contactPair{}
+		pairs := scene.broadphase.GetPairs(rects)
+		scene.queryColliders = colliders
+		scene.queryVersion = transformVersion
+		for _, pair := range pairs {
+			i, j := pair.I, pair.J
+			if !scene.collisionMatrix.CanCollide(colliders[i], colliders[j]) {
+				continue
+			}
+			entityI := colliders[i].GetEntity()
+			entityJ := colliders[j].GetEntity()
+			if contact, ok := Collide(shapes[i], shapes[j]); ok && scene.inCollisionCollection(entityI, entityJ) {
+				delegate := GetDelegateManager().GetCollisionDelegate()
+				if scene.GetCollisionMode() == ModeBox {
+					GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityI, entityJ}, true, entityI, entityJ, contact.Rect)
+				} else {
+					GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityI, entityJ}, true, entityI, entityJ)
+				}
+				scene.triggerContact(colliders[i], colliders[j], contact, contacts)
+				if !colliders[i].IsTrigger() && !colliders[j].IsTrigger() {
+					toResolve = append(toResolve, &contactPair{one: colliders[i], two: colliders[j], contact: contact})
 				}
 			}
 		}
+		scene.triggerContactExit(contacts)
+		for _, pair := range toResolve {
+			if scene.inCollisionCollection(pair.one.GetEntity(), pair.two.GetEntity()) {
+				resolveContact(pair.one.GetEntity(), pair.two.GetEntity(), pair.contact)
+			}
+		}
 	}
 }
 
+// clearCollisionCollection removes all colliders from the collision
+// collection.
+func (scene *Scene) clearCollisionCollection() {
+	scene.collisionCollection = []ICollider{}
+	scene.collisionIDs = make(map[string]bool)
+	scene.queryColliders = nil
+}
+
 // DeleteEntity deletes a entity from the scene.
 func (scene *Scene) DeleteEntity(entity IEntity) bool {
 	Logger.Trace().Str("scene", scene.GetName()).Str("Entity", entity.GetName()).Msg("delete entity")
@@ -159,11 +276,9 @@ func (scene *Scene) DeleteEntity(entity IEntity) bool {
 			for _, child := range entity.GetChildren() {
 				scene.toDeleteEntities = append(scene.toDeleteEntities, child)
 			}
-			// Remove collider from the collision collection, so there is not
-			// more checks between this collider and other other one.
-			if index, ok := scene.getIndexInCollisionCollectionByEntity(entity); ok {
-				scene.collisionCollection = append(scene.collisionCollection[:index], scene.collisionCollection[index+1:]...)
-			}
+			// Remove all entity colliders from the collision collection, so
+			// there is not more checks between them and other colliders.
+			scene.removeFromCollisionCollection(entity)
 			// Trigger destroy delegate
 			destroyDelegate := GetDelegateManager().GetDestroyDelegate()
 			GetDelegateManager().TriggerDelegate(destroyDelegate, true, entity)
@@ -173,21 +288,52 @@ func (scene *Scene) DeleteEntity(entity IEntity) bool {
 	return false
 }
 
-// DoDestroy calls all methods to clean up scene.
+// detachEntity removes the given entity from all scene entity lists.
+func (scene *Scene) detachEntity(entity IEntity) {
+	if _, i := scene.getEntity(entity.GetID()); i != -1 {
+		scene.entities = append(scene.entities[:i], scene.entities[i+1:]...)
+	}
+	if index, ok := scene.getIndexInLoadedEntity(entity); ok {
+		scene.loadedEntities = append(scene.loadedEntities[:index], scene.loadedEntities[index+1:]...)
+	}
+	if index, ok := scene.getIndexInUnloadedEntity(entity); ok {
+		scene.unloadedEntities = append(scene.unloadedEntities[:index], scene.unloadedEntities[index+1:]...)
+	}
+	if ilayer, index, ok := scene.getIndexInLayer(entity); ok {
+		scene.layers[ilayer] = append(scene.layers[ilayer][:index], scene.layers[ilayer][index+1:]...)
+	}
+	for _, cache := range scene.queries {
+		cache.remove(entity)
+	}
+}
+
+// DoDestroy calls all methods to clean up scene. Entities being removed are
+// not destroyed. Tweens owned by the scene are deleted, and entity pools used
+// by the scene are cleared, so released entities are destroyed.
 func (scene *Scene) DoDestroy() {
 	Logger.Trace().Str("scene", scene.GetName()).Msg("DoDestroy")
 	scene.SetLoaded(false)
+	scene.removeEntities()
 	for _, entity := range scene.loadedEntities {
 		entity.DoDestroy()
 	}
 	scene.entities = []IEntity{}
 	scene.loadedEntities = []IEntity{}
 	scene.unloadedEntities = []IEntity{}
-	scene.collisionCollection = []ICollider{}
+	scene.clearCollisionCollection()
+	scene.queries = make(map[string]*queryCache)
+	scene.contacts = make(map[contactKey]*contactPair)
 	scene.layers = make([][]IEntity, maxLayers)
+	if tweenManager := GetTweenManager(); tweenManager != nil {
+		tweenManager.DeleteTweensFor(scene)
+	}
+	for _, pool := range scene.pools {
+		pool.Clear()
+	}
+	scene.pools = make(map[string]IEntityPool)
 }
 
-// DoDump dumps all scene entities in JSON format.
+// DoDump dumps all scene entities in JSON format. Errors are reported.
 func (scene *Scene) DoDump() {
 	toDump := []*EntityToMarshal{}
 	for _, entity := range scene.GetEntities() {
@@ -195,13 +341,12 @@ func (scene *Scene) DoDump() {
 	}
 	result, err := json.MarshalIndent(toDump, "", "    ")
 	if err != nil {
-		Logger.Error().Err(err)
-		panic(err)
+		ReportError(err)
+		return
 	}
 	// fmt.Printf("%s\n", result)
 	if err := ioutil.WriteFile("entities.json", result, 0644); err != nil {
-		Logger.Error().Err(err)
-		panic(err)
+		ReportError(err)
 	}
 }
 
@@ -243,7 +388,8 @@ func (scene *Scene) DoSwapFrom() {
 	for _, entity := range scene.GetEntities() {
 		scene.unloadedEntities = append(scene.unloadedEntities, entity)
 	}
-	scene.collisionCollection = []ICollider{}
+	scene.clearCollisionCollection()
+	scene.contacts = make(map[contactKey]*contactPair)
 	scene.layers = make([][]IEntity, maxLayers)
 }
 
@@ -260,15 +406,38 @@ func (scene *Scene) DoUnLoad() {
 	// for _, entity := range scene.GetEntities() {
 	// 	scene.unloadedEntities = append(scene.unloadedEntities, entity)
 	// }
-	scene.collisionCollection = []ICollider{}
+	scene.clearCollisionCollection()
+	scene.queries = make(map[string]*queryCache)
+	scene.contacts = make(map[contactKey]*contactPair)
 	scene.layers = make([][]IEntity, maxLayers)
 }
 
+// GetAssets returns all assets required by the scene.
+func (scene *Scene) GetAssets() []*Asset {
+	return scene.assets
+}
+
+// GetBroadphase returns the broadphase used to check collisions.
+func (scene *Scene) GetBroadphase() IBroadphase {
+	return scene.broadphase
+}
+
+// GetCamera returns the scene camera.
+func (scene *Scene) GetCamera() ICamera {
+	return scene.camera
+}
+
 // GetCollisionCheck returns if collision have to be check in the scene.
 func (scene *Scene) GetCollisionCheck() bool {
 	return scene.collisionCheck
 }
 
+// GetCollisionMatrix returns the collision matrix with all named collision
+// layers.
+func (scene *Scene) GetCollisionMatrix() *CollisionMatrix {
+	return scene.collisionMatrix
+}
+
 // GetCollisionMode returns the collision mode used to detect collisions.
 func (scene *Scene) GetCollisionMode() int {
 	return scene.collisionMode
@@ -330,15 +499,16 @@ func (scene *Scene) GetEntityByName(name string) IEntity {
 	return nil
 }
 
-// getIndexInCollisionCollectionByEntity returns the index for the collider in
-// the collision collection that belongs to the given entity.
-func (scene *Scene) getIndexInCollisionCollectionByEntity(entity IEntity) (int, bool) {
-	for i, collider := range scene.collisionCollection {
-		if collider.GetEntity().GetID() == entity.GetID() {
-			return i, true
+// inCollisionCollection returns if all given entities have a collider in
+// the collision collection. Entities deleted by a collision delegate are
+// removed from the collection and they do not collide anymore.
+func (scene *Scene) inCollisionCollection(entities ...IEntity) bool {
+	for _, entity := range entities {
+		if !scene.collisionIDs[entity.GetID()] {
+			return false
 		}
 	}
-	return -1, false
+	return true
 }
 
 // getIndexInLayer returns the index for the given entity in layers array.
@@ -375,6 +545,12 @@ func (scene *Scene) getIndexInUnloadedEntity(entity IEntity) (int, bool) {
 	return -1, false
 }
 
+// GetGravity returns the gravity acceleration applied to all dynamic rigid
+// bodies in units per second squared.
+func (scene *Scene) GetGravity() *Vector {
+	return scene.gravity
+}
+
 // GetSceneCode returns the scene code.
 func (scene *Scene) GetSceneCode() TSceneCodeSignature {
 	return scene.sceneCode
@@ -385,6 +561,68 @@ func (scene *Scene) GetTag() string {
 	return scene.tag
 }
 
+// checkEntitiesToUnmarshal checks that all given entities and their children
+// can be unmarshaled.
+func checkEntitiesToUnmarshal(entities []*EntityToUnmarshal) error {
+	for _, instance := range entities {
+		if instance.Prefab != "" {
+			resolved, err := GetPrefabManager().resolve(instance, map[string]bool{})
+			if err != nil {
+				return err
+			}
+			instance = resolved
+		}
+		if _, ok := instance.Entity.(map[string]interface{}); !ok {
+			return fmt.Errorf("entity data not found")
+		}
+		for _, comp := range instance.Components {
+			if _, ok := GetComponentManager().Constructors[comp.ComponentName]; !ok {
+				return fmt.Errorf("component %s not found", comp.ComponentName)
+			}
+		}
+		if err := checkEntitiesToUnmarshal(instance.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadScene reads the scene from the given reader in JSON format, as it is
+// saved by SaveScene. Scene name, tag, collision settings, gravity and assets
+// are set, and all entities are added to the scene. It is usually called
+// from the scene code.
+func (scene *Scene) LoadScene(reader io.Reader) error {
+	Logger.Trace().Str("scene", scene.GetName()).Msg("LoadScene")
+	toLoad := &SceneToUnmarshal{}
+	if err := json.NewDecoder(reader).Decode(toLoad); err != nil {
+		Logger.Error().Err(err).Str("scene", scene.GetName()).Msg("LoadScene error")
+		return err
+	}
+	if err := checkEntitiesToUnmarshal(toLoad.Entities); err != nil {
+		Logger.Error().Err(err).Str("scene", scene.GetName()).Msg("LoadScene error")
+		return err
+	}
+	scene.SetName(toLoad.Name)
+	scene.SetTag(toLoad.Tag)
+	scene.SetCollisionCheck(toLoad.CollisionCheck)
+	scene.SetCollisionMode(toLoad.CollisionMode)
+	if toLoad.CollisionMatrix != nil {
+		scene.SetCollisionMatrix(toLoad.CollisionMatrix)
+	}
+	if toLoad.Gravity != nil {
+		scene.SetGravity(toLoad.Gravity)
+	}
+	if toLoad.Assets != nil {
+		scene.assets = toLoad.Assets
+	}
+	for _, instance := range toLoad.Entities {
+		entity := NewEntity("")
+		entity.Unmarshal(instance)
+		scene.AddEntity(entity)
+	}
+	return nil
+}
+
 // loadUnloadedEntities proceeds to load any unloaded entity
 func (scene *Scene) loadUnloadedEntities() {
 	unloaded := []IEntity{}
@@ -397,7 +635,7 @@ func (scene *Scene) loadUnloadedEntities() {
 			for _, component := range entity.GetComponents() {
 				if component.GetActive() {
 					if collider, ok := interface{}(component).(ICollider); ok {
-						scene.collisionCollection = append(scene.collisionCollection, collider)
+						scene.addToCollisionCollection(collider)
 					}
 				}
 			}
@@ -414,26 +652,18 @@ func (scene *Scene) loadUnloadedEntities() {
 // OnAfterUpdate calls executed after all DoUpdates have been executed and
 // before OnRender.
 func (scene *Scene) OnAfterUpdate() {
-	// Delete all Entities being marked to be deleted
-	if len(scene.toDeleteEntities) != 0 {
-		for _, entity := range scene.toDeleteEntities {
-			if _, i := scene.getEntity(entity.GetID()); i != -1 {
-				scene.entities = append(scene.entities[:i], scene.entities[i+1:]...)
-			}
-			if index, ok := scene.getIndexInLoadedEntity(entity); ok {
-				scene.loadedEntities = append(scene.loadedEntities[:index], scene.loadedEntities[index+1:]...)
-			}
-			if index, ok := scene.getIndexInUnloadedEntity(entity); ok {
-				scene.unloadedEntities = append(scene.unloadedEntities[:index], scene.unloadedEntities[index+1:]...)
-			}
-			if ilayer, index, ok := scene.getIndexInLayer(entity); ok {
-				scene.layers[ilayer] = append(scene.layers[ilayer][:index], scene.layers[ilayer][index+1:]...)
-			}
+	// Delete all Entities being marked to be deleted, including entities
+	// marked while other entities are destroyed.
+	for len(scene.toDeleteEntities) != 0 {
+		toDelete := scene.toDeleteEntities
+		scene.toDeleteEntities = []IEntity{}
+		for _, entity := range toDelete {
+			scene.detachEntity(entity)
 			entity.DoUnLoad()
 			entity.DoDestroy()
 		}
-		scene.toDeleteEntities = []IEntity{}
 	}
+	scene.removeEntities()
 }
 
 // OnRender calls all Entities OnRender methods. It call active entities using
@@ -466,9 +696,14 @@ func (scene *Scene) OnStart() {
 }
 
 // OnUpdate calls all Entities OnUpdate methods. It does not use layers struct,
-// but loadEntities struct. It calls to test collision in all entities active
-// in the scene.
+// but loadEntities struct. Entity transforms are saved first, so they can be
+// interpolated when rendering. It calls to test collision in all entities
+// active in the scene, and it updates the scene camera at the end.
 func (scene *Scene) OnUpdate() {
+	// Save all transforms to be interpolated when rendering.
+	for _, entity := range scene.loadedEntities {
+		entity.GetTransform().SaveStep()
+	}
 	// First check collisions in the scene.
 	for _, entity := range scene.loadedEntities {
 		if entity.GetActive() {
@@ -476,6 +711,268 @@ func (scene *Scene) OnUpdate() {
 		}
 	}
 	scene.checkCollisions()
+	scene.camera.OnUpdate()
+}
+
+// triggerContact triggers collision enter delegate if given colliders were
+// not in contact in the previous frame, or collision stay delegate if they
+// were. Contact is added to the given contacts for the running frame.
+func (scene *Scene) triggerContact(one ICollider, two ICollider, contact *Contact, contacts map[contactKey]*contactPair) {
+	key := newContactKey(one, two)
+	delegate := GetDelegateManager().GetCollisionEnterDelegate()
+	if _, ok := scene.contacts[key]; ok {
+		delegate = GetDelegateManager().GetCollisionStayDelegate()
+	}
+	contacts[key] = &contactPair{one: one, two: two, contact: contact}
+	entityOne, entityTwo := one.GetEntity(), two.GetEntity()
+	GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityOne, entityTwo}, true, entityOne, entityTwo, contact)
+}
+
+// triggerContactExit triggers collision exit delegate for all colliders in
+// contact in the previous frame that are not in the given contacts. Given
+// contacts become the contacts for the next frame.
+func (scene *Scene) triggerContactExit(contacts map[contactKey]*contactPair) {
+	exits := []*contactPair{}
+	for key, pair := range scene.contacts {
+		if _, ok := contacts[key]; !ok {
+			exits = append(exits, pair)
+		}
+	}
+	// Sort exits, so delegates are triggered always in the same order.
+	sort.Slice(exits, func(i, j int) bool {
+		if exits[i].one.GetID() != exits[j].one.GetID() {
+			return exits[i].one.GetID() < exits[j].one.GetID()
+		}
+		return exits[i].two.GetID() < exits[j].two.GetID()
+	})
+	scene.contacts = contacts
+	delegate := GetDelegateManager().GetCollisionExitDelegate()
+	for _, pair := range exits {
+		entityOne, entityTwo := pair.one.GetEntity(), pair.two.GetEntity()
+		GetDelegateManager().TriggerDelegateFor(delegate, []IEntity{entityOne, entityTwo}, true, entityOne, entityTwo, pair.contact)
+	}
+}
+
+// isQueryStale returns if broadphase used by scene queries has to be
+// updated, because colliders changed or any transform was changed after it
+// was updated. Transforms updated in place are only seen after the next
+// update step.
+func (scene *Scene) isQueryStale() bool {
+	return scene.queryColliders == nil || scene.queryVersion != transformVersion
+}
+
+// getQueryCandidates returns colliders selected by the given filter that
+// could overlap the given area. It uses the broadphase from the last
+// collision check, broadphase is updated with current colliders if there was
+// not any check after colliders were added, if collisions are not checked or
+// if any transform changed after the last check.
+func (scene *Scene) getQueryCandidates(area *Rect, filter *QueryFilter) []ICollider {
+	if scene.isQueryStale() {
+		colliders := make([]ICollider, len(scene.collisionCollection))
+		copy(colliders, scene.collisionCollection)
+		rects := make([]*Rect, len(colliders))
+		for i, collider := range colliders {
+			rects[i] = collider.GetCollisionShape().GetBounds()
+		}
+		scene.broadphase.GetPairs(rects)
+		scene.queryColliders = colliders
+		scene.queryVersion = transformVersion
+	}
+	result := []ICollider{}
+	for _, i := range scene.broadphase.Query(area) {
+		collider := scene.queryColliders[i]
+		if filter.Match(collider) && scene.inCollisionCollection(collider.GetEntity()) {
+			result = append(result, collider)
+		}
+	}
+	return result
+}
+
+// QueryCircle returns all colliders selected by the given filter that
+// overlap the given circle.
+func (scene *Scene) QueryCircle(center *Vector, radius float64, filter *QueryFilter) []*QueryHit {
+	return scene.queryShape(NewCircleShape(center, radius), filter)
+}
+
+// QueryEntities returns all entities in the scene matching the given
+// component query. Results are cached, and cached results are updated when
+// entities are added or removed, or entity components change. Entities
+// returned are not changed by any later update.
+func (scene *Scene) QueryEntities(query *ComponentQuery) []IEntity {
+	key := query.GetKey()
+	cache, ok := scene.queries[key]
+	if !ok {
+		cache = newQueryCache(query, scene.entities)
+		scene.queries[key] = cache
+	}
+	return cache.entities
+}
+
+// QueryPoint returns all colliders selected by the given filter that contain
+// the given point.
+func (scene *Scene) QueryPoint(point *Vector, filter *QueryFilter) []*QueryHit {
+	result := []*QueryHit{}
+	for _, collider := range scene.getQueryCandidates(&Rect{X: point.X, Y: point.Y}, filter) {
+		shape := collider.GetCollisionShape()
+		if shape.Contains(point) {
+			center := shape.GetCenter()
+			normal := normalize(NewVector(center.X-point.X, center.Y-point.Y))
+			if normal == nil {
+				normal = NewVector(0, 0)
+			}
+			result = append(result, NewQueryHit(collider, NewVector(point.X, point.Y), normal, math.Hypot(center.X-point.X, center.Y-point.Y)))
+		}
+	}
+	return sortHits(result)
+}
+
+// QueryRect returns all colliders selected by the given filter that overlap
+// the given rectangle.
+func (scene *Scene) QueryRect(rect *Rect, filter *QueryFilter) []*QueryHit {
+	return scene.queryShape(NewBoxShape(rect, 0), filter)
+}
+
+// queryShape returns all colliders selected by the given filter that overlap
+// the given shape, sorted by distance to the shape center.
+func (scene *Scene) queryShape(query ICollisionShape, filter *QueryFilter) []*QueryHit {
+	result := []*QueryHit{}
+	for _, collider := range scene.getQueryCandidates(query.GetBounds(), filter) {
+		if hit, ok := overlapShape(query, collider, collider.GetCollisionShape()); ok {
+			result = append(result, hit)
+		}
+	}
+	return sortHits(result)
+}
+
+// Raycast returns the first collider selected by the given filter hit by the
+// ray with the given origin and direction, up to the given distance. It
+// returns nil if the ray does not hit any collider.
+func (scene *Scene) Raycast(origin *Vector, direction *Vector, distance float64, filter *QueryFilter) *QueryHit {
+	if hits := scene.RaycastAll(origin, direction, distance, filter); len(hits) != 0 {
+		return hits[0]
+	}
+	return nil
+}
+
+// RaycastAll returns all colliders selected by the given filter hit by the
+// ray with the given origin and direction, up to the given distance. Hits
+// are sorted by distance to the origin.
+func (scene *Scene) RaycastAll(origin *Vector, direction *Vector, distance float64, filter *QueryFilter) []*QueryHit {
+	result := []*QueryHit{}
+	direction = normalize(direction)
+	if direction == nil || distance < 0 {
+		return result
+	}
+	for _, collider := range scene.getQueryCandidates(getRayBounds(origin, direction, distance), filter) {
+		if hitDistance, normal, ok := collider.GetCollisionShape().Raycast(origin, direction, distance); ok {
+			point := NewVector(origin.X+direction.X*hitDistance, origin.Y+direction.Y*hitDistance)
+			result = append(result, NewQueryHit(collider, point, normal, hitDistance))
+		}
+	}
+	return sortHits(result)
+}
+
+// RefreshQueries updates all cached component queries for the given entity,
+// and broadphase used by scene queries is updated in the next query. It is
+// called every time entity components or collider shapes change.
+func (scene *Scene) RefreshQueries(entity IEntity) {
+	if _, i := scene.getEntity(entity.GetID()); i == -1 {
+		return
+	}
+	scene.queryColliders = nil
+	for _, cache := range scene.queries {
+		cache.update(entity)
+	}
+}
+
+// RemoveEntity removes the entity and all its children from the scene
+// without destroying them, so they can be added to any scene again later.
+// Components are not unloaded and they keep all delegate registrations, but
+// delegates are not triggered for entities not being in any scene. Entity is
+// removed in OnAfterUpdate method.
+func (scene *Scene) RemoveEntity(entity IEntity) bool {
+	Logger.Trace().Str("scene", scene.GetName()).Str("Entity", entity.GetName()).Msg("remove entity")
+	if _, i := scene.getEntity(entity.GetID()); i == -1 {
+		return false
+	}
+	toRemove := []IEntity{entity}
+	for i := 0; i < len(toRemove); i++ {
+		toRemove = append(toRemove, toRemove[i].GetChildren()...)
+	}
+	for _, traverse := range toRemove {
+		// Remove colliders from the collision collection, so there is not
+		// more checks for removed entities.
+		scene.removeFromCollisionCollection(traverse)
+	}
+	scene.toRemoveEntities = append(scene.toRemoveEntities, toRemove...)
+	return true
+}
+
+// removeEntities removes all entities marked to be removed from the scene.
+func (scene *Scene) removeEntities() {
+	for _, entity := range scene.toRemoveEntities {
+		scene.detachEntity(entity)
+		if entity.GetScene() == IScene(scene) {
+			entity.SetScene(nil)
+		}
+	}
+	scene.toRemoveEntities = []IEntity{}
+}
+
+// removeFromCollisionCollection removes all colliders that belong to the
+// given entity from the collision collection.
+func (scene *Scene) removeFromCollisionCollection(entity IEntity) {
+	if !scene.collisionIDs[entity.GetID()] {
+		return
+	}
+	colliders := []ICollider{}
+	for _, collider := range scene.collisionCollection {
+		if collider.GetEntity().GetID() != entity.GetID() {
+			colliders = append(colliders, collider)
+		}
+	}
+	scene.collisionCollection = colliders
+	delete(scene.collisionIDs, entity.GetID())
+	scene.queryColliders = nil
+}
+
+// SaveScene writes the scene to the given writer in JSON format. It saves
+// scene name, tag, collision settings, gravity, assets and all entities with
+// their children, components and component delegate links.
+func (scene *Scene) SaveScene(writer io.Writer) error {
+	Logger.Trace().Str("scene", scene.GetName()).Msg("SaveScene")
+	toSave := &SceneToMarshal{
+		Name:            scene.GetName(),
+		Tag:             scene.GetTag(),
+		CollisionCheck:  scene.GetCollisionCheck(),
+		CollisionMode:   scene.GetCollisionMode(),
+		CollisionMatrix: scene.GetCollisionMatrix(),
+		Gravity:         scene.GetGravity(),
+		Assets:          scene.GetAssets(),
+		Entities:        []*EntityToMarshal{},
+	}
+	for _, entity := range scene.GetEntities() {
+		if entity.GetParent() == nil {
+			toSave.Entities = append(toSave.Entities, entity.DoDump())
+		}
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(toSave); err != nil {
+		Logger.Error().Err(err).Str("scene", scene.GetName()).Msg("SaveScene error")
+		return err
+	}
+	return nil
+}
+
+// SetBroadphase sets the broadphase used to check collisions.
+func (scene *Scene) SetBroadphase(broadphase IBroadphase) {
+	scene.broadphase = broadphase
+}
+
+// SetCamera sets the scene camera.
+func (scene *Scene) SetCamera(camera ICamera) {
+	scene.camera = camera
 }
 
 // SetCollisionCheck sets if the scene has to check collisions.
@@ -483,9 +980,22 @@ func (scene *Scene) SetCollisionCheck(check bool) {
 	scene.collisionCheck = check
 }
 
+// SetCollisionMatrix sets the collision matrix with all named collision
+// layers.
+func (scene *Scene) SetCollisionMatrix(matrix *CollisionMatrix) {
+	scene.collisionMatrix = matrix
+}
+
 // SetCollisionMode sets the scene collision mode used to detect collisions.
 func (scene *Scene) SetCollisionMode(mode int) {
 	scene.collisionMode = mode
+	scene.queryColliders = nil
+}
+
+// SetGravity sets the gravity acceleration applied to all dynamic rigid
+// bodies in units per second squared.
+func (scene *Scene) SetGravity(gravity *Vector) {
+	scene.gravity = gravity
 }
 
 // SetSceneCode sets the scene code.
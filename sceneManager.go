@@ -5,26 +5,72 @@ import (
 	"strconv"
 )
 
+// Scene stack block constants. Scenes pushed to the scene stack block the
+// scenes below them from being updated or rendered.
+const (
+	// Scenes below are updated and rendered.
+	SceneBlockNone int = 0
+	// Scenes below are not updated.
+	SceneBlockUpdate int = 1
+	// Scenes below are not rendered.
+	SceneBlockRender int = 2
+	// Scenes below are neither updated nor rendered.
+	SceneBlockAll int = SceneBlockUpdate | SceneBlockRender
+)
+
+// Scene event actions.
+const (
+	sceneEventSet int = iota
+	sceneEventPush
+	sceneEventPop
+)
+
 // SceneEventData is the event structure used by the scene manager.
 type SceneEventData struct {
 	*Object
-	scene IScene
-	index int
+	action     int
+	scene      IScene
+	index      int
+	block      int
+	transition ITransition
 }
 
-// NewSceneEvent creates a new scene event instance.
-func NewSceneEvent(scene IScene, index int) *Event {
-	Logger.Trace().Str("scene-event", scene.GetName()).Str("index", strconv.Itoa(index)).Msg("new scene-event")
+// NewSceneEvent creates a new scene event instance. Optional transition is
+// used to change to the new scene.
+func NewSceneEvent(scene IScene, index int, transitions ...ITransition) *Event {
+	return newSceneEvent(sceneEventSet, scene, index, SceneBlockNone, getTransition(transitions))
+}
+
+// newSceneEvent creates a new scene event instance for the given action. Pop
+// events do not have any scene.
+func newSceneEvent(action int, scene IScene, index int, block int, transition ITransition) *Event {
+	name := ""
+	if scene != nil {
+		name = scene.GetName()
+	}
+	Logger.Trace().Str("scene-event", name).Str("index", strconv.Itoa(index)).Msg("new scene-event")
 	return &Event{
 		Object: NewObject("scene-event"),
 		data: &SceneEventData{
-			Object: NewObject(scene.GetName()),
-			scene:  scene,
-			index:  index,
+			Object:     NewObject(name),
+			action:     action,
+			scene:      scene,
+			index:      index,
+			block:      block,
+			transition: transition,
 		},
 	}
 }
 
+// getTransition returns the first transition given, or nil if no transition
+// is given.
+func getTransition(transitions []ITransition) ITransition {
+	if len(transitions) > 0 {
+		return transitions[0]
+	}
+	return nil
+}
+
 // ISceneManager represents the interface for the scene handler.
 type ISceneManager interface {
 	IObject
@@ -36,36 +82,57 @@ type ISceneManager interface {
 	GetActiveScene() IScene
 	GetScene(string) IScene
 	GetSceneByName(string) IScene
+	GetSceneStack() []IScene
 	GetScenes() []IScene
 	GetStandbyScene() IScene
+	GetTransition() ITransition
+	IsSceneRendered(IScene) bool
+	IsSceneUpdated(IScene) bool
 	OnAfterUpdate()
 	OnRender()
 	OnEnable()
 	OnStart()
 	OnUpdate()
+	PopScene(...ITransition) bool
+	PushScene(IScene, int, ...ITransition) bool
 	RestartScene() bool
 	SetActiveFirstScene() IScene
 	SetActiveLastScene() IScene
 	SetActiveNextScene() IScene
 	SetActivePrevScene() IScene
-	SetActiveScene(IScene) bool
-	SwapBack() bool
-	SwapFromSceneTo(IScene) bool
+	SetActiveScene(IScene, ...ITransition) bool
+	SwapBack(...ITransition) bool
+	SwapFromSceneTo(IScene, ...ITransition) bool
 }
 
-// ActiveScene represents the active scene.
+// ActiveScene represents the active scene. Block flags are used for scenes
+// pushed to the scene stack.
 type ActiveScene struct {
 	scene IScene
 	index int
+	block int
 }
 
 // SceneManager is the default implementation for the scene handler interface.
+// Scene changes can use a transition, scenes being left are kept alive and
+// rendered in a texture, and the active scene is rendered in another texture
+// while the transition is running. Scenes being left are destroyed, unloaded
+// or swapped when the transition ends.
+// Scenes can be pushed on top of the active scene, which becomes the pushed
+// scene, and scenes below are still updated and rendered unless any scene
+// above blocks them.
 type SceneManager struct {
 	*Object
-	scenes       []IScene
-	activeScene  *ActiveScene
-	standByScene *ActiveScene
-	eventPoolID  string
+	scenes           []IScene
+	activeScene      *ActiveScene
+	standByScene     *ActiveScene
+	stack            []*ActiveScene
+	eventPoolID      string
+	transition       ITransition
+	transitionFrom   ITexture
+	transitionTo     ITexture
+	transitionScenes []IScene
+	transitionLeave  []func()
 }
 
 var _ ISceneManager = (*SceneManager)(nil)
@@ -74,10 +141,16 @@ var _ ISceneManager = (*SceneManager)(nil)
 func NewSceneManager(name string) *SceneManager {
 	Logger.Trace().Str("scene-manager", name).Msg("new scene handler")
 	return &SceneManager{
-		Object:       NewObject(name),
-		scenes:       []IScene{},
-		activeScene:  &ActiveScene{},
-		standByScene: &ActiveScene{},
+		Object:           NewObject(name),
+		scenes:           []IScene{},
+		activeScene:      &ActiveScene{},
+		standByScene:     &ActiveScene{},
+		stack:            []*ActiveScene{},
+		transition:       nil,
+		transitionFrom:   nil,
+		transitionTo:     nil,
+		transitionScenes: nil,
+		transitionLeave:  nil,
 	}
 }
 
@@ -103,30 +176,51 @@ func (h *SceneManager) DeleteScene(name string) bool {
 
 // DoFrameEnd calls all methods to run at the end of a tick frame.
 func (h *SceneManager) DoFrameEnd() {
-	if activeScene := h.GetActiveScene(); activeScene != nil {
-		activeScene.DoFrameEnd()
+	for _, scene := range h.getUpdatedScenes() {
+		scene.DoFrameEnd()
 	}
 	// Read the event pool for any scene change.
 	if pool := GetEventManager().GetPool(h.eventPoolID); pool != nil {
 		if event, _ := pool.Pop(); event != nil {
 			data := event.GetData().(*SceneEventData)
-			scene := data.scene
-			index := data.index
-			h.setActiveScene(scene, index)
+			switch data.action {
+			case sceneEventPush:
+				h.pushScene(data.scene, data.index, data.block, data.transition)
+			case sceneEventPop:
+				h.popScene(data.transition)
+			default:
+				h.setActiveScene(data.scene, data.index, data.transition)
+			}
 		}
 	}
 }
 
 // DoFrameStart calls all methods to run at the start of a tick frame.
 func (h *SceneManager) DoFrameStart() {
-	if activeScene := h.GetActiveScene(); activeScene != nil {
-		activeScene.DoFrameStart()
+	for _, scene := range h.getUpdatedScenes() {
+		scene.DoFrameStart()
 	}
 }
 
-// DoInit initializes all scene manager resources.
+// DoInit initializes all scene manager resources. Transition textures are
+// created again for the new renderer.
 func (h *SceneManager) DoInit() {
 	Logger.Trace().Str("scene-manager", h.GetName()).Msg("DoInit")
+	h.endTransition()
+	h.transitionFrom = nil
+	h.transitionTo = nil
+}
+
+// endTransition ends the transition running. Scenes being left are not
+// rendered anymore and they are destroyed, unloaded or swapped.
+func (h *SceneManager) endTransition() {
+	leave := h.transitionLeave
+	h.transition = nil
+	h.transitionScenes = nil
+	h.transitionLeave = nil
+	for _, callback := range leave {
+		callback()
+	}
 }
 
 // GetActiveScene returns the scene handler active scene at that time.
@@ -170,6 +264,52 @@ func (h *SceneManager) GetSceneByName(name string) IScene {
 	return nil
 }
 
+// GetSceneStack returns all scenes in the scene stack, from the bottom to
+// the top. Active scene is the scene at the top.
+func (h *SceneManager) GetSceneStack() []IScene {
+	result := []IScene{}
+	for _, activeScene := range h.getSceneStack() {
+		result = append(result, activeScene.scene)
+	}
+	return result
+}
+
+// getSceneStack returns the scene stack, from the bottom to the top,
+// including the active scene.
+func (h *SceneManager) getSceneStack() []*ActiveScene {
+	if h.activeScene.scene == nil {
+		return []*ActiveScene{}
+	}
+	return append(append([]*ActiveScene{}, h.stack...), h.activeScene)
+}
+
+// getScenesNotBlocked returns scenes in the scene stack, from the bottom to
+// the top, which are not blocked by any scene above for the given block.
+func (h *SceneManager) getScenesNotBlocked(block int) []IScene {
+	stack := h.getSceneStack()
+	i := len(stack) - 1
+	for i > 0 && stack[i].block&block == 0 {
+		i--
+	}
+	result := []IScene{}
+	for ; i >= 0 && i < len(stack); i++ {
+		result = append(result, stack[i].scene)
+	}
+	return result
+}
+
+// getRenderedScenes returns all scenes being rendered, from the bottom to the
+// top.
+func (h *SceneManager) getRenderedScenes() []IScene {
+	return h.getScenesNotBlocked(SceneBlockRender)
+}
+
+// getUpdatedScenes returns all scenes being updated, from the bottom to the
+// top.
+func (h *SceneManager) getUpdatedScenes() []IScene {
+	return h.getScenesNotBlocked(SceneBlockUpdate)
+}
+
 // GetScenes returns all scenes in the scene handler.
 func (h *SceneManager) GetScenes() []IScene {
 	return h.scenes
@@ -180,19 +320,80 @@ func (h *SceneManager) GetStandbyScene() IScene {
 	return h.standByScene.scene
 }
 
+// GetTransition returns the transition running, nil if there is not any
+// transition running.
+func (h *SceneManager) GetTransition() ITransition {
+	return h.transition
+}
+
+// IsSceneRendered returns if the given scene is rendered, because it is in
+// the scene stack and it is not blocked by any scene above.
+func (h *SceneManager) IsSceneRendered(scene IScene) bool {
+	return containsScene(h.getRenderedScenes(), scene)
+}
+
+// IsSceneUpdated returns if the given scene is updated, because it is in the
+// scene stack and it is not blocked by any scene above.
+func (h *SceneManager) IsSceneUpdated(scene IScene) bool {
+	return containsScene(h.getUpdatedScenes(), scene)
+}
+
+// containsScene returns if the given scene is in the given list of scenes.
+func containsScene(scenes []IScene, scene IScene) bool {
+	if scene == nil {
+		return false
+	}
+	for _, traverse := range scenes {
+		if traverse.GetID() == scene.GetID() {
+			return true
+		}
+	}
+	return false
+}
+
+// leaveScene calls the given function for a scene being left. If there is a
+// transition running, it is called when the transition ends, so the scene is
+// kept alive and rendered until then. If the scene being left is the scene
+// being entered, it is called right away and the transition keeps the last
+// frame rendered for scenes being left.
+func (h *SceneManager) leaveScene(scene IScene, entered IScene, leave func()) {
+	if h.transition != nil && scene != entered {
+		h.transitionLeave = append(h.transitionLeave, leave)
+		return
+	}
+	if containsScene(h.transitionScenes, scene) {
+		h.transitionScenes = nil
+	}
+	leave()
+}
+
 // OnAfterUpdate calls all scene OnAfterUpdate, which should run after DoUpdate
 // runs and before DoRender.
 func (h *SceneManager) OnAfterUpdate() {
-	if activeScene := h.GetActiveScene(); activeScene != nil {
-		activeScene.OnAfterUpdate()
+	for _, scene := range h.getUpdatedScenes() {
+		scene.OnAfterUpdate()
 	}
 }
 
-// OnRender calls all scene OnRender methods.
+// OnRender calls all scene OnRender methods, from the bottom of the scene
+// stack to the top. If there is a transition running, scenes being left and
+// scenes being entered are rendered in their transition textures and the
+// transition renders the display.
 func (h *SceneManager) OnRender() {
-	if activeScene := h.GetActiveScene(); activeScene != nil {
-		activeScene.OnRender()
+	if h.GetActiveScene() == nil {
+		return
+	}
+	if h.transition == nil {
+		for _, scene := range h.getRenderedScenes() {
+			scene.OnRender()
+		}
+		return
 	}
+	if h.transitionScenes != nil {
+		h.renderToTexture(h.transitionScenes, h.transitionFrom)
+	}
+	h.renderToTexture(h.getRenderedScenes(), h.transitionTo)
+	h.transition.Render(GetRenderer(), h.transitionFrom, h.transitionTo)
 }
 
 // OnEnable calls all scene OnEnable methods.
@@ -206,18 +407,99 @@ func (h *SceneManager) OnEnable() {
 func (h *SceneManager) OnStart() {
 	Logger.Trace().Str("scene-manager", h.GetName()).Msg("OnStart")
 	var err error
-	// Create event pool in event manager.
+	// Create event pool in event manager. Pool is reused when the engine is
+	// started again.
+	if h.eventPoolID != "" {
+		return
+	}
 	if h.eventPoolID, err = GetEventManager().CreatePool("scene-manager-pool"); err != nil {
-		Logger.Error().Err(err)
-		panic(err)
+		ReportError(err)
 	}
 }
 
-// OnUpdate calls all scene OnUpdate methods.
+// OnUpdate calls all scene OnUpdate methods, from the bottom of the scene
+// stack to the top, and it moves forward the transition running.
 func (h *SceneManager) OnUpdate() {
-	if activeScene := h.GetActiveScene(); activeScene != nil {
-		activeScene.OnUpdate()
+	for _, scene := range h.getUpdatedScenes() {
+		scene.OnUpdate()
+	}
+	if h.transition != nil && h.transition.Update(GetDeltaTime()) {
+		h.endTransition()
+	}
+}
+
+// PopScene removes the active scene from the top of the scene stack, and the
+// scene below becomes the active scene. Scene is destroyed at the end of the
+// frame. Optional transition is used to change to the scene below.
+func (h *SceneManager) PopScene(transitions ...ITransition) bool {
+	if len(h.stack) == 0 {
+		return false
+	}
+	if pool := GetEventManager().GetPool(h.eventPoolID); pool != nil {
+		pool.Add(newSceneEvent(sceneEventPop, nil, -1, SceneBlockNone, getTransition(transitions)))
 	}
+	return true
+}
+
+// popScene destroys the active scene and the scene at the top of the scene
+// stack becomes the active scene.
+func (h *SceneManager) popScene(transition ITransition) {
+	if len(h.stack) == 0 {
+		return
+	}
+	h.startTransition(transition)
+	top := h.stack[len(h.stack)-1]
+	h.leaveScene(h.activeScene.scene, top.scene, h.activeScene.scene.DoDestroy)
+	h.stack = h.stack[:len(h.stack)-1]
+	h.activeScene.scene = top.scene
+	h.activeScene.index = top.index
+	h.activeScene.block = top.block
+}
+
+// PushScene pushes the given scene on top of the scene stack, and it becomes
+// the active scene. Block flags, SceneBlockUpdate and SceneBlockRender, set if
+// scenes below are updated and rendered. Scene is loaded at the end of the
+// frame. Optional transition is used to change to the new scene.
+func (h *SceneManager) PushScene(scene IScene, block int, transitions ...ITransition) bool {
+	_, index := h.getScene(scene.GetID())
+	if index == -1 || containsScene(h.GetSceneStack(), scene) {
+		return false
+	}
+	if pool := GetEventManager().GetPool(h.eventPoolID); pool != nil {
+		pool.Add(newSceneEvent(sceneEventPush, scene, index, block, getTransition(transitions)))
+	}
+	return true
+}
+
+// pushScene moves the active scene to the scene stack and it loads the given
+// scene as the active scene. Scene is not pushed if it is already in the
+// scene stack.
+func (h *SceneManager) pushScene(scene IScene, index int, block int, transition ITransition) {
+	if containsScene(h.GetSceneStack(), scene) {
+		return
+	}
+	h.startTransition(transition)
+	if h.activeScene.scene != nil {
+		h.stack = append(h.stack, &ActiveScene{scene: h.activeScene.scene, index: h.activeScene.index, block: h.activeScene.block})
+	}
+	h.activeScene.scene = scene
+	h.activeScene.index = index
+	h.activeScene.block = block
+	h.activeScene.scene.GetSceneCode()(GetEngine(), h.activeScene.scene)
+	h.activeScene.scene.DoLoad()
+	h.activeScene.scene.OnStart()
+}
+
+// renderToTexture renders the given scenes in the given texture.
+func (h *SceneManager) renderToTexture(scenes []IScene, texture ITexture) {
+	renderer := GetRenderer()
+	renderer.SetRenderTarget(texture)
+	renderer.SetDrawColor(255, 255, 255, 255)
+	renderer.Clear()
+	for _, scene := range scenes {
+		scene.OnRender()
+	}
+	renderer.SetRenderTarget(nil)
 }
 
 // RestartScene restart the active scene.
@@ -233,7 +515,7 @@ func (h *SceneManager) SetActiveFirstScene() IScene {
 	if len(h.GetScenes()) > 0 {
 
 		scene := h.GetScenes()[0]
-		h.setActiveScene(scene, 0)
+		h.setActiveScene(scene, 0, nil)
 		return scene
 	}
 	return nil
@@ -244,7 +526,7 @@ func (h *SceneManager) SetActiveLastScene() IScene {
 	length := len(h.GetScenes())
 	if length > 0 {
 		scene := h.GetScenes()[length-1]
-		h.setActiveScene(scene, length-1)
+		h.setActiveScene(scene, length-1, nil)
 		return scene
 	}
 	return nil
@@ -256,7 +538,7 @@ func (h *SceneManager) SetActiveNextScene() IScene {
 	if length > 0 && h.activeScene.scene != nil && h.activeScene.index < length-1 {
 		index := h.activeScene.index + 1
 		scene := h.GetScenes()[index]
-		h.setActiveScene(scene, index)
+		h.setActiveScene(scene, index, nil)
 		return scene
 	}
 	return nil
@@ -268,15 +550,18 @@ func (h *SceneManager) SetActivePrevScene() IScene {
 	if length > 0 && h.activeScene.scene != nil && h.activeScene.index > 0 {
 		index := h.activeScene.index - 1
 		scene := h.GetScenes()[index]
-		h.setActiveScene(scene, index)
+		h.setActiveScene(scene, index, nil)
 		return scene
 	}
 	return nil
 }
 
 // setActiveScene set the given scene and index and active one. It proceeds
-// to unload previous scene active and load new one.
-func (h *SceneManager) setActiveScene(scene IScene, index int) {
+// to unload previous scene active and load new one, and all scenes in the
+// scene stack are destroyed. If any transition is given, it starts before the
+// previous scene is unloaded, and scenes are destroyed when it ends.
+func (h *SceneManager) setActiveScene(scene IScene, index int, transition ITransition) {
+	h.startTransition(transition)
 	fmt.Println("Audit Before UnLoading")
 	fmt.Println("----------------------")
 	GetDelegateManager().AuditDelegates()
@@ -284,14 +569,19 @@ func (h *SceneManager) setActiveScene(scene IScene, index int) {
 	if h.activeScene.scene != nil {
 		h.activeScene.scene.AuditEntities()
 		// h.activeScene.scene.DoUnLoad()
-		h.activeScene.scene.DoDestroy()
+		h.leaveScene(h.activeScene.scene, scene, h.activeScene.scene.DoDestroy)
+	}
+	for i := len(h.stack) - 1; i >= 0; i-- {
+		h.leaveScene(h.stack[i].scene, scene, h.stack[i].scene.DoDestroy)
 	}
+	h.stack = []*ActiveScene{}
 	fmt.Println("Audit After UnLoading")
 	fmt.Println("---------------------")
 	GetDelegateManager().AuditDelegates()
 	GetDelegateManager().AuditRegisters()
 	h.activeScene.scene = scene
 	h.activeScene.index = index
+	h.activeScene.block = SceneBlockNone
 	h.activeScene.scene.GetSceneCode()(GetEngine(), h.activeScene.scene)
 	h.activeScene.scene.DoLoad()
 	h.activeScene.scene.OnStart()
@@ -303,13 +593,14 @@ func (h *SceneManager) setActiveScene(scene IScene, index int) {
 	// h.activeScene.scene.DoDump()
 }
 
-// SetActiveScene sets the given scene as the active scene.
-func (h *SceneManager) SetActiveScene(scene IScene) bool {
+// SetActiveScene sets the given scene as the active scene. Optional
+// transition is used to change to the new scene.
+func (h *SceneManager) SetActiveScene(scene IScene, transitions ...ITransition) bool {
 	for index, scn := range h.GetScenes() {
 		if scn == scene {
 			// h.setActiveScene(scene, index)
 			if pool := GetEventManager().GetPool(h.eventPoolID); pool != nil {
-				pool.Add(NewSceneEvent(scene, index))
+				pool.Add(NewSceneEvent(scene, index, transitions...))
 			}
 			return true
 		}
@@ -317,17 +608,54 @@ func (h *SceneManager) SetActiveScene(scene IScene) bool {
 	return false
 }
 
+// startTransition ends any transition running, and it starts the given
+// transition if any. All scenes being rendered are rendered in the transition
+// texture for scenes being left while the transition is running.
+func (h *SceneManager) startTransition(transition ITransition) {
+	h.endTransition()
+	if transition == nil {
+		return
+	}
+	renderer := GetRenderer()
+	width, height, err := renderer.GetOutputSize()
+	if err == nil && h.transitionFrom != nil {
+		// Textures are created again if the display size has changed.
+		if _, _, w, ht, _ := h.transitionFrom.Query(); w != width || ht != height {
+			h.transitionFrom.Destroy()
+			h.transitionTo.Destroy()
+			h.transitionFrom, h.transitionTo = nil, nil
+		}
+	}
+	if err == nil && h.transitionFrom == nil {
+		if h.transitionFrom, err = renderer.CreateRenderTexture(width, height); err == nil {
+			h.transitionTo, err = renderer.CreateRenderTexture(width, height)
+		}
+	}
+	if err != nil {
+		Logger.Error().Err(err).Str("transition", transition.GetName()).Msg("start transition error")
+		h.transitionFrom, h.transitionTo = nil, nil
+		h.transition = nil
+		return
+	}
+	h.transitionScenes = h.getRenderedScenes()
+	h.renderToTexture(h.transitionScenes, h.transitionFrom)
+	transition.Start()
+	h.transition = transition
+}
+
 // SwapFromSceneTo swaps from the active scene to a new one. The former active
-// scene is moved to standby.
-func (h *SceneManager) SwapFromSceneTo(newScene IScene) bool {
+// scene is moved to standby. Optional transition is used to change to the new
+// scene.
+func (h *SceneManager) SwapFromSceneTo(newScene IScene, transitions ...ITransition) bool {
 	if scene, index := h.getScene(newScene.GetID()); scene != nil {
+		h.startTransition(getTransition(transitions))
 		fmt.Println("Audit Before Swap From")
 		fmt.Println("----------------------")
 		GetDelegateManager().AuditDelegates()
 		GetDelegateManager().AuditRegisters()
 		h.activeScene.scene.AuditEntities()
 
-		h.activeScene.scene.DoSwapFrom()
+		h.leaveScene(h.activeScene.scene, scene, h.activeScene.scene.DoSwapFrom)
 		h.standByScene.scene = h.activeScene.scene
 		h.standByScene.index = h.activeScene.index
 		h.activeScene.scene = scene
@@ -347,18 +675,20 @@ func (h *SceneManager) SwapFromSceneTo(newScene IScene) bool {
 	return false
 }
 
-// SwapBack swaps back to the standby scene.
-func (h *SceneManager) SwapBack() bool {
+// SwapBack swaps back to the standby scene. Optional transition is used to
+// change to the standby scene.
+func (h *SceneManager) SwapBack(transitions ...ITransition) bool {
 	if h.standByScene.scene == nil {
 		return false
 	}
+	h.startTransition(getTransition(transitions))
 	fmt.Println("Audit Before Swap Back")
 	fmt.Println("----------------------")
 	GetDelegateManager().AuditDelegates()
 	GetDelegateManager().AuditRegisters()
 	h.activeScene.scene.AuditEntities()
 
-	h.activeScene.scene.DoUnLoad()
+	h.leaveScene(h.activeScene.scene, h.standByScene.scene, h.activeScene.scene.DoUnLoad)
 	h.activeScene.scene = h.standByScene.scene
 	h.activeScene.index = h.standByScene.index
 	h.activeScene.scene.DoSwapBack()
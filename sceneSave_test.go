@@ -0,0 +1,147 @@
+package engosdl_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// removeIDs removes all object IDs from the given JSON data, so data for
+// different instances can be compared.
+func removeIDs(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		delete(value, "id")
+		for _, v := range value {
+			removeIDs(v)
+		}
+	case []interface{}:
+		for _, v := range value {
+			removeIDs(v)
+		}
+	}
+	return data
+}
+
+// decodeScene returns the given saved scene as generic JSON data without
+// object IDs.
+func decodeScene(t *testing.T, data []byte) interface{} {
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return removeIDs(result)
+}
+
+func TestScene_SaveLoad(t *testing.T) {
+	calls := map[string][]string{}
+	for _, name := range []string{"test-on-timer", "test-on-tween"} {
+		handlerName := name
+		engosdl.GetComponentManager().RegisterHandler(handlerName, func(component engosdl.IComponent) engosdl.TDelegateSignature {
+			return func(...interface{}) bool {
+				calls[handlerName] = append(calls[handlerName], reflect.TypeOf(component).String())
+				return true
+			}
+		})
+	}
+	red, green := sdl.Color{R: 255, A: 255}, sdl.Color{G: 255, A: 255}
+	scene := engosdl.NewScene("level", "test")
+	scene.SetCollisionMode(engosdl.ModeBox)
+	scene.SetGravity(engosdl.NewVector(0, 9.8))
+	scene.GetCollisionMatrix().AddLayer("enemies")
+	scene.GetCollisionMatrix().SetLayerCollision("enemies", "enemies", false)
+	scene.AddAssets(engosdl.NewImageAsset("player", "images/player.png"))
+	player := engosdl.NewEntity("player")
+	player.GetTransform().SetPositionXY(10, 20)
+	player.GetTransform().SetDim(engosdl.NewVector(10, 10))
+	box := components.NewBox("player/box", engosdl.NewRect(0, 0, 10, 10), red, true)
+	box.AddDelegateLink(engosdl.NewDelegateLink("", "clock", components.ComponentNameTimer, "test-on-timer"))
+	player.AddComponent(box)
+	stats := components.NewEntityStats("player/stats", 50)
+	stats.AddDelegateLink(engosdl.NewDelegateLink("engine-tween-manager/"+engosdl.TweenName, "", "", "test-on-tween"))
+	player.AddComponent(stats)
+	gun := engosdl.NewEntity("gun")
+	gun.GetTransform().SetPositionXY(5, 0)
+	gun.GetTransform().SetDim(engosdl.NewVector(4, 4))
+	gun.AddComponent(components.NewBox("gun/box", engosdl.NewRect(0, 0, 4, 4), green, false))
+	player.AddChild(gun)
+	clock := engosdl.NewEntity("clock")
+	clock.AddComponent(components.NewTimer("clock/timer", 0, 2))
+	scene.AddEntity(player)
+	scene.AddEntity(clock)
+	saved := &bytes.Buffer{}
+	if err := scene.SaveScene(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	var loadErr error
+	loaded := engosdl.NewScene("", "")
+	loaded.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		loadErr = scene.LoadScene(bytes.NewReader(saved.Bytes()))
+		return loadErr == nil
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(loaded)
+	engine.RunEngineFrames(loaded, 2)
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	if loaded.GetName() != "level" || loaded.GetTag() != "test" || loaded.GetCollisionMode() != engosdl.ModeBox || loaded.GetGravity().Y != 9.8 || len(loaded.GetAssets()) != 1 {
+		t.Errorf("error loading scene settings")
+	}
+	if matrix := loaded.GetCollisionMatrix(); matrix.GetLayer("enemies") != 2 || matrix.GetLayerCollision("enemies", "enemies") || !matrix.GetLayerCollision("enemies", engosdl.CollisionLayerDefault) {
+		t.Errorf("error loading collision matrix")
+	}
+	loadedGun := loaded.GetEntityByName("gun")
+	if loadedGun == nil || loadedGun.GetParent() == nil || loadedGun.GetParent().GetName() != "player" {
+		t.Fatalf("error loading entity hierarchy")
+	}
+	if x, y := loadedGun.GetTransform().GetWorldPosition().Get(); x != 15 || y != 20 {
+		t.Errorf("error loading child position\nexp: %f %f\ngot: %f %f\n", 15.0, 20.0, x, y)
+	}
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	drawCalls := []*engosdl.DrawCall{}
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect || drawCall.Op == engosdl.DrawRect {
+			drawCalls = append(drawCalls, drawCall)
+		}
+	}
+	if len(drawCalls) != 2 || drawCalls[0].Op != engosdl.DrawFillRect || drawCalls[0].Color != red || drawCalls[1].Op != engosdl.DrawRect || drawCalls[1].Color != green {
+		t.Errorf("error rendering loaded scene\nexp: %d\ngot: %d\n", 2, len(drawCalls))
+	}
+
+	// Delegate links are registered with the component in the entity.
+	engosdl.GetTweenManager().AddTween(engosdl.NewTweenWait("wait", 0))
+	engine.DoRunFrames(2)
+	if exp := []string{"*components.Box", "*components.Box"}; !reflect.DeepEqual(exp, calls["test-on-timer"]) {
+		t.Errorf("error linking component delegate\nexp: %v\ngot: %v\n", exp, calls["test-on-timer"])
+	}
+	if exp := []string{"*components.EntityStats"}; !reflect.DeepEqual(exp, calls["test-on-tween"]) {
+		t.Errorf("error linking delegate\nexp: %v\ngot: %v\n", exp, calls["test-on-tween"])
+	}
+
+	// Scene saved again is the same scene.
+	resaved := &bytes.Buffer{}
+	if err := loaded.SaveScene(resaved); err != nil {
+		t.Fatal(err)
+	}
+	if exp, got := decodeScene(t, saved.Bytes()), decodeScene(t, resaved.Bytes()); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error saving loaded scene\nexp: %s\ngot: %s\n", saved.String(), resaved.String())
+	}
+
+	// Scene is not changed if there is any error.
+	invalid := engosdl.NewScene("invalid", "test")
+	if err := invalid.LoadScene(strings.NewReader(`{"name": "level", "entities": [`)); err == nil {
+		t.Errorf("error loading invalid JSON")
+	}
+	data := `{"name": "level", "entities": [{"entity-data": {}, "components": [{"component-type": "unknown"}]}]}`
+	if err := invalid.LoadScene(strings.NewReader(data)); err == nil || invalid.GetName() != "invalid" {
+		t.Errorf("error loading unknown component")
+	}
+}
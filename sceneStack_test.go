@@ -0,0 +1,110 @@
+package engosdl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// getFillColors returns colors for all filled rectangles in the last frame.
+func getFillColors(renderer *engosdl.HeadlessRenderer) []sdl.Color {
+	result := []sdl.Color{}
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect {
+			result = append(result, drawCall.Color)
+		}
+	}
+	return result
+}
+
+func TestSceneManager_SceneStack(t *testing.T) {
+	red, green, blue := sdl.Color{R: 255, A: 255}, sdl.Color{G: 255, A: 255}, sdl.Color{B: 255, A: 255}
+	var gameUpdates, hudUpdates int
+	game := newBoxScene("game", red, func() { gameUpdates++ })
+	hud := newBoxScene("hud", green, func() { hudUpdates++ })
+	pause := newBoxScene("pause", blue, nil)
+	engine := newTestEngine(t)
+	engine.AddScene(game)
+	engine.AddScene(hud)
+	engine.AddScene(pause)
+	engine.RunEngineFrames(game, 1)
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	sceneManager := engosdl.GetSceneManager()
+	if !sceneManager.PushScene(hud, engosdl.SceneBlockNone) {
+		t.Fatalf("error pushing scene")
+	}
+	if sceneManager.PushScene(game, engosdl.SceneBlockNone) {
+		t.Errorf("error pushing scene already in the scene stack")
+	}
+
+	// Scene pushed without blocking: all scenes are updated and rendered.
+	engine.DoRunFrames(1)
+	gameUpdates, hudUpdates = 0, 0
+	engine.DoRunFrames(2)
+	if exp := []engosdl.IScene{game, hud}; !reflect.DeepEqual(exp, sceneManager.GetSceneStack()) || sceneManager.GetActiveScene() != hud {
+		t.Fatalf("error pushing scene\nexp: %v\ngot: %v\n", exp, sceneManager.GetSceneStack())
+	}
+	if gameUpdates != 2 || hudUpdates != 2 {
+		t.Errorf("error updating scene stack\nexp: %d %d\ngot: %d %d\n", 2, 2, gameUpdates, hudUpdates)
+	}
+	if exp, got := []sdl.Color{red, green}, getFillColors(renderer); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error rendering scene stack\nexp: %v\ngot: %v\n", exp, got)
+	}
+
+	// Scene pushed blocking update: scenes below are only rendered.
+	sceneManager.PushScene(pause, engosdl.SceneBlockUpdate)
+	engine.DoRunFrames(1)
+	gameUpdates, hudUpdates = 0, 0
+	engine.DoRunFrames(2)
+	if gameUpdates != 0 || hudUpdates != 0 {
+		t.Errorf("error blocking update\nexp: %d %d\ngot: %d %d\n", 0, 0, gameUpdates, hudUpdates)
+	}
+	if exp, got := []sdl.Color{red, green, blue}, getFillColors(renderer); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error rendering scene stack\nexp: %v\ngot: %v\n", exp, got)
+	}
+	if sceneManager.IsSceneUpdated(game) || !sceneManager.IsSceneRendered(game) || !sceneManager.IsSceneUpdated(pause) {
+		t.Errorf("error checking scenes blocked")
+	}
+
+	// Scene popped: scenes below are updated again.
+	if !sceneManager.PopScene() {
+		t.Fatalf("error popping scene")
+	}
+	engine.DoRunFrames(1)
+	gameUpdates, hudUpdates = 0, 0
+	engine.DoRunFrames(2)
+	if exp := []engosdl.IScene{game, hud}; !reflect.DeepEqual(exp, sceneManager.GetSceneStack()) || sceneManager.GetActiveScene() != hud {
+		t.Fatalf("error popping scene\nexp: %v\ngot: %v\n", exp, sceneManager.GetSceneStack())
+	}
+	if gameUpdates != 2 || hudUpdates != 2 {
+		t.Errorf("error updating scene stack\nexp: %d %d\ngot: %d %d\n", 2, 2, gameUpdates, hudUpdates)
+	}
+	if len(pause.GetEntities()) != 0 {
+		t.Errorf("error destroying popped scene\nexp: %d\ngot: %d\n", 0, len(pause.GetEntities()))
+	}
+
+	// Scene pushed blocking all: only the active scene is updated and
+	// rendered.
+	sceneManager.PushScene(pause, engosdl.SceneBlockAll)
+	engine.DoRunFrames(1)
+	gameUpdates, hudUpdates = 0, 0
+	engine.DoRunFrames(2)
+	if gameUpdates != 0 || hudUpdates != 0 {
+		t.Errorf("error blocking update\nexp: %d %d\ngot: %d %d\n", 0, 0, gameUpdates, hudUpdates)
+	}
+	if exp, got := []sdl.Color{blue}, getFillColors(renderer); !reflect.DeepEqual(exp, got) {
+		t.Errorf("error blocking render\nexp: %v\ngot: %v\n", exp, got)
+	}
+
+	// Setting the active scene clears the scene stack.
+	sceneManager.SetActiveScene(game)
+	engine.DoRunFrames(1)
+	if exp := []engosdl.IScene{game}; !reflect.DeepEqual(exp, sceneManager.GetSceneStack()) {
+		t.Errorf("error clearing scene stack\nexp: %v\ngot: %v\n", exp, sceneManager.GetSceneStack())
+	}
+	if sceneManager.PopScene() {
+		t.Errorf("error popping last scene")
+	}
+}
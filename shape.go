@@ -0,0 +1,468 @@
+package engosdl
+
+import "math"
+
+// Collider shape types.
+const (
+	// ShapeDefault uses the scene collision mode to select the shape. It is
+	// a box for ModeBox and a circle for ModeCircle.
+	ShapeDefault int = 0
+	// ShapeAABB is an axis aligned box with entity dimensions.
+	ShapeAABB int = 1
+	// ShapeCircle is a circle with a given radius.
+	ShapeCircle int = 2
+	// ShapeOBB is an oriented box with entity dimensions and rotation.
+	ShapeOBB int = 3
+	// ShapePolygon is a convex polygon.
+	ShapePolygon int = 4
+)
+
+// ICollisionShape represents any shape in world coordinates used to check
+// collisions.
+type ICollisionShape interface {
+	Contains(*Vector) bool
+	GetBounds() *Rect
+	GetCenter() *Vector
+	GetType() int
+	Raycast(*Vector, *Vector, float64) (float64, *Vector, bool)
+}
+
+// ColliderShape contains the shape definition for a collider. Offset is
+// added to the entity position. Radius is used only by circles, if it is
+// zero, half of the minimum entity dimension is used. Points are used only
+// by polygons, they are relative to the entity top-left corner and they have
+// to define a convex polygon. Offset, radius and points are affected by the
+// entity scale, and oriented boxes and polygons are rotated around the
+// entity center.
+type ColliderShape struct {
+	Type   int       `json:"type"`
+	Offset *Vector   `json:"offset"`
+	Radius float64   `json:"radius"`
+	Points []*Vector `json:"points"`
+}
+
+// NewColliderShape creates a new collider shape instance.
+func NewColliderShape(shapeType int) *ColliderShape {
+	return &ColliderShape{
+		Type:   shapeType,
+		Offset: NewVector(0, 0),
+		Radius: 0,
+		Points: []*Vector{},
+	}
+}
+
+// NewCircleColliderShape creates a new circle collider shape instance.
+func NewCircleColliderShape(radius float64, offset *Vector) *ColliderShape {
+	shape := NewColliderShape(ShapeCircle)
+	shape.Radius = radius
+	shape.Offset = offset
+	return shape
+}
+
+// NewPolygonColliderShape creates a new convex polygon collider shape
+// instance.
+func NewPolygonColliderShape(points []*Vector) *ColliderShape {
+	shape := NewColliderShape(ShapePolygon)
+	shape.Points = points
+	return shape
+}
+
+// UnmarshalShape returns a collider shape from the given data. It returns
+// nil if data does not contain any shape.
+func UnmarshalShape(data map[string]interface{}) *ColliderShape {
+	obj, ok := data["shape"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	shape := NewColliderShape(int(obj["type"].(float64)))
+	if offset, ok := obj["offset"].(map[string]interface{}); ok {
+		shape.Offset = NewVector(offset["X"].(float64), offset["Y"].(float64))
+	}
+	if radius, ok := obj["radius"].(float64); ok {
+		shape.Radius = radius
+	}
+	if points, ok := obj["points"].([]interface{}); ok {
+		for _, p := range points {
+			point := p.(map[string]interface{})
+			shape.Points = append(shape.Points, NewVector(point["X"].(float64), point["Y"].(float64)))
+		}
+	}
+	return shape
+}
+
+// NewCollisionShape returns the collision shape in world coordinates for the
+// given shape definition and entity.
+func NewCollisionShape(shape *ColliderShape, entity IEntity) ICollisionShape {
+	transform := entity.GetTransform()
+	x, y, w, h := transform.GetRectExt()
+	scale := transform.GetWorldScale()
+	rotation := transform.GetWorldRotation()
+	shapeType := ShapeDefault
+	offset := NewVector(0, 0)
+	if shape != nil {
+		shapeType = shape.Type
+		if shape.Offset != nil {
+			offset = NewVector(shape.Offset.X*scale.X, shape.Offset.Y*scale.Y)
+		}
+	}
+	center := NewVector(x+w/2+offset.X, y+h/2+offset.Y)
+	switch shapeType {
+	case ShapeAABB:
+		return NewBoxShape(&Rect{X: x + offset.X, Y: y + offset.Y, W: w, H: h}, 0)
+	case ShapeCircle:
+		radius := shape.Radius * math.Max(scale.X, scale.Y)
+		if radius == 0 {
+			radius = math.Min(w, h) / 2
+		}
+		return NewCircleShape(center, radius)
+	case ShapeOBB:
+		return NewBoxShape(&Rect{X: x + offset.X, Y: y + offset.Y, W: w, H: h}, rotation)
+	case ShapePolygon:
+		points := []*Vector{}
+		for _, point := range shape.Points {
+			px, py := rotate(point.X*scale.X-w/2, point.Y*scale.Y-h/2, rotation)
+			points = append(points, NewVector(center.X+px, center.Y+py))
+		}
+		return NewPolygonShape(ShapePolygon, points)
+	}
+	if scene := entity.GetScene(); scene != nil && scene.GetCollisionMode() == ModeBox {
+		return NewBoxShape(&Rect{X: x, Y: y, W: w, H: h}, 0)
+	}
+	// Default circle radius is 75% of the minimum radius.
+	return NewCircleShape(center, (math.Min(w, h)/2)*0.75)
+}
+
+// CircleShape is a collision shape for circles.
+type CircleShape struct {
+	Center *Vector
+	Radius float64
+}
+
+var _ ICollisionShape = (*CircleShape)(nil)
+
+// NewCircleShape creates a new circle shape instance.
+func NewCircleShape(center *Vector, radius float64) *CircleShape {
+	return &CircleShape{
+		Center: center,
+		Radius: radius,
+	}
+}
+
+// Contains returns if the given point is inside the shape.
+func (s *CircleShape) Contains(point *Vector) bool {
+	dx, dy := point.X-s.Center.X, point.Y-s.Center.Y
+	return dx*dx+dy*dy <= s.Radius*s.Radius
+}
+
+// GetBounds returns the axis aligned rectangle containing the shape.
+func (s *CircleShape) GetBounds() *Rect {
+	return &Rect{X: s.Center.X - s.Radius, Y: s.Center.Y - s.Radius, W: 2 * s.Radius, H: 2 * s.Radius}
+}
+
+// GetCenter returns the shape center.
+func (s *CircleShape) GetCenter() *Vector {
+	return s.Center
+}
+
+// GetType returns the shape type.
+func (s *CircleShape) GetType() int {
+	return ShapeCircle
+}
+
+// Raycast returns the distance and the normal where the ray with the given
+// origin and unit direction hits the shape. Ray starting inside the shape
+// hits at distance zero with the normal opposite to the direction.
+func (s *CircleShape) Raycast(origin *Vector, direction *Vector, maxDistance float64) (float64, *Vector, bool) {
+	mx, my := origin.X-s.Center.X, origin.Y-s.Center.Y
+	b := mx*direction.X + my*direction.Y
+	c := mx*mx + my*my - s.Radius*s.Radius
+	if c <= 0 {
+		return 0, NewVector(-direction.X, -direction.Y), true
+	}
+	discriminant := b*b - c
+	if b > 0 || discriminant < 0 {
+		return 0, nil, false
+	}
+	distance := -b - math.Sqrt(discriminant)
+	if distance > maxDistance {
+		return 0, nil, false
+	}
+	x, y := origin.X+direction.X*distance, origin.Y+direction.Y*distance
+	return distance, NewVector((x-s.Center.X)/s.Radius, (y-s.Center.Y)/s.Radius), true
+}
+
+// PolygonShape is a collision shape for convex polygons. Axis aligned boxes
+// and oriented boxes are polygons with four points.
+type PolygonShape struct {
+	Points    []*Vector
+	shapeType int
+}
+
+var _ ICollisionShape = (*PolygonShape)(nil)
+
+// NewPolygonShape creates a new polygon shape instance.
+func NewPolygonShape(shapeType int, points []*Vector) *PolygonShape {
+	return &PolygonShape{
+		Points:    points,
+		shapeType: shapeType,
+	}
+}
+
+// NewBoxShape creates a new polygon shape instance for the given rectangle
+// rotated around its center. Rotation is given in degrees.
+func NewBoxShape(rect *Rect, rotation float64) *PolygonShape {
+	shapeType := ShapeAABB
+	if rotation != 0 {
+		shapeType = ShapeOBB
+	}
+	cx, cy := rect.X+rect.W/2, rect.Y+rect.H/2
+	points := []*Vector{}
+	for _, corner := range [][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		px, py := rotate(corner[0]*rect.W/2, corner[1]*rect.H/2, rotation)
+		points = append(points, NewVector(cx+px, cy+py))
+	}
+	return NewPolygonShape(shapeType, points)
+}
+
+// Contains returns if the given point is inside the shape.
+func (s *PolygonShape) Contains(point *Vector) bool {
+	if len(s.Points) < 3 {
+		return false
+	}
+	for i, normal := range getOutwardNormals(s.Points, s.GetCenter()) {
+		if normal != nil && (point.X-s.Points[i].X)*normal.X+(point.Y-s.Points[i].Y)*normal.Y > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// GetBounds returns the axis aligned rectangle containing the shape.
+func (s *PolygonShape) GetBounds() *Rect {
+	if len(s.Points) == 0 {
+		return &Rect{}
+	}
+	minX, minY := s.Points[0].X, s.Points[0].Y
+	maxX, maxY := minX, minY
+	for _, point := range s.Points[1:] {
+		minX, maxX = math.Min(minX, point.X), math.Max(maxX, point.X)
+		minY, maxY = math.Min(minY, point.Y), math.Max(maxY, point.Y)
+	}
+	return &Rect{X: minX, Y: minY, W: maxX - minX, H: maxY - minY}
+}
+
+// GetCenter returns the shape center as the average of all points.
+func (s *PolygonShape) GetCenter() *Vector {
+	var x, y float64
+	for _, point := range s.Points {
+		x += point.X
+		y += point.Y
+	}
+	if n := float64(len(s.Points)); n > 0 {
+		return NewVector(x/n, y/n)
+	}
+	return NewVector(0, 0)
+}
+
+// GetType returns the shape type.
+func (s *PolygonShape) GetType() int {
+	return s.shapeType
+}
+
+// Raycast returns the distance and the normal where the ray with the given
+// origin and unit direction hits the shape. Ray starting inside the shape
+// hits at distance zero with the normal opposite to the direction.
+func (s *PolygonShape) Raycast(origin *Vector, direction *Vector, maxDistance float64) (float64, *Vector, bool) {
+	if len(s.Points) < 3 {
+		return 0, nil, false
+	}
+	enter, exit := 0.0, maxDistance
+	var normal *Vector
+	for i, edgeNormal := range getOutwardNormals(s.Points, s.GetCenter()) {
+		if edgeNormal == nil {
+			continue
+		}
+		numerator := (s.Points[i].X-origin.X)*edgeNormal.X + (s.Points[i].Y-origin.Y)*edgeNormal.Y
+		denominator := direction.X*edgeNormal.X + direction.Y*edgeNormal.Y
+		if denominator == 0 {
+			if numerator < 0 {
+				return 0, nil, false
+			}
+			continue
+		}
+		distance := numerator / denominator
+		if denominator < 0 {
+			if distance > enter {
+				enter, normal = distance, edgeNormal
+			}
+		} else if distance < exit {
+			exit = distance
+		}
+		if enter > exit {
+			return 0, nil, false
+		}
+	}
+	if normal == nil {
+		return 0, NewVector(-direction.X, -direction.Y), true
+	}
+	return enter, normal, true
+}
+
+// Collide checks if two shapes collide using separating axis tests. If they
+// collide, it returns the contact with the penetration depth and the normal
+// pointing from the first shape to the second one.
+func Collide(one ICollisionShape, two ICollisionShape) (*Contact, bool) {
+	var normal *Vector
+	var depth float64
+	var ok bool
+	circleOne, isCircleOne := one.(*CircleShape)
+	circleTwo, isCircleTwo := two.(*CircleShape)
+	polygonOne, isPolygonOne := one.(*PolygonShape)
+	polygonTwo, isPolygonTwo := two.(*PolygonShape)
+	switch {
+	case isCircleOne && isCircleTwo:
+		normal, depth, ok = collideCircles(circleOne, circleTwo)
+	case isCircleOne && isPolygonTwo:
+		normal, depth, ok = collideCirclePolygon(circleOne, polygonTwo)
+	case isPolygonOne && isCircleTwo:
+		normal, depth, ok = collideCirclePolygon(circleTwo, polygonOne)
+		if ok {
+			normal = NewVector(-normal.X, -normal.Y)
+		}
+	case isPolygonOne && isPolygonTwo:
+		normal, depth, ok = collidePolygons(polygonOne, polygonTwo)
+	}
+	if !ok {
+		return nil, false
+	}
+	rect, _ := one.GetBounds().Intersect(two.GetBounds())
+	contact := NewContact(rect, normal)
+	contact.Depth = depth
+	return contact, true
+}
+
+// collideCircles checks collision between two circles.
+func collideCircles(one *CircleShape, two *CircleShape) (*Vector, float64, bool) {
+	dx := two.Center.X - one.Center.X
+	dy := two.Center.Y - one.Center.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance >= one.Radius+two.Radius {
+		return nil, 0, false
+	}
+	if distance == 0 {
+		return NewVector(1, 0), one.Radius + two.Radius, true
+	}
+	return NewVector(dx/distance, dy/distance), one.Radius + two.Radius - distance, true
+}
+
+// collideCirclePolygon checks collision between a circle and a polygon.
+// Normal points from the circle to the polygon.
+func collideCirclePolygon(circle *CircleShape, polygon *PolygonShape) (*Vector, float64, bool) {
+	if len(polygon.Points) < 3 {
+		return nil, 0, false
+	}
+	axes := getAxes(polygon.Points)
+	var closest *Vector
+	closestDistance := math.Inf(1)
+	for _, point := range polygon.Points {
+		dx, dy := point.X-circle.Center.X, point.Y-circle.Center.Y
+		if distance := dx*dx + dy*dy; distance < closestDistance {
+			closest, closestDistance = NewVector(dx, dy), distance
+		}
+	}
+	if axis := normalize(closest); axis != nil {
+		axes = append(axes, axis)
+	}
+	var normal *Vector
+	depth := math.Inf(1)
+	for _, axis := range axes {
+		center := circle.Center.X*axis.X + circle.Center.Y*axis.Y
+		minPolygon, maxPolygon := project(polygon.Points, axis)
+		overlap := math.Min(center+circle.Radius, maxPolygon) - math.Max(center-circle.Radius, minPolygon)
+		if overlap <= 0 {
+			return nil, 0, false
+		}
+		if overlap < depth {
+			normal, depth = axis, overlap
+		}
+	}
+	return orient(normal, circle.Center, polygon.GetCenter()), depth, true
+}
+
+// collidePolygons checks collision between two convex polygons.
+func collidePolygons(one *PolygonShape, two *PolygonShape) (*Vector, float64, bool) {
+	if len(one.Points) < 3 || len(two.Points) < 3 {
+		return nil, 0, false
+	}
+	var normal *Vector
+	depth := math.Inf(1)
+	for _, axis := range append(getAxes(one.Points), getAxes(two.Points)...) {
+		minOne, maxOne := project(one.Points, axis)
+		minTwo, maxTwo := project(two.Points, axis)
+		overlap := math.Min(maxOne, maxTwo) - math.Max(minOne, minTwo)
+		if overlap <= 0 {
+			return nil, 0, false
+		}
+		if overlap < depth {
+			normal, depth = axis, overlap
+		}
+	}
+	return orient(normal, one.GetCenter(), two.GetCenter()), depth, true
+}
+
+// getAxes returns unit normals for all polygon edges.
+func getAxes(points []*Vector) []*Vector {
+	axes := []*Vector{}
+	for i, point := range points {
+		next := points[(i+1)%len(points)]
+		if axis := normalize(NewVector(point.Y-next.Y, next.X-point.X)); axis != nil {
+			axes = append(axes, axis)
+		}
+	}
+	return axes
+}
+
+// getOutwardNormals returns unit normals for all polygon edges pointing
+// outside the polygon. Normal for an edge without length is nil.
+func getOutwardNormals(points []*Vector, center *Vector) []*Vector {
+	normals := []*Vector{}
+	for i, point := range points {
+		next := points[(i+1)%len(points)]
+		normal := normalize(NewVector(point.Y-next.Y, next.X-point.X))
+		if normal != nil {
+			middle := NewVector((point.X+next.X)/2, (point.Y+next.Y)/2)
+			normal = orient(normal, center, middle)
+		}
+		normals = append(normals, normal)
+	}
+	return normals
+}
+
+// normalize returns the unit vector for the given vector. It returns nil
+// for a zero vector.
+func normalize(v *Vector) *Vector {
+	length := math.Sqrt(v.X*v.X + v.Y*v.Y)
+	if length == 0 {
+		return nil
+	}
+	return NewVector(v.X/length, v.Y/length)
+}
+
+// orient returns the given normal pointing from one position to the other.
+func orient(normal *Vector, from *Vector, to *Vector) *Vector {
+	if (to.X-from.X)*normal.X+(to.Y-from.Y)*normal.Y < 0 {
+		return NewVector(-normal.X, -normal.Y)
+	}
+	return normal
+}
+
+// project returns minimum and maximum values for all points projected in the
+// given axis.
+func project(points []*Vector, axis *Vector) (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		value := point.X*axis.X + point.Y*axis.Y
+		min, max = math.Min(min, value), math.Max(max, value)
+	}
+	return min, max
+}
@@ -0,0 +1,91 @@
+package engosdl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+)
+
+func TestShape_CollideCircles(t *testing.T) {
+	one := engosdl.NewCircleShape(engosdl.NewVector(0, 0), 10)
+	two := engosdl.NewCircleShape(engosdl.NewVector(15, 0), 10)
+	contact, ok := engosdl.Collide(one, two)
+	if !ok {
+		t.Fatalf("error colliding circles")
+	}
+	if contact.Depth != 5 || !equalVector(contact.Normal, engosdl.NewVector(1, 0)) {
+		t.Errorf("error getting contact\nexp: %f %v\ngot: %f %v\n", 5.0, engosdl.NewVector(1, 0), contact.Depth, contact.Normal)
+	}
+	if _, ok := engosdl.Collide(one, engosdl.NewCircleShape(engosdl.NewVector(0, 25), 10)); ok {
+		t.Errorf("error colliding separated circles")
+	}
+}
+
+func TestShape_CollideBoxes(t *testing.T) {
+	box := engosdl.NewBoxShape(engosdl.NewRect(0, 0, 20, 20), 0)
+	other := engosdl.NewBoxShape(engosdl.NewRect(0, 16, 20, 20), 0)
+	contact, ok := engosdl.Collide(other, box)
+	if !ok {
+		t.Fatalf("error colliding boxes")
+	}
+	if math.Abs(contact.Depth-4) > 0.0001 || !equalVector(contact.Normal, engosdl.NewVector(0, -1)) {
+		t.Errorf("error getting contact\nexp: %f %v\ngot: %f %v\n", 4.0, engosdl.NewVector(0, -1), contact.Depth, contact.Normal)
+	}
+
+	// Rotated box corner reaches 14.14 from the center, so it overlaps a
+	// box placed 12 units right, but an axis aligned box would not.
+	rotated := engosdl.NewBoxShape(engosdl.NewRect(-10, -10, 20, 20), 45)
+	if rotated.GetType() != engosdl.ShapeOBB {
+		t.Errorf("error getting shape type\nexp: %d\ngot: %d\n", engosdl.ShapeOBB, rotated.GetType())
+	}
+	right := engosdl.NewBoxShape(engosdl.NewRect(12, -5, 10, 10), 0)
+	if contact, ok := engosdl.Collide(rotated, right); !ok || math.Abs(contact.Depth-(10*math.Sqrt2-12)) > 0.0001 {
+		t.Errorf("error colliding oriented box")
+	}
+	if _, ok := engosdl.Collide(engosdl.NewBoxShape(engosdl.NewRect(-10, -10, 20, 20), 0), right); ok {
+		t.Errorf("error colliding separated boxes")
+	}
+}
+
+func TestShape_CollideCirclePolygon(t *testing.T) {
+	triangle := engosdl.NewPolygonShape(engosdl.ShapePolygon, []*engosdl.Vector{
+		engosdl.NewVector(0, 0),
+		engosdl.NewVector(20, 0),
+		engosdl.NewVector(0, 20),
+	})
+	circle := engosdl.NewCircleShape(engosdl.NewVector(15, 15), 5)
+	if _, ok := engosdl.Collide(circle, triangle); ok {
+		t.Errorf("error colliding circle outside triangle hypotenuse")
+	}
+	circle = engosdl.NewCircleShape(engosdl.NewVector(10, -3), 5)
+	contact, ok := engosdl.Collide(triangle, circle)
+	if !ok {
+		t.Fatalf("error colliding circle with triangle")
+	}
+	if math.Abs(contact.Depth-2) > 0.0001 || !equalVector(contact.Normal, engosdl.NewVector(0, -1)) {
+		t.Errorf("error getting contact\nexp: %f %v\ngot: %f %v\n", 2.0, engosdl.NewVector(0, -1), contact.Depth, contact.Normal)
+	}
+}
+
+func TestShape_NewCollisionShape(t *testing.T) {
+	entity := engosdl.NewEntity("entity")
+	entity.GetTransform().SetPositionXY(10, 10).SetDimXY(20, 10).SetScaleXY(2, 2)
+	shape := engosdl.NewCollisionShape(engosdl.NewCircleColliderShape(4, engosdl.NewVector(1, 0)), entity)
+	circle, ok := shape.(*engosdl.CircleShape)
+	if !ok {
+		t.Fatalf("error creating circle shape")
+	}
+	if circle.Radius != 8 || !equalVector(circle.Center, engosdl.NewVector(32, 20)) {
+		t.Errorf("error creating circle shape\nexp: %f %v\ngot: %f %v\n", 8.0, engosdl.NewVector(32, 20), circle.Radius, circle.Center)
+	}
+	polygon := engosdl.NewPolygonColliderShape([]*engosdl.Vector{
+		engosdl.NewVector(0, 0),
+		engosdl.NewVector(20, 0),
+		engosdl.NewVector(10, 10),
+	})
+	bounds := engosdl.NewCollisionShape(polygon, entity).GetBounds()
+	if bounds.X != 10 || bounds.Y != 10 || bounds.W != 40 || bounds.H != 20 {
+		t.Errorf("error creating polygon shape\nexp: %v\ngot: %v\n", engosdl.NewRect(10, 10, 40, 20), bounds)
+	}
+}
@@ -1,7 +1,7 @@
 package engosdl
 
 import (
-	"io/ioutil"
+	"fmt"
 
 	"github.com/veandco/go-sdl2/mix"
 )
@@ -29,38 +29,54 @@ type SoundResource struct {
 
 var _ ISoundResource = (*SoundResource)(nil)
 
-// NewSound creates a new source instance.
+// NewSound creates a new source instance. It returns nil and the error is
+// reported if sound file can not be loaded.
 func NewSound(name string, filename string, format int) *SoundResource {
-	var err error
+	result, err := NewSoundFromFile(name, filename, format)
+	if err != nil {
+		ReportError(err)
+		return nil
+	}
+	return result
+}
+
+// NewSoundFromFile creates a new sound instance. It returns an error if sound
+// file can not be loaded.
+func NewSoundFromFile(name string, filename string, format int) (*SoundResource, error) {
 	Logger.Trace().Str("sound", name).Str("filename", filename).Msg("new sound")
-	result := &SoundResource{
+	sound, chunk, err := loadSound(filename, format)
+	if err != nil {
+		return nil, fmt.Errorf("sound %s load error: %w", filename, err)
+	}
+	return NewSoundFromMix(name, filename, format, sound, chunk), nil
+}
+
+// NewSoundFromMix creates a new sound instance for a music or a chunk already
+// loaded.
+func NewSoundFromMix(name string, filename string, format int, sound *mix.Music, chunk *mix.Chunk) *SoundResource {
+	Logger.Trace().Str("sound", name).Str("filename", filename).Msg("new sound from mix")
+	return &SoundResource{
 		Object:   NewObject(name),
 		filename: filename,
 		format:   format,
 		counter:  0,
-		sound:    nil,
-		chunk:    nil,
+		sound:    sound,
+		chunk:    chunk,
 	}
+}
+
+// loadSound loads the music for MP3 files or the chunk for WAV files. It does
+// not use any engine resource, so it can be called from any goroutine.
+func loadSound(filename string, format int) (*mix.Music, *mix.Chunk, error) {
 	switch format {
 	case SoundMP3:
-		if result.sound, err = mix.LoadMUS(filename); err != nil {
-			Logger.Error().Err(err).Str("filename", filename).Msg("load MP3 error")
-			panic(err)
-		}
-		break
+		sound, err := mix.LoadMUS(filename)
+		return sound, nil, err
 	case SoundWAV:
-		var data []byte
-		if data, err = ioutil.ReadFile(filename); err != nil {
-			Logger.Error().Err(err).Str("filename", filename).Msg("load WAV file error")
-			panic(err)
-		}
-		if result.chunk, err = mix.QuickLoadWAV(data); err != nil {
-			Logger.Error().Err(err).Str("filename", filename).Msg("load WAV error")
-			panic(err)
-		}
-		break
+		chunk, err := mix.LoadWAV(filename)
+		return nil, chunk, err
 	}
-	return result
+	return nil, nil, fmt.Errorf("unknown format %d", format)
 }
 
 // Clear deletes sound even if counter is not zero.
@@ -112,6 +128,7 @@ func (s *SoundResource) New() {
 // ISoundManager represents the handler that is in charge of all sounds.
 type ISoundManager interface {
 	IObject
+	AddSound(ISoundResource) bool
 	Clear()
 	CreateSound(string, string, int) ISoundResource
 	DeleteSound(ISoundResource) bool
@@ -120,6 +137,7 @@ type ISoundManager interface {
 	GetSoundByFilename(string) ISoundResource
 	GetSoundByName(string) ISoundResource
 	GetSounds() []ISoundResource
+	LoadSound(string, string, int) (ISoundResource, error)
 	OnStart()
 }
 
@@ -140,6 +158,17 @@ func NewSoundManager(name string) *SoundManager {
 	}
 }
 
+// AddSound adds a sound already created to the sound manager. It returns
+// false if there is already a sound with the same filename.
+func (h *SoundManager) AddSound(sound ISoundResource) bool {
+	Logger.Trace().Str("sound-manager", h.GetName()).Str("name", sound.GetName()).Str("filename", sound.GetFilename()).Msg("AddSound")
+	if h.GetSoundByFilename(sound.GetFilename()) != nil {
+		return false
+	}
+	h.sounds = append(h.sounds, sound)
+	return true
+}
+
 // Clear removes all sounds from the sound manager.
 func (h *SoundManager) Clear() {
 	Logger.Trace().Str("sound-manager", h.GetName()).Msg("Clear")
@@ -150,17 +179,14 @@ func (h *SoundManager) Clear() {
 }
 
 // CreateSound creates a new sound. If the same sound has already been created
-// with the same filename, existing sound is returned.
+// with the same filename, existing sound is returned. It returns nil and the
+// error is reported if sound can not be loaded.
 func (h *SoundManager) CreateSound(name string, filename string, format int) ISoundResource {
-	Logger.Trace().Str("sound-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("CreateSound")
-	for _, sound := range h.sounds {
-		if sound.GetFilename() == filename {
-			sound.New()
-			return sound
-		}
+	sound, err := h.LoadSound(name, filename, format)
+	if err != nil {
+		ReportError(err)
+		return nil
 	}
-	sound := NewSound(name, filename, format)
-	h.sounds = append(h.sounds, sound)
 	return sound
 }
 
@@ -220,6 +246,25 @@ func (h *SoundManager) GetSounds() []ISoundResource {
 	return h.sounds
 }
 
+// LoadSound creates a new sound. If the same sound has already been created
+// with the same filename, existing sound is returned. It returns an error if
+// sound can not be loaded.
+func (h *SoundManager) LoadSound(name string, filename string, format int) (ISoundResource, error) {
+	Logger.Trace().Str("sound-manager", h.GetName()).Str("name", name).Str("filename", filename).Msg("LoadSound")
+	for _, sound := range h.sounds {
+		if sound.GetFilename() == filename {
+			sound.New()
+			return sound, nil
+		}
+	}
+	sound, err := NewSoundFromFile(name, filename, format)
+	if err != nil {
+		return nil, err
+	}
+	h.sounds = append(h.sounds, sound)
+	return sound, nil
+}
+
 // OnStart initializes all sound manager structure.
 func (h *SoundManager) OnStart() {
 	Logger.Trace().Str("Sound-manager", h.GetName()).Msg("OnStart")
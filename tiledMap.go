@@ -0,0 +1,814 @@
+package engosdl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Tiled global tile ID flags. Highest bits in a global tile ID are used to
+// flip the tile, remaining bits are the tile ID.
+const (
+	TiledFlippedHorizontally uint32 = 0x80000000
+	TiledFlippedVertically   uint32 = 0x40000000
+	TiledFlippedDiagonally   uint32 = 0x20000000
+	TiledGIDMask             uint32 = 0x1FFFFFFF
+)
+
+// Tiled layer types.
+const (
+	TiledTileLayer   string = "tilelayer"
+	TiledObjectGroup string = "objectgroup"
+)
+
+// TiledCollisionProperty is the tile or object property used to generate
+// collision shapes. Tiles and objects collide if it is "true".
+const TiledCollisionProperty string = "collision"
+
+// TiledMap represents a map created with the Tiled map editor. Only
+// orthogonal maps are supported.
+type TiledMap struct {
+	Filename   string
+	Width      int
+	Height     int
+	TileWidth  int
+	TileHeight int
+	Properties map[string]string
+	Tilesets   []*TiledTileset
+	Layers     []*TiledLayer
+}
+
+// TiledTileset represents a tileset used by a Tiled map. Image is the
+// filename for the tileset image, it is empty for tilesets without image.
+type TiledTileset struct {
+	FirstGID    uint32
+	Name        string
+	TileWidth   int
+	TileHeight  int
+	Spacing     int
+	Margin      int
+	Columns     int
+	TileCount   int
+	Image       string
+	ImageWidth  int
+	ImageHeight int
+	Tiles       map[uint32]*TiledTile
+}
+
+// TiledTile contains properties for a tile in a tileset.
+type TiledTile struct {
+	ID         uint32
+	Properties map[string]string
+}
+
+// TiledLayer represents a tile layer or an object group in a Tiled map. Data
+// contains a global tile ID for every cell in tile layers, row by row.
+// Layers inside groups are flattened, so layers are in display order.
+type TiledLayer struct {
+	Name       string
+	Type       string
+	Width      int
+	Height     int
+	Visible    bool
+	Opacity    float64
+	Properties map[string]string
+	Data       []uint32
+	Objects    []*TiledObject
+}
+
+// TiledObject represents an object in an object group. Position is the
+// top-left corner for the object in map coordinates, even for tile objects.
+// Polygon points are relative to the object position.
+type TiledObject struct {
+	ID         int
+	Name       string
+	Type       string
+	X          float64
+	Y          float64
+	Width      float64
+	Height     float64
+	Rotation   float64
+	GID        uint32
+	Ellipse    bool
+	Polygon    []*Vector
+	Properties map[string]string
+}
+
+// GetCollision returns if the object has the collision property.
+func (o *TiledObject) GetCollision() bool {
+	return o.Properties[TiledCollisionProperty] == "true"
+}
+
+// GetTile returns the global tile ID for the given cell. It returns zero for
+// empty cells and cells outside the layer.
+func (l *TiledLayer) GetTile(col int, row int) uint32 {
+	if col < 0 || row < 0 || col >= l.Width || row >= l.Height || row*l.Width+col >= len(l.Data) {
+		return 0
+	}
+	return l.Data[row*l.Width+col]
+}
+
+// GetTileRect returns the rectangle in the tileset image for the given local
+// tile ID.
+func (t *TiledTileset) GetTileRect(id uint32) *Rect {
+	columns := t.Columns
+	if columns <= 0 && t.TileWidth+t.Spacing > 0 {
+		columns = (t.ImageWidth - 2*t.Margin + t.Spacing) / (t.TileWidth + t.Spacing)
+	}
+	if columns <= 0 {
+		columns = 1
+	}
+	col, row := int(id)%columns, int(id)/columns
+	return NewRect(float64(t.Margin+col*(t.TileWidth+t.Spacing)), float64(t.Margin+row*(t.TileHeight+t.Spacing)), float64(t.TileWidth), float64(t.TileHeight))
+}
+
+// GetLayer returns the layer with the given name. It returns nil if there is
+// not any layer with that name.
+func (m *TiledMap) GetLayer(name string) *TiledLayer {
+	for _, layer := range m.Layers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// GetTileset returns the tileset and the local tile ID for the given global
+// tile ID. Flip flags are ignored. It returns nil for empty tiles.
+func (m *TiledMap) GetTileset(gid uint32) (*TiledTileset, uint32) {
+	gid &= TiledGIDMask
+	if gid == 0 {
+		return nil, 0
+	}
+	var result *TiledTileset
+	for _, tileset := range m.Tilesets {
+		if tileset.FirstGID <= gid {
+			result = tileset
+		}
+	}
+	if result == nil {
+		return nil, 0
+	}
+	return result, gid - result.FirstGID
+}
+
+// GetTileProperties returns properties for the given global tile ID. It
+// returns nil if tile does not have properties.
+func (m *TiledMap) GetTileProperties(gid uint32) map[string]string {
+	tileset, id := m.GetTileset(gid)
+	if tileset == nil {
+		return nil
+	}
+	if tile, ok := tileset.Tiles[id]; ok {
+		return tile.Properties
+	}
+	return nil
+}
+
+// GetTileCollision returns if the given global tile ID has the collision
+// property.
+func (m *TiledMap) GetTileCollision(gid uint32) bool {
+	return m.GetTileProperties(gid)[TiledCollisionProperty] == "true"
+}
+
+// GetCollisionRects returns rectangles for all tiles with the collision
+// property, in map coordinates. Adjacent tiles in the same row are merged
+// in a single rectangle.
+func (m *TiledMap) GetCollisionRects() []*Rect {
+	result := []*Rect{}
+	tw, th := float64(m.TileWidth), float64(m.TileHeight)
+	for row := 0; row < m.Height; row++ {
+		start := -1
+		for col := 0; col <= m.Width; col++ {
+			collide := false
+			if col < m.Width {
+				for _, layer := range m.Layers {
+					if layer.Type == TiledTileLayer && m.GetTileCollision(layer.GetTile(col, row)) {
+						collide = true
+						break
+					}
+				}
+			}
+			if collide && start == -1 {
+				start = col
+			} else if !collide && start != -1 {
+				result = append(result, NewRect(float64(start)*tw, float64(row)*th, float64(col-start)*tw, th))
+				start = -1
+			}
+		}
+	}
+	return result
+}
+
+// LoadTiledMap reads and parses the given Tiled map file. Files with ".tmx"
+// extension are parsed as TMX, any other file is parsed as JSON. External
+// tilesets are loaded relative to the map file, and image paths are
+// returned relative to the working directory.
+func LoadTiledMap(filename string) (*TiledMap, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(filename)
+	var result *TiledMap
+	if strings.ToLower(filepath.Ext(filename)) == ".tmx" {
+		result, err = parseTiledTMX(data, dir)
+	} else {
+		result, err = parseTiledJSON(data, dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("map %s: %w", filename, err)
+	}
+	result.Filename = filename
+	return result, nil
+}
+
+// ParseTiledMap parses Tiled map data in TMX or JSON format. External
+// tilesets and images are relative to the given directory.
+func ParseTiledMap(data []byte, dir string) (*TiledMap, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) != 0 && data[0] == '<' {
+		return parseTiledTMX(data, dir)
+	}
+	return parseTiledJSON(data, dir)
+}
+
+// getTiledPath returns the given path relative to the given directory.
+func getTiledPath(dir string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// decodeTiledData decodes tile layer data encoded in base64 and optionally
+// compressed with zlib or gzip.
+func decodeTiledData(data string, compression string) ([]uint32, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader = bytes.NewReader(raw)
+	switch compression {
+	case "":
+	case "zlib":
+		if reader, err = zlib.NewReader(reader); err != nil {
+			return nil, err
+		}
+	case "gzip":
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compression)
+	}
+	if raw, err = ioutil.ReadAll(reader); err != nil {
+		return nil, err
+	}
+	result := make([]uint32, len(raw)/4)
+	for i := range result {
+		result[i] = binary.LittleEndian.Uint32(raw[i*4:])
+	}
+	return result, nil
+}
+
+// decodeTiledCSV decodes tile layer data encoded as comma separated values.
+func decodeTiledCSV(data string) ([]uint32, error) {
+	result := []uint32{}
+	for _, value := range strings.Split(data, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		gid, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, uint32(gid))
+	}
+	return result, nil
+}
+
+// sortTilesets sorts tilesets by first global tile ID.
+func sortTilesets(tilesets []*TiledTileset) []*TiledTileset {
+	sort.Slice(tilesets, func(i, j int) bool {
+		return tilesets[i].FirstGID < tilesets[j].FirstGID
+	})
+	return tilesets
+}
+
+// tmxProperties contains properties in TMX format.
+type tmxProperties struct {
+	Properties []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+		Text  string `xml:",chardata"`
+	} `xml:"property"`
+}
+
+// toMap returns TMX properties as a map. Multiline values are given as
+// element text.
+func (p *tmxProperties) toMap() map[string]string {
+	result := make(map[string]string)
+	if p == nil {
+		return result
+	}
+	for _, property := range p.Properties {
+		if property.Value == "" {
+			result[property.Name] = property.Text
+		} else {
+			result[property.Name] = property.Value
+		}
+	}
+	return result
+}
+
+// tmxTileset is a tileset in TMX or TSX format.
+type tmxTileset struct {
+	FirstGID   uint32 `xml:"firstgid,attr"`
+	Source     string `xml:"source,attr"`
+	Name       string `xml:"name,attr"`
+	TileWidth  int    `xml:"tilewidth,attr"`
+	TileHeight int    `xml:"tileheight,attr"`
+	Spacing    int    `xml:"spacing,attr"`
+	Margin     int    `xml:"margin,attr"`
+	TileCount  int    `xml:"tilecount,attr"`
+	Columns    int    `xml:"columns,attr"`
+	Image      struct {
+		Source string `xml:"source,attr"`
+		Width  int    `xml:"width,attr"`
+		Height int    `xml:"height,attr"`
+	} `xml:"image"`
+	Tiles []struct {
+		ID         uint32         `xml:"id,attr"`
+		Properties *tmxProperties `xml:"properties"`
+	} `xml:"tile"`
+}
+
+// tmxLayer is a tile layer, an object group or a group in TMX format.
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string         `xml:"name,attr"`
+	Width      int            `xml:"width,attr"`
+	Height     int            `xml:"height,attr"`
+	Visible    string         `xml:"visible,attr"`
+	Opacity    string         `xml:"opacity,attr"`
+	Properties *tmxProperties `xml:"properties"`
+	Data       struct {
+		Encoding    string `xml:"encoding,attr"`
+		Compression string `xml:"compression,attr"`
+		Text        string `xml:",chardata"`
+		Tiles       []struct {
+			GID uint32 `xml:"gid,attr"`
+		} `xml:"tile"`
+	} `xml:"data"`
+	Objects []struct {
+		ID         int            `xml:"id,attr"`
+		Name       string         `xml:"name,attr"`
+		Type       string         `xml:"type,attr"`
+		Class      string         `xml:"class,attr"`
+		X          float64        `xml:"x,attr"`
+		Y          float64        `xml:"y,attr"`
+		Width      float64        `xml:"width,attr"`
+		Height     float64        `xml:"height,attr"`
+		Rotation   float64        `xml:"rotation,attr"`
+		GID        uint32         `xml:"gid,attr"`
+		Properties *tmxProperties `xml:"properties"`
+		Ellipse    *struct{}      `xml:"ellipse"`
+		Polygon    *struct {
+			Points string `xml:"points,attr"`
+		} `xml:"polygon"`
+	} `xml:"object"`
+	Layers []tmxLayer `xml:",any"`
+}
+
+// tmxMap is a map in TMX format.
+type tmxMap struct {
+	Orientation string         `xml:"orientation,attr"`
+	Width       int            `xml:"width,attr"`
+	Height      int            `xml:"height,attr"`
+	TileWidth   int            `xml:"tilewidth,attr"`
+	TileHeight  int            `xml:"tileheight,attr"`
+	Properties  *tmxProperties `xml:"properties"`
+	Tilesets    []tmxTileset   `xml:"tileset"`
+	Layers      []tmxLayer     `xml:",any"`
+}
+
+// parseTiledTMX parses a Tiled map in TMX format.
+func parseTiledTMX(data []byte, dir string) (*TiledMap, error) {
+	descriptor := &tmxMap{}
+	if err := xml.Unmarshal(data, descriptor); err != nil {
+		return nil, err
+	}
+	if descriptor.Orientation != "" && descriptor.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("unsupported orientation %s", descriptor.Orientation)
+	}
+	result := &TiledMap{
+		Width:      descriptor.Width,
+		Height:     descriptor.Height,
+		TileWidth:  descriptor.TileWidth,
+		TileHeight: descriptor.TileHeight,
+		Properties: descriptor.Properties.toMap(),
+		Tilesets:   []*TiledTileset{},
+		Layers:     []*TiledLayer{},
+	}
+	for _, tileset := range descriptor.Tilesets {
+		firstGID, tilesetDir := tileset.FirstGID, dir
+		if tileset.Source != "" {
+			source := getTiledPath(dir, tileset.Source)
+			tsx, err := ioutil.ReadFile(source)
+			if err != nil {
+				return nil, err
+			}
+			if strings.ToLower(filepath.Ext(source)) != ".tsx" {
+				external, err := parseTiledJSONTileset(tsx, filepath.Dir(source))
+				if err != nil {
+					return nil, err
+				}
+				external.FirstGID = firstGID
+				result.Tilesets = append(result.Tilesets, external)
+				continue
+			}
+			tileset = tmxTileset{}
+			if err := xml.Unmarshal(tsx, &tileset); err != nil {
+				return nil, err
+			}
+			tilesetDir = filepath.Dir(source)
+		}
+		result.Tilesets = append(result.Tilesets, newTiledTilesetFromTMX(&tileset, firstGID, tilesetDir))
+	}
+	sortTilesets(result.Tilesets)
+	if err := appendTMXLayers(result, descriptor.Layers); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newTiledTilesetFromTMX creates a new tileset instance from the given TMX
+// or TSX tileset.
+func newTiledTilesetFromTMX(tileset *tmxTileset, firstGID uint32, dir string) *TiledTileset {
+	result := &TiledTileset{
+		FirstGID:    firstGID,
+		Name:        tileset.Name,
+		TileWidth:   tileset.TileWidth,
+		TileHeight:  tileset.TileHeight,
+		Spacing:     tileset.Spacing,
+		Margin:      tileset.Margin,
+		Columns:     tileset.Columns,
+		TileCount:   tileset.TileCount,
+		Image:       getTiledPath(dir, tileset.Image.Source),
+		ImageWidth:  tileset.Image.Width,
+		ImageHeight: tileset.Image.Height,
+		Tiles:       make(map[uint32]*TiledTile),
+	}
+	for _, tile := range tileset.Tiles {
+		result.Tiles[tile.ID] = &TiledTile{ID: tile.ID, Properties: tile.Properties.toMap()}
+	}
+	return result
+}
+
+// appendTMXLayers appends the given TMX layers to the map. Groups are
+// flattened and image layers are not supported.
+func appendTMXLayers(m *TiledMap, layers []tmxLayer) error {
+	for _, layer := range layers {
+		visible := layer.Visible != "0"
+		opacity := 1.0
+		if layer.Opacity != "" {
+			opacity, _ = strconv.ParseFloat(layer.Opacity, 64)
+		}
+		result := &TiledLayer{
+			Name:       layer.Name,
+			Width:      layer.Width,
+			Height:     layer.Height,
+			Visible:    visible,
+			Opacity:    opacity,
+			Properties: layer.Properties.toMap(),
+		}
+		switch layer.XMLName.Local {
+		case "layer":
+			result.Type = TiledTileLayer
+			var err error
+			switch layer.Data.Encoding {
+			case "csv":
+				result.Data, err = decodeTiledCSV(layer.Data.Text)
+			case "base64":
+				result.Data, err = decodeTiledData(layer.Data.Text, layer.Data.Compression)
+			case "":
+				for _, tile := range layer.Data.Tiles {
+					result.Data = append(result.Data, tile.GID)
+				}
+			default:
+				err = fmt.Errorf("unsupported encoding %s", layer.Data.Encoding)
+			}
+			if err != nil {
+				return fmt.Errorf("layer %s: %w", layer.Name, err)
+			}
+		case "objectgroup":
+			result.Type = TiledObjectGroup
+			for _, obj := range layer.Objects {
+				object := &TiledObject{
+					ID:         obj.ID,
+					Name:       obj.Name,
+					Type:       obj.Type,
+					X:          obj.X,
+					Y:          obj.Y,
+					Width:      obj.Width,
+					Height:     obj.Height,
+					Rotation:   obj.Rotation,
+					GID:        obj.GID,
+					Ellipse:    obj.Ellipse != nil,
+					Properties: obj.Properties.toMap(),
+				}
+				if object.Type == "" {
+					object.Type = obj.Class
+				}
+				if obj.Polygon != nil {
+					for _, point := range strings.Fields(obj.Polygon.Points) {
+						var x, y float64
+						if _, err := fmt.Sscanf(point, "%g,%g", &x, &y); err != nil {
+							return fmt.Errorf("object %d: %w", obj.ID, err)
+						}
+						object.Polygon = append(object.Polygon, NewVector(x, y))
+					}
+				}
+				result.Objects = append(result.Objects, normalizeTiledObject(object))
+			}
+		case "group":
+			if err := appendTMXLayers(m, layer.Layers); err != nil {
+				return err
+			}
+			continue
+		default:
+			continue
+		}
+		m.Layers = append(m.Layers, result)
+	}
+	return nil
+}
+
+// normalizeTiledObject moves the object position to the top-left corner.
+// Tiled uses the bottom-left corner for tile objects, and polygons are
+// placed at their bounding box.
+func normalizeTiledObject(object *TiledObject) *TiledObject {
+	if object.GID != 0 {
+		object.Y -= object.Height
+	}
+	if len(object.Polygon) != 0 {
+		minX, minY := object.Polygon[0].X, object.Polygon[0].Y
+		maxX, maxY := minX, minY
+		for _, point := range object.Polygon {
+			if point.X < minX {
+				minX = point.X
+			}
+			if point.Y < minY {
+				minY = point.Y
+			}
+			if point.X > maxX {
+				maxX = point.X
+			}
+			if point.Y > maxY {
+				maxY = point.Y
+			}
+		}
+		for _, point := range object.Polygon {
+			point.X -= minX
+			point.Y -= minY
+		}
+		object.X += minX
+		object.Y += minY
+		object.Width, object.Height = maxX-minX, maxY-minY
+	}
+	return object
+}
+
+// jsonProperties contains properties in JSON format.
+type jsonProperties []struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// toMap returns JSON properties as a map. All values are converted to
+// strings.
+func (p jsonProperties) toMap() map[string]string {
+	result := make(map[string]string)
+	for _, property := range p {
+		result[property.Name] = fmt.Sprint(property.Value)
+	}
+	return result
+}
+
+// jsonTileset is a tileset in JSON format.
+type jsonTileset struct {
+	FirstGID    uint32 `json:"firstgid"`
+	Source      string `json:"source"`
+	Name        string `json:"name"`
+	TileWidth   int    `json:"tilewidth"`
+	TileHeight  int    `json:"tileheight"`
+	Spacing     int    `json:"spacing"`
+	Margin      int    `json:"margin"`
+	TileCount   int    `json:"tilecount"`
+	Columns     int    `json:"columns"`
+	Image       string `json:"image"`
+	ImageWidth  int    `json:"imagewidth"`
+	ImageHeight int    `json:"imageheight"`
+	Tiles       []struct {
+		ID         uint32         `json:"id"`
+		Properties jsonProperties `json:"properties"`
+	} `json:"tiles"`
+}
+
+// jsonLayer is a tile layer, an object group or a group in JSON format.
+type jsonLayer struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Visible     *bool           `json:"visible"`
+	Opacity     *float64        `json:"opacity"`
+	Properties  jsonProperties  `json:"properties"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Data        json.RawMessage `json:"data"`
+	Objects     []struct {
+		ID         int            `json:"id"`
+		Name       string         `json:"name"`
+		Type       string         `json:"type"`
+		Class      string         `json:"class"`
+		X          float64        `json:"x"`
+		Y          float64        `json:"y"`
+		Width      float64        `json:"width"`
+		Height     float64        `json:"height"`
+		Rotation   float64        `json:"rotation"`
+		GID        uint32         `json:"gid"`
+		Ellipse    bool           `json:"ellipse"`
+		Polygon    []*Vector      `json:"polygon"`
+		Properties jsonProperties `json:"properties"`
+	} `json:"objects"`
+	Layers []jsonLayer `json:"layers"`
+}
+
+// jsonMap is a map in JSON format.
+type jsonMap struct {
+	Orientation string         `json:"orientation"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	TileWidth   int            `json:"tilewidth"`
+	TileHeight  int            `json:"tileheight"`
+	Properties  jsonProperties `json:"properties"`
+	Tilesets    []jsonTileset  `json:"tilesets"`
+	Layers      []jsonLayer    `json:"layers"`
+}
+
+// parseTiledJSON parses a Tiled map in JSON format.
+func parseTiledJSON(data []byte, dir string) (*TiledMap, error) {
+	descriptor := &jsonMap{}
+	if err := json.Unmarshal(data, descriptor); err != nil {
+		return nil, err
+	}
+	if descriptor.Orientation != "" && descriptor.Orientation != "orthogonal" {
+		return nil, fmt.Errorf("unsupported orientation %s", descriptor.Orientation)
+	}
+	result := &TiledMap{
+		Width:      descriptor.Width,
+		Height:     descriptor.Height,
+		TileWidth:  descriptor.TileWidth,
+		TileHeight: descriptor.TileHeight,
+		Properties: descriptor.Properties.toMap(),
+		Tilesets:   []*TiledTileset{},
+		Layers:     []*TiledLayer{},
+	}
+	for _, tileset := range descriptor.Tilesets {
+		if tileset.Source == "" {
+			result.Tilesets = append(result.Tilesets, newTiledTilesetFromJSON(&tileset, dir))
+			continue
+		}
+		source := getTiledPath(dir, tileset.Source)
+		external, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		var loaded *TiledTileset
+		if strings.ToLower(filepath.Ext(source)) == ".tsx" {
+			tsx := &tmxTileset{}
+			if err := xml.Unmarshal(external, tsx); err != nil {
+				return nil, err
+			}
+			loaded = newTiledTilesetFromTMX(tsx, 0, filepath.Dir(source))
+		} else if loaded, err = parseTiledJSONTileset(external, filepath.Dir(source)); err != nil {
+			return nil, err
+		}
+		loaded.FirstGID = tileset.FirstGID
+		result.Tilesets = append(result.Tilesets, loaded)
+	}
+	sortTilesets(result.Tilesets)
+	if err := appendJSONLayers(result, descriptor.Layers); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseTiledJSONTileset parses an external tileset in JSON format.
+func parseTiledJSONTileset(data []byte, dir string) (*TiledTileset, error) {
+	tileset := &jsonTileset{}
+	if err := json.Unmarshal(data, tileset); err != nil {
+		return nil, err
+	}
+	return newTiledTilesetFromJSON(tileset, dir), nil
+}
+
+// newTiledTilesetFromJSON creates a new tileset instance from the given JSON
+// tileset.
+func newTiledTilesetFromJSON(tileset *jsonTileset, dir string) *TiledTileset {
+	result := &TiledTileset{
+		FirstGID:    tileset.FirstGID,
+		Name:        tileset.Name,
+		TileWidth:   tileset.TileWidth,
+		TileHeight:  tileset.TileHeight,
+		Spacing:     tileset.Spacing,
+		Margin:      tileset.Margin,
+		Columns:     tileset.Columns,
+		TileCount:   tileset.TileCount,
+		Image:       getTiledPath(dir, tileset.Image),
+		ImageWidth:  tileset.ImageWidth,
+		ImageHeight: tileset.ImageHeight,
+		Tiles:       make(map[uint32]*TiledTile),
+	}
+	for _, tile := range tileset.Tiles {
+		result.Tiles[tile.ID] = &TiledTile{ID: tile.ID, Properties: tile.Properties.toMap()}
+	}
+	return result
+}
+
+// appendJSONLayers appends the given JSON layers to the map. Groups are
+// flattened and image layers are not supported.
+func appendJSONLayers(m *TiledMap, layers []jsonLayer) error {
+	for _, layer := range layers {
+		result := &TiledLayer{
+			Name:       layer.Name,
+			Type:       layer.Type,
+			Width:      layer.Width,
+			Height:     layer.Height,
+			Visible:    layer.Visible == nil || *layer.Visible,
+			Opacity:    1,
+			Properties: layer.Properties.toMap(),
+		}
+		if layer.Opacity != nil {
+			result.Opacity = *layer.Opacity
+		}
+		switch layer.Type {
+		case TiledTileLayer:
+			var err error
+			if layer.Encoding == "base64" {
+				var text string
+				if err = json.Unmarshal(layer.Data, &text); err == nil {
+					result.Data, err = decodeTiledData(text, layer.Compression)
+				}
+			} else {
+				err = json.Unmarshal(layer.Data, &result.Data)
+			}
+			if err != nil {
+				return fmt.Errorf("layer %s: %w", layer.Name, err)
+			}
+		case TiledObjectGroup:
+			for _, obj := range layer.Objects {
+				object := &TiledObject{
+					ID:         obj.ID,
+					Name:       obj.Name,
+					Type:       obj.Type,
+					X:          obj.X,
+					Y:          obj.Y,
+					Width:      obj.Width,
+					Height:     obj.Height,
+					Rotation:   obj.Rotation,
+					GID:        obj.GID,
+					Ellipse:    obj.Ellipse,
+					Polygon:    obj.Polygon,
+					Properties: obj.Properties.toMap(),
+				}
+				if object.Type == "" {
+					object.Type = obj.Class
+				}
+				result.Objects = append(result.Objects, normalizeTiledObject(object))
+			}
+		case "group":
+			if err := appendJSONLayers(m, layer.Layers); err != nil {
+				return err
+			}
+			continue
+		default:
+			continue
+		}
+		m.Layers = append(m.Layers, result)
+	}
+	return nil
+}
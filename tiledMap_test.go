@@ -0,0 +1,216 @@
+package engosdl_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+)
+
+const tiledTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.9" orientation="orthogonal" width="4" height="2" tilewidth="16" tileheight="16">
+	<tileset firstgid="1" name="tiles" tilewidth="16" tileheight="16" tilecount="4" columns="2">
+		<image source="tiles.png" width="32" height="32"/>
+		<tile id="1">
+			<properties><property name="collision" type="bool" value="true"/></properties>
+		</tile>
+	</tileset>
+	<layer id="1" name="ground" width="4" height="2">
+		<data encoding="csv">1,1,1,1,
+2,2,0,2147483650</data>
+	</layer>
+	<group name="group">
+		<objectgroup name="objects">
+			<object id="1" name="door" type="exit" x="16" y="0" width="16" height="16">
+				<properties><property name="collision" type="bool" value="true"/></properties>
+			</object>
+			<object id="2" x="10" y="10"><polygon points="0,0 8,-4 8,4"/></object>
+		</objectgroup>
+	</group>
+	<layer id="2" name="top" width="4" height="2" visible="0" opacity="0.5">
+		<data encoding="base64" compression="zlib">%s</data>
+	</layer>
+</map>`
+
+const tiledJSON = `{
+	"orientation": "orthogonal", "width": 4, "height": 2, "tilewidth": 16, "tileheight": 16,
+	"tilesets": [{"firstgid": 1, "name": "tiles", "tilewidth": 16, "tileheight": 16, "tilecount": 4, "columns": 2,
+		"image": "tiles.png", "imagewidth": 32, "imageheight": 32,
+		"tiles": [{"id": 1, "properties": [{"name": "collision", "type": "bool", "value": true}]}]}],
+	"layers": [
+		{"type": "tilelayer", "name": "ground", "width": 4, "height": 2, "data": [1, 1, 1, 1, 2, 2, 0, 2147483650]},
+		{"type": "group", "name": "group", "layers": [
+			{"type": "objectgroup", "name": "objects", "objects": [
+				{"id": 1, "name": "door", "type": "exit", "x": 16, "y": 0, "width": 16, "height": 16,
+					"properties": [{"name": "collision", "type": "bool", "value": true}]},
+				{"id": 2, "x": 10, "y": 10, "polygon": [{"x": 0, "y": 0}, {"x": 8, "y": -4}, {"x": 8, "y": 4}]}]}]},
+		{"type": "tilelayer", "name": "top", "width": 4, "height": 2, "visible": false, "opacity": 0.5,
+			"encoding": "base64", "compression": "zlib", "data": "%s"}
+	]
+}`
+
+// encodeTiles returns the given tiles encoded in base64 and compressed with
+// zlib.
+func encodeTiles(tiles []uint32) string {
+	raw := make([]byte, len(tiles)*4)
+	for i, tile := range tiles {
+		binary.LittleEndian.PutUint32(raw[i*4:], tile)
+	}
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	writer.Write(raw)
+	writer.Close()
+	return base64.StdEncoding.EncodeToString(buffer.Bytes())
+}
+
+// writeBMP writes a 24 bits uncompressed BMP image with the given size.
+func writeBMP(t *testing.T, filename string, width int, height int) {
+	pixels := make([]byte, ((width*3+3)/4*4)*height)
+	var buffer bytes.Buffer
+	buffer.WriteString("BM")
+	for _, value := range []uint32{uint32(54 + len(pixels)), 0, 54, 40, uint32(width), uint32(height)} {
+		binary.Write(&buffer, binary.LittleEndian, value)
+	}
+	binary.Write(&buffer, binary.LittleEndian, []uint16{1, 24})
+	for _, value := range []uint32{0, uint32(len(pixels)), 2835, 2835, 0, 0} {
+		binary.Write(&buffer, binary.LittleEndian, value)
+	}
+	buffer.Write(pixels)
+	if err := ioutil.WriteFile(filename, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTiledMap_Parse(t *testing.T) {
+	top := []uint32{0, 3, 4, 0, 0, 0, 0, 0}
+	for name, data := range map[string]string{"tmx": tiledTMX, "json": tiledJSON} {
+		tiledMap, err := engosdl.ParseTiledMap([]byte(fmt.Sprintf(data, encodeTiles(top))), "maps")
+		if err != nil {
+			t.Errorf("error parsing %s map: %v", name, err)
+			continue
+		}
+		if len(tiledMap.Tilesets) != 1 || tiledMap.Tilesets[0].Image != filepath.Join("maps", "tiles.png") {
+			t.Errorf("error parsing %s tilesets\nexp: %d\ngot: %d\n", name, 1, len(tiledMap.Tilesets))
+			continue
+		}
+		names := []string{}
+		for _, layer := range tiledMap.Layers {
+			names = append(names, layer.Name)
+		}
+		if exp := []string{"ground", "objects", "top"}; !reflect.DeepEqual(exp, names) {
+			t.Errorf("error parsing %s layers\nexp: %v\ngot: %v\n", name, exp, names)
+			continue
+		}
+		ground, objects, topLayer := tiledMap.Layers[0], tiledMap.Layers[1], tiledMap.Layers[2]
+		if exp := []uint32{1, 1, 1, 1, 2, 2, 0, 2147483650}; !reflect.DeepEqual(exp, ground.Data) {
+			t.Errorf("error parsing %s tiles\nexp: %v\ngot: %v\n", name, exp, ground.Data)
+		}
+		if !reflect.DeepEqual(top, topLayer.Data) || topLayer.Visible || topLayer.Opacity != 0.5 {
+			t.Errorf("error parsing %s encoded layer\nexp: %v\ngot: %v\n", name, top, topLayer.Data)
+		}
+		if !tiledMap.GetTileCollision(ground.GetTile(3, 1)) || tiledMap.GetTileCollision(ground.GetTile(0, 0)) {
+			t.Errorf("error getting %s tile collision", name)
+		}
+		if rect := tiledMap.Tilesets[0].GetTileRect(3); rect.X != 16 || rect.Y != 16 {
+			t.Errorf("error getting %s tile rectangle\nexp: %v\ngot: %v\n", name, engosdl.NewRect(16, 16, 16, 16), rect)
+		}
+		exp := []*engosdl.Rect{engosdl.NewRect(0, 16, 32, 16), engosdl.NewRect(48, 16, 16, 16)}
+		if got := tiledMap.GetCollisionRects(); !reflect.DeepEqual(exp, got) {
+			t.Errorf("error getting %s collision rectangles\nexp: %v %v\ngot: %v\n", name, exp[0], exp[1], got)
+		}
+		if len(objects.Objects) != 2 {
+			t.Errorf("error parsing %s objects\nexp: %d\ngot: %d\n", name, 2, len(objects.Objects))
+			continue
+		}
+		door, polygon := objects.Objects[0], objects.Objects[1]
+		if door.Name != "door" || door.Type != "exit" || !door.GetCollision() {
+			t.Errorf("error parsing %s object\nexp: %s %s\ngot: %s %s\n", name, "door", "exit", door.Name, door.Type)
+		}
+		if polygon.Y != 6 || polygon.Height != 8 || !equalVector(polygon.Polygon[1], engosdl.NewVector(8, 0)) {
+			t.Errorf("error parsing %s polygon\nexp: %f %f\ngot: %f %f\n", name, 6.0, 8.0, polygon.Y, polygon.Height)
+		}
+	}
+}
+
+func TestTileMap_Component(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tiled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Map is bigger than the display, 40x20 cells of 16 pixels.
+	tiles := strings.TrimSuffix(strings.Repeat("1,", 40*20), ",")
+	data := fmt.Sprintf(`{"width": 40, "height": 20, "tilewidth": 16, "tileheight": 16,
+		"tilesets": [{"firstgid": 1, "tilewidth": 16, "tileheight": 16, "columns": 2, "image": "tiles.bmp",
+			"tiles": [{"id": 0, "properties": [{"name": "collision", "value": false}]}]}],
+		"layers": [{"type": "tilelayer", "name": "ground", "width": 40, "height": 20, "data": [%s]},
+			{"type": "objectgroup", "name": "objects", "objects": [
+				{"id": 1, "name": "door", "type": "exit", "x": 32, "y": 48, "width": 16, "height": 16,
+					"properties": [{"name": "collision", "value": true}, {"name": "target", "value": "level-2"}]}]}]}`, tiles)
+	filename := filepath.Join(dir, "map.json")
+	if err := ioutil.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeBMP(t, filepath.Join(dir, "tiles.bmp"), 32, 16)
+	engine := newTestEngine(t)
+	var tileMap *components.TileMap
+	handled := 0
+	scene := engosdl.NewScene("test-tile-map-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		board := engosdl.NewEntity("board")
+		board.GetTransform().SetPositionXY(100, 0)
+		tileMap = components.NewTileMap("board/tile-map", filename)
+		tileMap.SetObjectHandler(func(entity engosdl.IEntity, object *engosdl.TiledObject) {
+			handled++
+		})
+		board.AddComponent(tileMap)
+		scene.AddEntity(board)
+		return true
+	})
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 3)
+	door := scene.GetEntityByName("door")
+	if door == nil || handled != 1 {
+		t.Fatalf("error creating object entity\nexp: %d\ngot: %d\n", 1, handled)
+	}
+	if x, y := door.GetTransform().GetPosition().Get(); x != 132 || y != 48 {
+		t.Errorf("error placing object entity\nexp: %f %f\ngot: %f %f\n", 132.0, 48.0, x, y)
+	}
+	if target, err := door.GetCache("target"); err != nil || target != "level-2" {
+		t.Errorf("error getting object property\nexp: %s\ngot: %v\n", "level-2", target)
+	}
+	if door.GetComponent(&components.Collider2D{}) == nil {
+		t.Errorf("error creating object collider")
+	}
+	if col, row := tileMap.GetCell(engosdl.NewVector(140, 40)); col != 2 || row != 2 {
+		t.Errorf("error getting cell\nexp: %d %d\ngot: %d %d\n", 2, 2, col, row)
+	}
+	// Visible cells are from column 0 to 18 and from row 0 to 18.
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	copies := 0
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawCopy {
+			copies++
+		}
+	}
+	if exp := 19 * 19; copies != exp {
+		t.Errorf("error rendering visible tiles\nexp: %d\ngot: %d\n", exp, copies)
+	}
+
+	// Entities created by the tile map are destroyed with the tile map.
+	engine.DestroyEntity(scene.GetEntityByName("board"))
+	engine.DoRunFrames(1)
+	if len(scene.GetEntities()) != 0 || len(door.GetComponents()) != 0 {
+		t.Errorf("error destroying tile map entities\nexp: %d\ngot: %d\n", 0, len(scene.GetEntities()))
+	}
+}
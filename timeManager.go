@@ -0,0 +1,125 @@
+package engosdl
+
+// ITimeManager represents the interface for the time manager. Time manager
+// tracks real time elapsed between frames and splits it in fixed update
+// steps.
+type ITimeManager interface {
+	IObject
+	DoInit()
+	GetAlpha() float64
+	GetDeltaTime() float64
+	GetFixedDeltaTime() float64
+	GetFrameCount() int
+	GetStepCount() int
+	GetTime() float64
+	NextStep() bool
+	OnStart()
+	SetFixedDeltaTime(float64)
+	Tick(float64)
+}
+
+// TimeManager is the default implementation for the time manager interface.
+// Every engine frame calls Tick with the real elapsed time, which is added
+// to an accumulator. NextStep consumes the accumulator in fixed delta time
+// steps, so game updates run at the same rate independently of the machine
+// load. Any remaining time in the accumulator is exposed as the
+// interpolation alpha to be used when rendering.
+type TimeManager struct {
+	*Object
+	fixedDeltaTime float64
+	accumulator    float64
+	time           float64
+	frameCount     int
+	stepCount      int
+}
+
+var _ ITimeManager = (*TimeManager)(nil)
+
+// NewTimeManager creates a new time manager instance.
+func NewTimeManager(name string) *TimeManager {
+	Logger.Trace().Str("time-manager", name).Msg("new time-manager")
+	return &TimeManager{
+		Object:         NewObject(name),
+		fixedDeltaTime: _fixedDeltaTime,
+	}
+}
+
+// DoInit initializes all time manager resources.
+func (h *TimeManager) DoInit() {
+	Logger.Trace().Str("time-manager", h.GetName()).Msg("DoInit")
+}
+
+// GetAlpha returns the interpolation factor between the last two update
+// steps, in the range [0, 1). It is used by render methods to interpolate
+// between previous and current state, like transform GetRenderRect.
+func (h *TimeManager) GetAlpha() float64 {
+	return h.accumulator / h.fixedDeltaTime
+}
+
+// GetDeltaTime returns the time in seconds to be used in every update step.
+// Update steps run with a fixed delta time, so this is the same value
+// returned by GetFixedDeltaTime.
+func (h *TimeManager) GetDeltaTime() float64 {
+	return h.fixedDeltaTime
+}
+
+// GetFixedDeltaTime returns the fixed update step in seconds.
+func (h *TimeManager) GetFixedDeltaTime() float64 {
+	return h.fixedDeltaTime
+}
+
+// GetFrameCount returns the number of engine frames elapsed.
+func (h *TimeManager) GetFrameCount() int {
+	return h.frameCount
+}
+
+// GetStepCount returns the number of update steps executed.
+func (h *TimeManager) GetStepCount() int {
+	return h.stepCount
+}
+
+// GetTime returns the time in seconds elapsed since the engine started.
+func (h *TimeManager) GetTime() float64 {
+	return h.time
+}
+
+// NextStep returns true if there is enough time accumulated to run a new
+// update step. Time for the step is consumed from the accumulator.
+func (h *TimeManager) NextStep() bool {
+	if h.accumulator >= h.fixedDeltaTime {
+		h.accumulator -= h.fixedDeltaTime
+		h.stepCount++
+		return true
+	}
+	return false
+}
+
+// OnStart initializes all time manager structures.
+func (h *TimeManager) OnStart() {
+	Logger.Trace().Str("time-manager", h.GetName()).Msg("OnStart")
+	h.accumulator = 0
+	h.time = 0
+	h.frameCount = 0
+	h.stepCount = 0
+}
+
+// SetFixedDeltaTime sets the fixed update step in seconds.
+func (h *TimeManager) SetFixedDeltaTime(delta float64) {
+	if delta > 0 {
+		h.fixedDeltaTime = delta
+	}
+}
+
+// Tick adds the real time in seconds elapsed from the previous frame.
+// Elapsed time is clamped to avoid running a huge number of update steps
+// after a long hitch.
+func (h *TimeManager) Tick(elapsed float64) {
+	if elapsed < 0 {
+		elapsed = 0
+	} else if elapsed > _maxFrameTime {
+		elapsed = _maxFrameTime
+	}
+	h.time += elapsed
+	h.accumulator += elapsed
+	h.frameCount++
+}
@@ -0,0 +1,129 @@
+package engosdl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+func TestTimeManager_FixedSteps(t *testing.T) {
+	h := engosdl.NewTimeManager("test-time-manager")
+	if h == nil {
+		t.Error("error creating time manager")
+	}
+	h.SetFixedDeltaTime(0.0625)
+	h.OnStart()
+	if h.NextStep() {
+		t.Errorf("error running step without elapsed time")
+	}
+	h.Tick(0.15625)
+	steps := 0
+	for h.NextStep() {
+		steps++
+	}
+	if steps != 2 {
+		t.Errorf("error getting number of steps\nexp: %d\ngot: %d\n", 2, steps)
+	}
+	if alpha := h.GetAlpha(); alpha != 0.5 {
+		t.Errorf("error getting alpha\nexp: %f\ngot: %f\n", 0.5, alpha)
+	}
+	h.Tick(0.03125)
+	if !h.NextStep() {
+		t.Errorf("error running step with accumulated time")
+	}
+	if h.GetStepCount() != 3 {
+		t.Errorf("error getting step count\nexp: %d\ngot: %d\n", 3, h.GetStepCount())
+	}
+	if h.GetFrameCount() != 2 {
+		t.Errorf("error getting frame count\nexp: %d\ngot: %d\n", 2, h.GetFrameCount())
+	}
+	if h.GetDeltaTime() != 0.0625 {
+		t.Errorf("error getting delta time\nexp: %f\ngot: %f\n", 0.0625, h.GetDeltaTime())
+	}
+}
+
+func TestTimeManager_ClampElapsed(t *testing.T) {
+	h := engosdl.NewTimeManager("test-time-manager")
+	h.SetFixedDeltaTime(0.0625)
+	h.OnStart()
+	h.Tick(10)
+	steps := 0
+	for h.NextStep() {
+		steps++
+	}
+	if steps != 4 {
+		t.Errorf("error clamping elapsed time\nexp: %d\ngot: %d\n", 4, steps)
+	}
+}
+
+func TestTimer_FixedSteps(t *testing.T) {
+	calls := map[string]int{}
+	scene := engosdl.NewScene("test-timer-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		for _, tick := range []float64{0.2, 1.0} {
+			name := fmt.Sprintf("timer-%.1f", tick)
+			entity := engosdl.NewEntity(name)
+			listener := engosdl.NewComponent(name + "/listener")
+			listener.AddDelegateToRegister(nil, nil, &components.Timer{}, func(...interface{}) bool {
+				calls[name]++
+				return true
+			})
+			entity.AddComponent(components.NewTimer(name+"/timer", tick, -1))
+			entity.AddComponent(listener)
+			scene.AddEntity(entity)
+		}
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	// First frame activates the scene, next 60 frames run two seconds.
+	engine.RunEngineFrames(scene, 61)
+	if calls["timer-0.2"] != 10 || calls["timer-1.0"] != 2 {
+		t.Errorf("error triggering timers at fixed steps\nexp: %d %d\ngot: %d %d\n", 10, 2, calls["timer-0.2"], calls["timer-1.0"])
+	}
+}
+
+func TestTimeManager_RenderAlpha(t *testing.T) {
+	red := sdl.Color{R: 255, A: 255}
+	scene := engosdl.NewScene("test-alpha-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity := engosdl.NewEntity("box")
+		entity.AddComponent(components.NewBox("box/box", engosdl.NewRect(0, 0, 10, 10), red, true))
+		entity.SetCustomOnUpdate(func(entity engosdl.IEntity) {
+			x, y := entity.GetTransform().GetPosition().Get()
+			entity.GetTransform().SetPositionXY(x+10, y)
+		})
+		scene.AddEntity(entity)
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 2)
+	// Half of a fixed step is left in the accumulator, so the box is
+	// rendered halfway between the last two update steps.
+	box := scene.GetEntityByName("box")
+	engine.DoFrame(engine.GetTimeManager().GetFixedDeltaTime() * 1.5)
+	x := box.GetTransform().GetPosition().X
+	if alpha := engine.GetTimeManager().GetAlpha(); alpha < 0.49 || alpha > 0.51 {
+		t.Fatalf("error getting interpolation alpha\nexp: %f\ngot: %f\n", 0.5, alpha)
+	}
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	fills := 0
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Op == engosdl.DrawFillRect {
+			fills++
+			if drawCall.Dst.X != int32(x-5) {
+				t.Errorf("error rendering interpolated position\nexp: %d\ngot: %d\n", int32(x-5), drawCall.Dst.X)
+			}
+		}
+	}
+	if fills != 1 {
+		t.Errorf("error rendering box\nexp: %d\ngot: %d\n", 1, fills)
+	}
+	if tick := components.CreateTimer("timer", 2, 1).(*components.Timer).GetTick(); tick != 2 {
+		t.Errorf("error creating timer with int tick\nexp: %f\ngot: %f\n", 2.0, tick)
+	}
+}
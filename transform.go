@@ -1,28 +1,73 @@
 package engosdl
 
+import "fmt"
+
 // ITransform represents the interface for any entity transformation.
+// Position, rotation and scale are local to the parent transform. World
+// values compose all transforms up in the hierarchy. For a transform without
+// parent, local and world values are the same.
 type ITransform interface {
 	GetDim() *Vector
+	GetLocalPosition() *Vector
+	GetLocalRotation() float64
+	GetLocalScale() *Vector
+	GetParent() ITransform
 	GetPosition() *Vector
 	GetRect() *Rect
 	GetRectExt() (float64, float64, float64, float64)
+	GetRenderRect() *Rect
 	GetRotation() float64
 	GetScale() *Vector
+	GetWorldMatrix() *Matrix
+	GetWorldPosition() *Vector
+	GetWorldRotation() float64
+	GetWorldScale() *Vector
+	SaveStep()
 	SetDim(*Vector) ITransform
 	SetDimXY(float64, float64) ITransform
+	SetLocalPosition(*Vector) ITransform
+	SetParent(ITransform) ITransform
 	SetPosition(*Vector) ITransform
 	SetPositionXY(float64, float64) ITransform
 	SetRotation(float64) ITransform
 	SetScale(*Vector) ITransform
 	SetScaleXY(float64, float64) ITransform
+	SetWorldPosition(*Vector) ITransform
+}
+
+// transformState keeps local values used to compute the cached world
+// matrix. It is used to detect local values updated in place.
+type transformState struct {
+	positionX float64
+	positionY float64
+	rotation  float64
+	scaleX    float64
+	scaleY    float64
 }
 
+// transformVersion is increased every time any transform is changed, so
+// scene queries can detect colliders moved after they were updated.
+var transformVersion uint64
+
 // Transform is the default implementation for ITransform interface.
+// World matrix is cached and it is computed again only when the transform
+// is dirty, any local value has changed or the parent world matrix has
+// changed, so any change is propagated down to all children. World
+// rectangle is saved at the start of every update step, so it can be
+// interpolated when rendering.
 type Transform struct {
-	Position *Vector `json:"position"`
-	Rotation float64 `json:"rotation"`
-	Scale    *Vector `json:"scale"`
-	Dim      *Vector `json:"dimension"`
+	Position      *Vector `json:"position"`
+	Rotation      float64 `json:"rotation"`
+	Scale         *Vector `json:"scale"`
+	Dim           *Vector `json:"dimension"`
+	parent        ITransform
+	dirty         bool
+	state         transformState
+	parentMatrix  *Matrix
+	worldMatrix   *Matrix
+	worldRotation float64
+	worldScale    *Vector
+	previous      *Rect
 }
 
 // NewTransform creates a new transform instance.
@@ -32,7 +77,46 @@ func NewTransform() *Transform {
 		Rotation: 0,
 		Scale:    NewVector(1, 1),
 		Dim:      NewVector(0, 0),
+		dirty:    true,
+	}
+}
+
+// getState returns transform local values.
+func (t *Transform) getState() transformState {
+	return transformState{
+		positionX: t.Position.X,
+		positionY: t.Position.Y,
+		rotation:  t.Rotation,
+		scaleX:    t.Scale.X,
+		scaleY:    t.Scale.Y,
+	}
+}
+
+// update computes world matrix, rotation and scale if they are not valid
+// anymore.
+func (t *Transform) update() {
+	var parentMatrix *Matrix
+	if t.parent != nil {
+		parentMatrix = t.parent.GetWorldMatrix()
+	}
+	state := t.getState()
+	if !t.dirty && t.worldMatrix != nil && state == t.state && parentMatrix == t.parentMatrix {
+		return
+	}
+	local := NewTRSMatrix(t.Position, t.Rotation, t.Scale)
+	if t.parent != nil {
+		parentScale := t.parent.GetWorldScale()
+		t.worldMatrix = parentMatrix.Multiply(local)
+		t.worldRotation = t.parent.GetWorldRotation() + t.Rotation
+		t.worldScale = NewVector(parentScale.X*t.Scale.X, parentScale.Y*t.Scale.Y)
+	} else {
+		t.worldMatrix = local
+		t.worldRotation = t.Rotation
+		t.worldScale = NewVector(t.Scale.X, t.Scale.Y)
 	}
+	t.state = state
+	t.parentMatrix = parentMatrix
+	t.dirty = false
 }
 
 //GetDim returns the transform original dimensions.
@@ -40,40 +124,103 @@ func (t *Transform) GetDim() *Vector {
 	return t.Dim
 }
 
-// GetPosition returns the transform position.
+// GetLocalPosition returns the transform position relative to the parent.
+func (t *Transform) GetLocalPosition() *Vector {
+	return t.Position
+}
+
+// GetLocalRotation returns the transform rotation relative to the parent.
+func (t *Transform) GetLocalRotation() float64 {
+	return t.Rotation
+}
+
+// GetLocalScale returns the transform scale relative to the parent.
+func (t *Transform) GetLocalScale() *Vector {
+	return t.Scale
+}
+
+// GetParent returns the parent transform.
+func (t *Transform) GetParent() ITransform {
+	return t.parent
+}
+
+// GetPosition returns the transform local position. It is the world
+// position only when transform has no parent, use GetWorldPosition
+// otherwise.
 func (t *Transform) GetPosition() *Vector {
 	return t.Position
 }
 
-// GetRect returns a rectangle with real position and dimensions.
-// Real dimensions are affected by the scale value.
+// GetRect returns a rectangle with real world position and dimensions.
+// Real dimensions are affected by the world scale value.
 func (t *Transform) GetRect() *Rect {
-	return &Rect{
-		X: t.GetPosition().X,
-		Y: t.GetPosition().Y,
-		W: t.GetDim().X * t.GetScale().X,
-		H: t.GetDim().Y * t.GetScale().Y,
-	}
+	x, y, w, h := t.GetRectExt()
+	return &Rect{X: x, Y: y, W: w, H: h}
 }
 
-// GetRectExt returns rectangle coordinates as x, y, w, and h.
+// GetRectExt returns world rectangle coordinates as x, y, w, and h.
 func (t *Transform) GetRectExt() (float64, float64, float64, float64) {
-	return t.GetPosition().X, t.GetPosition().Y, t.GetDim().X * t.GetScale().X, t.GetDim().Y * t.GetScale().Y
+	position := t.GetWorldPosition()
+	scale := t.GetWorldScale()
+	return position.X, position.Y, t.GetDim().X * scale.X, t.GetDim().Y * scale.Y
 }
 
-// GetRotation returns the transform rotation.
+// GetRenderRect returns the world rectangle to be used when rendering. It is
+// interpolated between the rectangle saved at the start of the last update
+// step and the current one, using the time manager interpolation alpha.
+func (t *Transform) GetRenderRect() *Rect {
+	rect := t.GetRect()
+	if t.previous == nil {
+		return rect
+	}
+	return t.previous.Lerp(rect, GetAlpha())
+}
+
+// GetRotation returns the transform local rotation.
 func (t *Transform) GetRotation() float64 {
 	return t.Rotation
 }
 
-// GetScale returns the transform scale.
+// GetScale returns the transform local scale.
 func (t *Transform) GetScale() *Vector {
 	return t.Scale
 }
 
+// GetWorldMatrix returns the matrix converting local coordinates to world
+// coordinates.
+func (t *Transform) GetWorldMatrix() *Matrix {
+	t.update()
+	return t.worldMatrix
+}
+
+// GetWorldPosition returns the transform position in world coordinates.
+func (t *Transform) GetWorldPosition() *Vector {
+	t.update()
+	return NewVector(t.worldMatrix.Tx, t.worldMatrix.Ty)
+}
+
+// GetWorldRotation returns the transform rotation in world coordinates.
+func (t *Transform) GetWorldRotation() float64 {
+	t.update()
+	return t.worldRotation
+}
+
+// GetWorldScale returns the transform scale in world coordinates.
+func (t *Transform) GetWorldScale() *Vector {
+	t.update()
+	return NewVector(t.worldScale.X, t.worldScale.Y)
+}
+
+// SaveStep saves the world rectangle at the start of an update step, so it
+// is interpolated with the rectangle at the end of the step when rendering.
+func (t *Transform) SaveStep() {
+	t.previous = t.GetRect()
+}
+
 // SetDim sets the transform original dimensions.
 func (t *Transform) SetDim(v *Vector) ITransform {
 	t.Dim = v
+	transformVersion++
 	return t
 }
 
@@ -83,9 +230,32 @@ func (t *Transform) SetDimXY(x float64, y float64) ITransform {
 	return t.SetDim(NewVector(x, y))
 }
 
-// SetPosition sets the transform position.
+// SetLocalPosition sets the transform position relative to the parent.
+func (t *Transform) SetLocalPosition(v *Vector) ITransform {
+	return t.SetPosition(v)
+}
+
+// SetParent sets the parent transform. Local values are not changed, so
+// world values will change if the new parent is not at the origin. Parent is
+// not set if it would create a cycle in the hierarchy.
+func (t *Transform) SetParent(parent ITransform) ITransform {
+	for p := parent; p != nil; p = p.GetParent() {
+		if p == ITransform(t) {
+			Logger.Error().Err(fmt.Errorf("transform parent creates a cycle")).Msg("SetParent error")
+			return t
+		}
+	}
+	t.parent = parent
+	t.dirty = true
+	transformVersion++
+	return t
+}
+
+// SetPosition sets the transform local position.
 func (t *Transform) SetPosition(v *Vector) ITransform {
 	t.Position = v
+	t.dirty = true
+	transformVersion++
 	return t
 }
 
@@ -95,15 +265,19 @@ func (t *Transform) SetPositionXY(x float64, y float64) ITransform {
 	return t.SetPosition(NewVector(x, y))
 }
 
-// SetRotation sets the transform rotation.
+// SetRotation sets the transform local rotation.
 func (t *Transform) SetRotation(r float64) ITransform {
 	t.Rotation = r
+	t.dirty = true
+	transformVersion++
 	return t
 }
 
-// SetScale sets the transform scale.
+// SetScale sets the transform local scale.
 func (t *Transform) SetScale(v *Vector) ITransform {
 	t.Scale = v
+	t.dirty = true
+	transformVersion++
 	return t
 }
 
@@ -112,3 +286,15 @@ func (t *Transform) SetScale(v *Vector) ITransform {
 func (t *Transform) SetScaleXY(x float64, y float64) ITransform {
 	return t.SetScale(NewVector(x, y))
 }
+
+// SetWorldPosition sets the transform position in world coordinates. Local
+// position is calculated using the parent world matrix.
+func (t *Transform) SetWorldPosition(v *Vector) ITransform {
+	if t.parent == nil {
+		return t.SetPosition(NewVector(v.X, v.Y))
+	}
+	if inverse := t.parent.GetWorldMatrix().Inverse(); inverse != nil {
+		return t.SetPosition(inverse.Apply(v))
+	}
+	return t
+}
@@ -0,0 +1,76 @@
+package engosdl_test
+
+import (
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+)
+
+func TestTransform_Hierarchy(t *testing.T) {
+	parent := engosdl.NewEntity("parent")
+	child := engosdl.NewEntity("child")
+	parent.GetTransform().SetPositionXY(100, 50)
+	child.GetTransform().SetPositionXY(10, 0)
+	parent.AddChild(child)
+	if got := child.GetTransform().GetWorldPosition(); !equalVector(got, engosdl.NewVector(110, 50)) {
+		t.Errorf("error getting world position\nexp: %v\ngot: %v\n", engosdl.NewVector(110, 50), got)
+	}
+	if got := child.GetTransform().GetLocalPosition(); !equalVector(got, engosdl.NewVector(10, 0)) {
+		t.Errorf("error getting local position\nexp: %v\ngot: %v\n", engosdl.NewVector(10, 0), got)
+	}
+
+	parent.GetTransform().SetRotation(90).SetScaleXY(2, 2)
+	if got := child.GetTransform().GetWorldPosition(); !equalVector(got, engosdl.NewVector(100, 70)) {
+		t.Errorf("error getting rotated world position\nexp: %v\ngot: %v\n", engosdl.NewVector(100, 70), got)
+	}
+	if got := child.GetTransform().GetWorldRotation(); got != 90 {
+		t.Errorf("error getting world rotation\nexp: %f\ngot: %f\n", 90.0, got)
+	}
+	if got := child.GetTransform().GetWorldScale(); !equalVector(got, engosdl.NewVector(2, 2)) {
+		t.Errorf("error getting world scale\nexp: %v\ngot: %v\n", engosdl.NewVector(2, 2), got)
+	}
+
+	// Position updated in place has to be propagated to children.
+	parent.GetTransform().SetRotation(0).SetScaleXY(1, 1)
+	parent.GetTransform().GetPosition().X += 5
+	if got := child.GetTransform().GetWorldPosition(); !equalVector(got, engosdl.NewVector(115, 50)) {
+		t.Errorf("error propagating parent position\nexp: %v\ngot: %v\n", engosdl.NewVector(115, 50), got)
+	}
+	child.GetTransform().SetDimXY(4, 4)
+	if rect := child.GetTransform().GetRect(); rect.X != 115 || rect.Y != 50 || rect.W != 4 {
+		t.Errorf("error getting world rectangle\nexp: %v\ngot: %v\n", &engosdl.Rect{X: 115, Y: 50, W: 4, H: 4}, rect)
+	}
+}
+
+func TestTransform_SetWorldPosition(t *testing.T) {
+	parent := engosdl.NewTransform()
+	parent.SetPositionXY(20, 20).SetRotation(90).SetScaleXY(2, 2)
+	child := engosdl.NewTransform()
+	child.SetParent(parent)
+	child.SetWorldPosition(engosdl.NewVector(20, 40))
+	if got := child.GetLocalPosition(); !equalVector(got, engosdl.NewVector(10, 0)) {
+		t.Errorf("error setting world position\nexp: %v\ngot: %v\n", engosdl.NewVector(10, 0), got)
+	}
+	if got := child.GetWorldPosition(); !equalVector(got, engosdl.NewVector(20, 40)) {
+		t.Errorf("error getting world position\nexp: %v\ngot: %v\n", engosdl.NewVector(20, 40), got)
+	}
+	parent.SetParent(child)
+	if parent.GetParent() != nil {
+		t.Errorf("error setting parent creating a cycle")
+	}
+}
+
+func TestTransform_DeleteChild(t *testing.T) {
+	parent := engosdl.NewEntity("parent")
+	child := engosdl.NewEntity("child")
+	parent.GetTransform().SetPositionXY(30, 30)
+	child.GetTransform().SetPositionXY(5, 5)
+	parent.AddChild(child)
+	parent.DeleteChild(child.GetID())
+	if child.GetTransform().GetParent() != nil {
+		t.Errorf("error removing parent transform")
+	}
+	if got := child.GetTransform().GetPosition(); !equalVector(got, engosdl.NewVector(35, 35)) {
+		t.Errorf("error keeping world position\nexp: %v\ngot: %v\n", engosdl.NewVector(35, 35), got)
+	}
+}
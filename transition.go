@@ -0,0 +1,223 @@
+package engosdl
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// TransitionRenderFunc represents the function that renders a transition
+// frame. From texture contains the scene being left and to texture contains
+// the scene being entered. Progress goes from 0 to 1.
+type TransitionRenderFunc func(renderer IRenderer, from ITexture, to ITexture, progress float64)
+
+// ITransition represents the interface for any scene transition. Scene
+// manager renders the scene being left in a texture when the transition
+// starts and the scene being entered in another texture every frame, and
+// the transition renders both of them in the display.
+type ITransition interface {
+	IObject
+	GetDuration() float64
+	GetProgress() float64
+	IsFinished() bool
+	OnEnd(func(ITransition)) ITransition
+	OnMidpoint(func(ITransition)) ITransition
+	Render(IRenderer, ITexture, ITexture)
+	Start()
+	Update(float64) bool
+}
+
+// Transition is the default implementation for the transition interface.
+// Midpoint functions are called when the transition reaches half of its
+// duration and end functions are called when it ends.
+type Transition struct {
+	*Object
+	duration   float64
+	elapsed    float64
+	midpoint   bool
+	finished   bool
+	render     TransitionRenderFunc
+	onMidpoint []func(ITransition)
+	onEnd      []func(ITransition)
+}
+
+var _ ITransition = (*Transition)(nil)
+
+// NewTransition creates a new transition instance with the given duration in
+// seconds. Render function is used to render every transition frame, so it
+// can be used to create custom transitions.
+func NewTransition(name string, duration float64, render TransitionRenderFunc) *Transition {
+	Logger.Trace().Str("transition", name).Msg("new transition")
+	return &Transition{
+		Object:     NewObject(name),
+		duration:   duration,
+		elapsed:    0,
+		midpoint:   false,
+		finished:   false,
+		render:     render,
+		onMidpoint: []func(ITransition){},
+		onEnd:      []func(ITransition){},
+	}
+}
+
+// NewCrossfadeTransition creates a new transition that fades out the scene
+// being left while the scene being entered fades in.
+func NewCrossfadeTransition(name string, duration float64) *Transition {
+	return NewTransition(name, duration, func(renderer IRenderer, from ITexture, to ITexture, progress float64) {
+		renderTransitionTexture(renderer, from, nil, nil, 255)
+		renderTransitionTexture(renderer, to, nil, nil, uint8(math.Round(progress*255)))
+	})
+}
+
+// NewFadeTransition creates a new transition that fades the scene being left
+// to the given color, and then it fades from that color to the scene being
+// entered.
+func NewFadeTransition(name string, duration float64, color sdl.Color) *Transition {
+	return NewTransition(name, duration, func(renderer IRenderer, from ITexture, to ITexture, progress float64) {
+		alpha := progress * 2
+		if progress < 0.5 {
+			renderTransitionTexture(renderer, from, nil, nil, 255)
+		} else {
+			renderTransitionTexture(renderer, to, nil, nil, 255)
+			alpha = 2 - alpha
+		}
+		renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+		renderer.SetDrawColor(color.R, color.G, color.B, uint8(math.Round(alpha*float64(color.A))))
+		renderer.FillRect(nil)
+	})
+}
+
+// NewSlideTransition creates a new transition where the scene being entered
+// pushes the scene being left out of the display. Direction is the movement
+// direction, MoveLeft, MoveRight, MoveUp or MoveDown.
+func NewSlideTransition(name string, duration float64, direction int) *Transition {
+	return NewTransition(name, duration, func(renderer IRenderer, from ITexture, to ITexture, progress float64) {
+		_, _, w, h, _ := to.Query()
+		dx, dy := getTransitionOffset(direction, w, h, progress)
+		renderTransitionTexture(renderer, from, nil, &sdl.Rect{X: dx, Y: dy, W: w, H: h}, 255)
+		dx, dy = getTransitionOffset(direction, w, h, progress-1)
+		renderTransitionTexture(renderer, to, nil, &sdl.Rect{X: dx, Y: dy, W: w, H: h}, 255)
+	})
+}
+
+// NewWipeTransition creates a new transition where the scene being entered
+// is uncovered over the scene being left. Direction is the wipe direction,
+// MoveLeft, MoveRight, MoveUp or MoveDown.
+func NewWipeTransition(name string, duration float64, direction int) *Transition {
+	return NewTransition(name, duration, func(renderer IRenderer, from ITexture, to ITexture, progress float64) {
+		_, _, w, h, _ := to.Query()
+		renderTransitionTexture(renderer, from, nil, nil, 255)
+		rect := &sdl.Rect{X: 0, Y: 0, W: w, H: h}
+		switch direction {
+		case MoveLeft:
+			rect.W = int32(math.Round(float64(w) * progress))
+			rect.X = w - rect.W
+		case MoveRight:
+			rect.W = int32(math.Round(float64(w) * progress))
+		case MoveUp:
+			rect.H = int32(math.Round(float64(h) * progress))
+			rect.Y = h - rect.H
+		case MoveDown:
+			rect.H = int32(math.Round(float64(h) * progress))
+		}
+		if rect.W > 0 && rect.H > 0 {
+			renderTransitionTexture(renderer, to, rect, rect, 255)
+		}
+	})
+}
+
+// getTransitionOffset returns the offset for a texture moving in the given
+// direction for the given progress.
+func getTransitionOffset(direction int, w int32, h int32, progress float64) (int32, int32) {
+	switch direction {
+	case MoveLeft:
+		return -int32(math.Round(float64(w) * progress)), 0
+	case MoveRight:
+		return int32(math.Round(float64(w) * progress)), 0
+	case MoveUp:
+		return 0, -int32(math.Round(float64(h) * progress))
+	case MoveDown:
+		return 0, int32(math.Round(float64(h) * progress))
+	}
+	return 0, 0
+}
+
+// renderTransitionTexture copies the given texture region to the display
+// with the given alpha. Nil rectangles use the whole texture and the whole
+// display.
+func renderTransitionTexture(renderer IRenderer, texture ITexture, src *sdl.Rect, dst *sdl.Rect, alpha uint8) {
+	if texture == nil {
+		return
+	}
+	texture.SetBlendMode(sdl.BLENDMODE_BLEND)
+	texture.SetAlphaMod(alpha)
+	renderer.Copy(texture, src, dst)
+}
+
+// GetDuration returns the transition duration in seconds.
+func (t *Transition) GetDuration() float64 {
+	return t.duration
+}
+
+// GetProgress returns the transition progress from 0 to 1.
+func (t *Transition) GetProgress() float64 {
+	if t.duration <= 0 {
+		return 1
+	}
+	return math.Min(t.elapsed/t.duration, 1)
+}
+
+// IsFinished returns if the transition has ended.
+func (t *Transition) IsFinished() bool {
+	return t.finished
+}
+
+// OnEnd adds a function to be called when the transition ends.
+func (t *Transition) OnEnd(callback func(ITransition)) ITransition {
+	t.onEnd = append(t.onEnd, callback)
+	return t
+}
+
+// OnMidpoint adds a function to be called when the transition reaches half
+// of its duration.
+func (t *Transition) OnMidpoint(callback func(ITransition)) ITransition {
+	t.onMidpoint = append(t.onMidpoint, callback)
+	return t
+}
+
+// Render renders the transition frame for the given textures.
+func (t *Transition) Render(renderer IRenderer, from ITexture, to ITexture) {
+	if t.render != nil {
+		t.render(renderer, from, to, t.GetProgress())
+	}
+}
+
+// Start moves the transition back to the beginning.
+func (t *Transition) Start() {
+	t.elapsed = 0
+	t.midpoint = false
+	t.finished = false
+}
+
+// Update moves the transition forward the given time in seconds. It returns
+// true when the transition has ended.
+func (t *Transition) Update(delta float64) bool {
+	if t.finished {
+		return true
+	}
+	t.elapsed += delta
+	progress := t.GetProgress()
+	if !t.midpoint && progress >= 0.5 {
+		t.midpoint = true
+		for _, callback := range t.onMidpoint {
+			callback(t)
+		}
+	}
+	if progress >= 1 {
+		t.finished = true
+		for _, callback := range t.onEnd {
+			callback(t)
+		}
+	}
+	return t.finished
+}
@@ -0,0 +1,131 @@
+package engosdl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// newBoxScene creates a new scene with a box with the given color. Scene
+// code calls the given function when the scene is updated.
+func newBoxScene(name string, color sdl.Color, onUpdate func()) engosdl.IScene {
+	scene := engosdl.NewScene(name, "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity := engosdl.NewEntity(name + "/box")
+		entity.AddComponent(components.NewBox(name+"/box", engosdl.NewRect(0, 0, 10, 10), color, true))
+		if onUpdate != nil {
+			controller := engosdl.NewComponent(name + "/controller")
+			controller.SetCustomOnUpdate(func(engosdl.IComponent) { onUpdate() })
+			entity.AddComponent(controller)
+		}
+		scene.AddEntity(entity)
+		return true
+	})
+	return scene
+}
+
+func TestSceneManager_Transition(t *testing.T) {
+	red, blue := sdl.Color{R: 255, A: 255}, sdl.Color{B: 255, A: 255}
+	events := []string{}
+	var to engosdl.IScene
+	requested := false
+	from := newBoxScene("from", red, func() {
+		if !requested {
+			requested = true
+			transition := engosdl.NewCrossfadeTransition("crossfade", 0.1).
+				OnMidpoint(func(engosdl.ITransition) { events = append(events, "midpoint") }).
+				OnEnd(func(engosdl.ITransition) { events = append(events, "end") })
+			engosdl.GetSceneManager().SetActiveScene(to, transition)
+		}
+	})
+	to = newBoxScene("to", blue, nil)
+	engine := newTestEngine(t)
+	engine.AddScene(from)
+	engine.AddScene(to)
+	engine.RunEngineFrames(from, 3)
+	renderer := engine.GetRenderer().(*engosdl.HeadlessRenderer)
+	// Scene being left is rendered when the transition starts, and it is
+	// kept alive, so it is rendered every frame like the scene being
+	// entered, both in their own textures.
+	getDrawCalls := func() ([]*engosdl.DrawCall, []*engosdl.DrawCall) {
+		fills, copies := []*engosdl.DrawCall{}, []*engosdl.DrawCall{}
+		for _, drawCall := range renderer.GetDrawCalls() {
+			switch drawCall.Op {
+			case engosdl.DrawFillRect:
+				fills = append(fills, drawCall)
+			case engosdl.DrawCopy:
+				copies = append(copies, drawCall)
+			}
+		}
+		return fills, copies
+	}
+	fills, copies := getDrawCalls()
+	if len(fills) != 3 || fills[0].Color != red || fills[1].Color != red || fills[2].Color != blue || fills[0].Target == nil || fills[1].Target != fills[0].Target || fills[2].Target == nil {
+		t.Fatalf("error rendering scenes in transition textures\nexp: %d\ngot: %d\n", 3, len(fills))
+	}
+	if len(copies) != 2 || copies[0].Texture != fills[1].Target || copies[1].Texture != fills[2].Target || copies[0].Target != nil {
+		t.Fatalf("error rendering transition\nexp: %d\ngot: %d\n", 2, len(copies))
+	}
+	if copies[0].Alpha != 255 || copies[1].Alpha != 85 {
+		t.Errorf("error rendering crossfade\nexp: %d %d\ngot: %d %d\n", 255, 85, copies[0].Alpha, copies[1].Alpha)
+	}
+	if len(events) != 0 {
+		t.Errorf("error calling transition callbacks\nexp: %v\ngot: %v\n", []string{}, events)
+	}
+	engine.DoRunFrames(1)
+	if fills, _ = getDrawCalls(); len(fills) != 2 || fills[0].Color != red || fills[1].Color != blue {
+		t.Errorf("error rendering scene being left\nexp: %d\ngot: %d\n", 2, len(fills))
+	}
+	if len(from.GetEntities()) != 1 {
+		t.Errorf("error keeping scene being left alive\nexp: %d\ngot: %d\n", 1, len(from.GetEntities()))
+	}
+	engine.DoRunFrames(2)
+	if exp := []string{"midpoint", "end"}; !reflect.DeepEqual(exp, events) {
+		t.Errorf("error calling transition callbacks\nexp: %v\ngot: %v\n", exp, events)
+	}
+	if engosdl.GetSceneManager().GetTransition() != nil || engosdl.GetSceneManager().GetActiveScene() != to || len(from.GetEntities()) != 0 {
+		t.Errorf("error ending transition")
+	}
+	for _, drawCall := range renderer.GetDrawCalls() {
+		if drawCall.Target != nil || drawCall.Op == engosdl.DrawCopy {
+			t.Errorf("error rendering after transition\nexp: %v\ngot: %v\n", nil, drawCall.Target)
+		}
+	}
+}
+
+func TestTransition_Render(t *testing.T) {
+	renderer := engosdl.NewHeadlessRenderer(100, 50)
+	from, to := engosdl.NewHeadlessTexture(100, 50), engosdl.NewHeadlessTexture(100, 50)
+	cases := []struct {
+		name       string
+		transition engosdl.ITransition
+		exp        []*sdl.Rect
+	}{
+		{"slide", engosdl.NewSlideTransition("slide", 1, engosdl.MoveLeft),
+			[]*sdl.Rect{{X: -25, Y: 0, W: 100, H: 50}, {X: 75, Y: 0, W: 100, H: 50}}},
+		{"wipe", engosdl.NewWipeTransition("wipe", 1, engosdl.MoveDown),
+			[]*sdl.Rect{nil, {X: 0, Y: 0, W: 100, H: 13}}},
+		{"fade", engosdl.NewFadeTransition("fade", 1, sdl.Color{A: 255}),
+			[]*sdl.Rect{nil, nil}},
+	}
+	for _, c := range cases {
+		c.transition.Start()
+		c.transition.Update(0.25)
+		c.transition.Render(renderer, from, to)
+		renderer.Present()
+		got := []*sdl.Rect{}
+		for _, drawCall := range renderer.GetDrawCalls() {
+			got = append(got, drawCall.Dst)
+		}
+		if !reflect.DeepEqual(c.exp, got) {
+			t.Errorf("error rendering %s transition\nexp: %v\ngot: %v\n", c.name, c.exp, got)
+		}
+	}
+	// Fade is half way to the color at a quarter of the transition.
+	if drawCall := renderer.GetDrawCalls()[1]; drawCall.Op != engosdl.DrawFillRect || drawCall.Color.A != 128 {
+		t.Errorf("error rendering fade transition\nexp: %d\ngot: %d\n", 128, drawCall.Color.A)
+	}
+}
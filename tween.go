@@ -0,0 +1,350 @@
+package engosdl
+
+import (
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ITween represents the interface for any tween. Tween changes values over
+// time. Every tween runs a cycle with the given duration, which can be
+// repeated, and in yoyo tweens every other cycle runs backwards.
+type ITween interface {
+	IObject
+	GetCycleDuration() float64
+	GetDuration() float64
+	GetElapsed() float64
+	GetOwner() IObject
+	IsFinished() bool
+	IsPaused() bool
+	OnComplete(func(ITween)) ITween
+	Pause()
+	Restart()
+	Resume()
+	Seek(float64)
+	SetDelay(float64) ITween
+	SetEase(EaseFunc) ITween
+	SetOwner(IObject) ITween
+	SetRepeat(int) ITween
+	SetYoyo(bool) ITween
+	Update(float64) bool
+}
+
+// Tween is the default implementation for the tween interface. Value tweens,
+// sequences and groups are all tweens with a different cycle, so they can be
+// combined in any way. Tween owner is the entity or scene being tweened, and
+// tween is deleted when the owner is destroyed.
+type Tween struct {
+	*Object
+	cycleDuration func() float64
+	apply         func(float64)
+	delay         float64
+	repeat        int
+	yoyo          bool
+	ease          EaseFunc
+	elapsed       float64
+	started       bool
+	finished      bool
+	paused        bool
+	owner         IObject
+	onComplete    []func(ITween)
+}
+
+var _ ITween = (*Tween)(nil)
+
+// newTween creates a new tween instance with the given cycle. Cycle is
+// applied with the time in the cycle, from zero to the cycle duration.
+func newTween(name string, cycleDuration func() float64, apply func(float64)) *Tween {
+	Logger.Trace().Str("tween", name).Msg("new tween")
+	return &Tween{
+		Object:        NewObject(name),
+		cycleDuration: cycleDuration,
+		apply:         apply,
+		delay:         0,
+		repeat:        0,
+		yoyo:          false,
+		ease:          nil,
+		elapsed:       0,
+		started:       false,
+		finished:      false,
+		paused:        false,
+		owner:         nil,
+		onComplete:    []func(ITween){},
+	}
+}
+
+// NewTween creates a new tween that changes values from the values returned
+// by get to the given values in the given duration in seconds. Initial values
+// are taken when the tween starts, so tweens in a sequence start where the
+// previous tween ended.
+func NewTween(name string, get func() []float64, set func([]float64), to []float64, duration float64) *Tween {
+	var from []float64
+	values := make([]float64, len(to))
+	return newTween(name,
+		func() float64 { return duration },
+		func(time float64) {
+			if from == nil {
+				from = get()
+			}
+			factor := 1.0
+			if duration > 0 {
+				factor = time / duration
+			}
+			for i := range values {
+				values[i] = from[i] + (to[i]-from[i])*factor
+			}
+			set(values)
+		})
+}
+
+// NewFloatTween creates a new tween that changes a single value.
+func NewFloatTween(name string, get func() float64, set func(float64), to float64, duration float64) *Tween {
+	return NewTween(name,
+		func() []float64 { return []float64{get()} },
+		func(values []float64) { set(values[0]) },
+		[]float64{to}, duration)
+}
+
+// NewColorTween creates a new tween that changes a color. Color components
+// are rounded to the closest value.
+func NewColorTween(name string, get func() sdl.Color, set func(sdl.Color), to sdl.Color, duration float64) *Tween {
+	toUint8 := func(value float64) uint8 {
+		return uint8(clampFloat(math.Round(value), 0, 255))
+	}
+	return NewTween(name,
+		func() []float64 {
+			color := get()
+			return []float64{float64(color.R), float64(color.G), float64(color.B), float64(color.A)}
+		},
+		func(values []float64) {
+			set(sdl.Color{R: toUint8(values[0]), G: toUint8(values[1]), B: toUint8(values[2]), A: toUint8(values[3])})
+		},
+		[]float64{float64(to.R), float64(to.G), float64(to.B), float64(to.A)}, duration)
+}
+
+// NewPositionTween creates a new tween that moves the given transform to the
+// given local position.
+func NewPositionTween(name string, transform ITransform, to *Vector, duration float64) *Tween {
+	return NewTween(name,
+		func() []float64 { return []float64{transform.GetPosition().X, transform.GetPosition().Y} },
+		func(values []float64) { transform.SetPositionXY(values[0], values[1]) },
+		[]float64{to.X, to.Y}, duration)
+}
+
+// NewRotationTween creates a new tween that rotates the given transform to
+// the given local rotation in degrees.
+func NewRotationTween(name string, transform ITransform, to float64, duration float64) *Tween {
+	return NewFloatTween(name, transform.GetRotation, func(value float64) { transform.SetRotation(value) }, to, duration)
+}
+
+// NewScaleTween creates a new tween that scales the given transform to the
+// given local scale.
+func NewScaleTween(name string, transform ITransform, to *Vector, duration float64) *Tween {
+	return NewTween(name,
+		func() []float64 { return []float64{transform.GetScale().X, transform.GetScale().Y} },
+		func(values []float64) { transform.SetScaleXY(values[0], values[1]) },
+		[]float64{to.X, to.Y}, duration)
+}
+
+// NewTweenWait creates a new tween that does not change any value. It can be
+// used to wait in a sequence.
+func NewTweenWait(name string, duration float64) *Tween {
+	return newTween(name, func() float64 { return duration }, func(float64) {})
+}
+
+// NewTweenSequence creates a new tween that runs the given tweens one after
+// the other. Tweens running forever can only be the last one in the
+// sequence.
+func NewTweenSequence(name string, tweens ...ITween) *Tween {
+	previous := 0.0
+	return newTween(name,
+		func() float64 {
+			result := 0.0
+			for _, tween := range tweens {
+				result += tween.GetDuration()
+			}
+			return result
+		},
+		func(time float64) {
+			// Every tween in the range of time elapsed is applied, so tweens
+			// skipped in a single update end in their final values. Running
+			// backwards, tweens are applied in reverse order, so the tween at
+			// the given time is always applied last.
+			low, high := math.Min(previous, time), math.Max(previous, time)
+			offsets := make([]float64, len(tweens)+1)
+			for i, tween := range tweens {
+				offsets[i+1] = offsets[i] + tween.GetDuration()
+			}
+			for i := range tweens {
+				if time < previous {
+					i = len(tweens) - 1 - i
+				}
+				if offsets[i] <= high && offsets[i+1] >= low {
+					tweens[i].Seek(clampFloat(time-offsets[i], 0, offsets[i+1]-offsets[i]))
+				}
+			}
+			previous = time
+		})
+}
+
+// NewTweenGroup creates a new tween that runs all given tweens at the same
+// time. Group ends when all tweens have ended.
+func NewTweenGroup(name string, tweens ...ITween) *Tween {
+	return newTween(name,
+		func() float64 {
+			result := 0.0
+			for _, tween := range tweens {
+				result = math.Max(result, tween.GetDuration())
+			}
+			return result
+		},
+		func(time float64) {
+			for _, tween := range tweens {
+				tween.Seek(math.Min(time, tween.GetDuration()))
+			}
+		})
+}
+
+// applyCycle applies the given time in the cycle with the tween easing.
+func (t *Tween) applyCycle(time float64) {
+	if duration := t.GetCycleDuration(); t.ease != nil && duration > 0 {
+		time = t.ease(time/duration) * duration
+	}
+	t.apply(time)
+}
+
+// GetCycleDuration returns the duration in seconds for a single cycle.
+func (t *Tween) GetCycleDuration() float64 {
+	return t.cycleDuration()
+}
+
+// GetDuration returns the duration in seconds for the whole tween, including
+// the delay and all cycles. Tweens repeating forever have infinite duration.
+func (t *Tween) GetDuration() float64 {
+	if t.repeat < 0 {
+		return math.Inf(1)
+	}
+	return t.delay + t.GetCycleDuration()*float64(t.repeat+1)
+}
+
+// GetElapsed returns the time in seconds elapsed since the tween started.
+func (t *Tween) GetElapsed() float64 {
+	return t.elapsed
+}
+
+// GetOwner returns the entity or scene owning the tween.
+func (t *Tween) GetOwner() IObject {
+	return t.owner
+}
+
+// IsFinished returns if the tween has ended.
+func (t *Tween) IsFinished() bool {
+	return t.finished
+}
+
+// IsPaused returns if the tween has been paused.
+func (t *Tween) IsPaused() bool {
+	return t.paused
+}
+
+// OnComplete adds a function to be called when the tween ends.
+func (t *Tween) OnComplete(callback func(ITween)) ITween {
+	t.onComplete = append(t.onComplete, callback)
+	return t
+}
+
+// Pause stops updating the tween.
+func (t *Tween) Pause() {
+	t.paused = true
+}
+
+// Restart moves the tween back to the start.
+func (t *Tween) Restart() {
+	t.finished = false
+	t.paused = false
+	t.Seek(0)
+}
+
+// Resume continues updating the tween.
+func (t *Tween) Resume() {
+	t.paused = false
+}
+
+// Seek applies the tween values for the given time in seconds since the
+// tween started. Nothing is applied during the delay unless the tween has
+// already started.
+func (t *Tween) Seek(time float64) {
+	t.elapsed = time
+	local := time - t.delay
+	if local < 0 {
+		if t.started {
+			t.applyCycle(0)
+		}
+		return
+	}
+	t.started = true
+	duration := t.GetCycleDuration()
+	if duration <= 0 {
+		t.applyCycle(0)
+		return
+	}
+	cycle := math.Floor(local / duration)
+	position := local - cycle*duration
+	if t.repeat >= 0 && cycle > float64(t.repeat) {
+		cycle = float64(t.repeat)
+		position = duration
+	}
+	if t.yoyo && math.Mod(cycle, 2) == 1 {
+		position = duration - position
+	}
+	t.applyCycle(position)
+}
+
+// SetDelay sets the time in seconds to wait before the tween starts.
+func (t *Tween) SetDelay(delay float64) ITween {
+	t.delay = delay
+	return t
+}
+
+// SetEase sets the easing function for every cycle. Nil easing is linear.
+func (t *Tween) SetEase(ease EaseFunc) ITween {
+	t.ease = ease
+	return t
+}
+
+// SetOwner sets the entity or scene owning the tween. Tween is deleted when
+// the owner is destroyed.
+func (t *Tween) SetOwner(owner IObject) ITween {
+	t.owner = owner
+	return t
+}
+
+// SetRepeat sets the number of times the cycle is repeated after the first
+// one. Negative value repeats forever.
+func (t *Tween) SetRepeat(repeat int) ITween {
+	t.repeat = repeat
+	return t
+}
+
+// SetYoyo sets if every other cycle runs backwards.
+func (t *Tween) SetYoyo(yoyo bool) ITween {
+	t.yoyo = yoyo
+	return t
+}
+
+// Update moves the tween forward the given time in seconds. It returns true
+// when the tween has ended, and all completion functions are called the
+// first time.
+func (t *Tween) Update(delta float64) bool {
+	if t.finished || t.paused {
+		return t.finished
+	}
+	t.Seek(t.elapsed + delta)
+	if t.elapsed >= t.GetDuration() {
+		t.finished = true
+		for _, callback := range t.onComplete {
+			callback(t)
+		}
+	}
+	return t.finished
+}
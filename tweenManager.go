@@ -0,0 +1,127 @@
+package engosdl
+
+// TweenName represents on tween delegate.
+const TweenName = "on-tween"
+
+// ITweenManager represents the interface for the tween manager. Tween
+// manager updates all tweens added to it for every update step.
+type ITweenManager interface {
+	IObject
+	AddTween(ITween) ITween
+	Clear()
+	DeleteTween(ITween) bool
+	DeleteTweensFor(IObject) bool
+	DoInit()
+	GetDelegate() IDelegate
+	GetTweens() []ITween
+	OnStart()
+	OnUpdate()
+}
+
+// TweenManager is the default implementation for the tween manager
+// interface. Tweens are removed when they end and tween manager delegate is
+// triggered with the tween that ended.
+type TweenManager struct {
+	*Object
+	tweens   []ITween
+	delegate IDelegate
+}
+
+var _ ITweenManager = (*TweenManager)(nil)
+
+// NewTweenManager creates a new tween manager instance.
+func NewTweenManager(name string) *TweenManager {
+	Logger.Trace().Str("tween-manager", name).Msg("new tween-manager")
+	return &TweenManager{
+		Object:   NewObject(name),
+		tweens:   []ITween{},
+		delegate: nil,
+	}
+}
+
+// AddTween adds a new tween to be updated. Tweens without owner are owned by
+// the active scene, so they are deleted when the scene is destroyed.
+func (h *TweenManager) AddTween(tween ITween) ITween {
+	Logger.Trace().Str("tween-manager", h.GetName()).Str("tween", tween.GetName()).Msg("AddTween")
+	if tween.GetOwner() == nil {
+		if sceneManager := GetSceneManager(); sceneManager != nil && sceneManager.GetActiveScene() != nil {
+			tween.SetOwner(sceneManager.GetActiveScene())
+		}
+	}
+	h.tweens = append(h.tweens, tween)
+	return tween
+}
+
+// Clear removes all tweens.
+func (h *TweenManager) Clear() {
+	Logger.Trace().Str("tween-manager", h.GetName()).Msg("Clear")
+	h.tweens = []ITween{}
+}
+
+// DeleteTween removes the given tween, so it is not updated anymore.
+func (h *TweenManager) DeleteTween(tween ITween) bool {
+	Logger.Trace().Str("tween-manager", h.GetName()).Str("tween", tween.GetName()).Msg("DeleteTween")
+	for i, traverse := range h.tweens {
+		if traverse.GetID() == tween.GetID() {
+			h.tweens = append(h.tweens[:i], h.tweens[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteTweensFor removes all tweens owned by the given entity or scene. It
+// returns true if any tween was removed.
+func (h *TweenManager) DeleteTweensFor(owner IObject) bool {
+	Logger.Trace().Str("tween-manager", h.GetName()).Str("owner", owner.GetName()).Msg("DeleteTweensFor")
+	tweens := []ITween{}
+	for _, tween := range h.tweens {
+		if tween.GetOwner() == nil || tween.GetOwner().GetID() != owner.GetID() {
+			tweens = append(tweens, tween)
+		}
+	}
+	result := len(tweens) != len(h.tweens)
+	h.tweens = tweens
+	return result
+}
+
+// DoInit initializes all tween manager resources. It creates the tween
+// manager delegate, so delegate manager has to be initialized before.
+func (h *TweenManager) DoInit() {
+	Logger.Trace().Str("tween-manager", h.GetName()).Msg("DoInit")
+	h.tweens = []ITween{}
+	h.delegate = GetDelegateManager().CreateDelegate(h, TweenName)
+}
+
+// GetDelegate returns the delegate triggered when a tween ends.
+func (h *TweenManager) GetDelegate() IDelegate {
+	return h.delegate
+}
+
+// GetTweens returns all tweens being updated.
+func (h *TweenManager) GetTweens() []ITween {
+	return h.tweens
+}
+
+// OnStart is called when the engine starts.
+func (h *TweenManager) OnStart() {
+	Logger.Trace().Str("tween-manager", h.GetName()).Msg("OnStart")
+}
+
+// OnUpdate updates all tweens with the update step delta time. Tweens added
+// while updating are updated in the next step.
+func (h *TweenManager) OnUpdate() {
+	tweens := h.tweens
+	h.tweens = []ITween{}
+	running := []ITween{}
+	for _, tween := range tweens {
+		if tween.Update(GetDeltaTime()) {
+			if h.delegate != nil {
+				GetDelegateManager().TriggerDelegate(h.delegate, false, tween)
+			}
+			continue
+		}
+		running = append(running, tween)
+	}
+	h.tweens = append(running, h.tweens...)
+}
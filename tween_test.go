@@ -0,0 +1,188 @@
+package engosdl_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jrecuero/engosdl"
+	"github.com/jrecuero/engosdl/assets/components"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// runTween updates the given tween with the given delta time until it ends
+// or the given number of steps. It returns the value after every step.
+func runTween(tween engosdl.ITween, value *float64, delta float64, steps int) []float64 {
+	result := []float64{}
+	for i := 0; i < steps; i++ {
+		finished := tween.Update(delta)
+		result = append(result, math.Round(*value*100)/100)
+		if finished {
+			break
+		}
+	}
+	return result
+}
+
+func TestEase(t *testing.T) {
+	names := []string{
+		engosdl.EaseNameLinear, engosdl.EaseNameInQuad, engosdl.EaseNameOutQuad, engosdl.EaseNameInOutQuad,
+		engosdl.EaseNameInCubic, engosdl.EaseNameOutCubic, engosdl.EaseNameInOutCubic,
+		engosdl.EaseNameInSine, engosdl.EaseNameOutSine, engosdl.EaseNameInOutSine,
+		engosdl.EaseNameInElastic, engosdl.EaseNameOutElastic, engosdl.EaseNameInOutElastic,
+		engosdl.EaseNameInBounce, engosdl.EaseNameOutBounce, engosdl.EaseNameInOutBounce,
+		engosdl.EaseNameInBack, engosdl.EaseNameOutBack, engosdl.EaseNameInOutBack,
+	}
+	for _, name := range names {
+		ease := engosdl.GetEase(name)
+		if start, end := ease(0), ease(1); math.Abs(start) > 1e-9 || math.Abs(end-1) > 1e-9 {
+			t.Errorf("error in %s easing limits\nexp: %f %f\ngot: %f %f\n", name, 0.0, 1.0, start, end)
+		}
+	}
+	if got := engosdl.EaseInOutQuad(0.25); got != 0.125 {
+		t.Errorf("error in easing\nexp: %f\ngot: %f\n", 0.125, got)
+	}
+	if got := engosdl.EaseOutBack(0.5); got <= 1 {
+		t.Errorf("error in back easing overshoot\nexp: > %f\ngot: %f\n", 1.0, got)
+	}
+	if got := engosdl.GetEase("unknown")(0.3); got != 0.3 {
+		t.Errorf("error getting unknown easing\nexp: %f\ngot: %f\n", 0.3, got)
+	}
+}
+
+func TestTween_Update(t *testing.T) {
+	var value float64
+	newValueTween := func(to float64, duration float64) engosdl.ITween {
+		return engosdl.NewFloatTween("value", func() float64 { return value }, func(v float64) { value = v }, to, duration)
+	}
+	cases := []struct {
+		name  string
+		tween func() engosdl.ITween
+		exp   []float64
+	}{
+		{"linear", func() engosdl.ITween { return newValueTween(10, 1) },
+			[]float64{2.5, 5, 7.5, 10}},
+		{"delay", func() engosdl.ITween { return newValueTween(10, 0.5).SetDelay(0.5) },
+			[]float64{0, 0, 5, 10}},
+		{"ease", func() engosdl.ITween { return newValueTween(10, 1).SetEase(engosdl.EaseInQuad) },
+			[]float64{0.63, 2.5, 5.63, 10}},
+		{"repeat", func() engosdl.ITween { return newValueTween(10, 0.5).SetRepeat(1) },
+			[]float64{5, 0, 5, 10}},
+		{"yoyo", func() engosdl.ITween { return newValueTween(10, 0.5).SetRepeat(2).SetYoyo(true) },
+			[]float64{5, 10, 5, 0, 5, 10}},
+		{"sequence", func() engosdl.ITween {
+			return engosdl.NewTweenSequence("sequence", newValueTween(10, 0.5), engosdl.NewTweenWait("wait", 0.25), newValueTween(0, 0.5))
+		}, []float64{5, 10, 10, 5, 0}},
+		{"sequence yoyo", func() engosdl.ITween {
+			return engosdl.NewTweenSequence("sequence", newValueTween(10, 0.5), newValueTween(20, 0.5)).SetYoyo(true).SetRepeat(1)
+		}, []float64{5, 10, 15, 20, 15, 10, 5, 0}},
+		{"group", func() engosdl.ITween {
+			return engosdl.NewTweenGroup("group", newValueTween(10, 0.5), engosdl.NewTweenWait("wait", 1))
+		}, []float64{5, 10, 10, 10}},
+	}
+	for _, c := range cases {
+		value = 0
+		completed := 0
+		tween := c.tween().OnComplete(func(engosdl.ITween) { completed++ })
+		got := runTween(tween, &value, 0.25, 20)
+		if !reflect.DeepEqual(c.exp, got) {
+			t.Errorf("error updating %s tween\nexp: %v\ngot: %v\n", c.name, c.exp, got)
+		}
+		if !tween.IsFinished() || completed != 1 {
+			t.Errorf("error completing %s tween\nexp: %d\ngot: %d\n", c.name, 1, completed)
+		}
+	}
+}
+
+func TestTweenManager(t *testing.T) {
+	var box *components.Box
+	var entity engosdl.IEntity
+	completed := []string{}
+	scene := engosdl.NewScene("test-tween-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity = engosdl.NewEntity("player")
+		box = components.NewBox("player/box", engosdl.NewRect(0, 0, 10, 10), sdl.Color{R: 255, A: 255}, true)
+		entity.AddComponent(box)
+		listener := engosdl.NewComponent("player/listener")
+		listener.AddDelegateToRegister(engosdl.GetTweenManager().GetDelegate(), nil, nil, func(params ...interface{}) bool {
+			completed = append(completed, params[0].(engosdl.ITween).GetName())
+			return true
+		})
+		entity.AddComponent(listener)
+		scene.AddEntity(entity)
+		transform := entity.GetTransform()
+		engosdl.GetTweenManager().AddTween(engosdl.NewTweenGroup("appear",
+			engosdl.NewPositionTween("move", transform, engosdl.NewVector(30, 60), 0.1),
+			engosdl.NewScaleTween("scale", transform, engosdl.NewVector(2, 2), 0.1),
+			engosdl.NewRotationTween("rotate", transform, 90, 0.1),
+			engosdl.NewColorTween("fade", box.GetColor, box.SetColor, sdl.Color{B: 255, A: 0}, 0.1)))
+		engosdl.GetTweenManager().AddTween(engosdl.NewTweenWait("forever", 1).SetRepeat(-1))
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 3)
+	transform := entity.GetTransform()
+	if x, y := transform.GetPosition().Get(); x != 20 || y != 40 {
+		t.Errorf("error tweening position\nexp: %f %f\ngot: %f %f\n", 20.0, 40.0, x, y)
+	}
+	if exp, got := (sdl.Color{R: 85, B: 170, A: 85}), box.GetColor(); exp != got {
+		t.Errorf("error tweening color\nexp: %v\ngot: %v\n", exp, got)
+	}
+	engine.DoRunFrames(2)
+	if x, y := transform.GetScale().Get(); x != 2 || y != 2 || transform.GetRotation() != 90 {
+		t.Errorf("error tweening scale and rotation\nexp: %f %f %f\ngot: %f %f %f\n", 2.0, 2.0, 90.0, x, y, transform.GetRotation())
+	}
+	if exp := []string{"appear"}; !reflect.DeepEqual(exp, completed) {
+		t.Errorf("error triggering tween delegate\nexp: %v\ngot: %v\n", exp, completed)
+	}
+	if tweens := engosdl.GetTweenManager().GetTweens(); len(tweens) != 1 || tweens[0].GetName() != "forever" {
+		t.Errorf("error removing finished tweens\nexp: %d\ngot: %d\n", 1, len(tweens))
+	}
+}
+
+func TestTweenManager_DeleteOwner(t *testing.T) {
+	var entity engosdl.IEntity
+	scene := engosdl.NewScene("test-tween-owner-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity = engosdl.NewEntity("player")
+		scene.AddEntity(entity)
+		engosdl.GetTweenManager().AddTween(engosdl.NewTweenWait("entity", 1).SetRepeat(-1).SetOwner(entity))
+		engosdl.GetTweenManager().AddTween(engosdl.NewTweenWait("scene", 1).SetRepeat(-1))
+		return true
+	})
+	engine := newTestEngine(t)
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 1)
+	scene.DeleteEntity(entity)
+	engine.DoRunFrames(1)
+	if tweens := engosdl.GetTweenManager().GetTweens(); len(tweens) != 1 || tweens[0].GetName() != "scene" {
+		t.Errorf("error deleting tweens for destroyed entity\nexp: %d\ngot: %d\n", 1, len(tweens))
+	}
+	scene.DoDestroy()
+	if tweens := engosdl.GetTweenManager().GetTweens(); len(tweens) != 0 {
+		t.Errorf("error deleting tweens for destroyed scene\nexp: %d\ngot: %d\n", 0, len(tweens))
+	}
+}
+
+func TestTweenManager_TextColor(t *testing.T) {
+	var entity engosdl.IEntity
+	scene := engosdl.NewScene("test-tween-text-scene", "test")
+	scene.SetSceneCode(func(engine *engosdl.Engine, scene engosdl.IScene) bool {
+		entity = engosdl.NewEntity("title")
+		text := components.NewText("title/text", fontFilename, 12, sdl.Color{A: 255}, "title")
+		entity.AddComponent(text)
+		scene.AddEntity(entity)
+		engosdl.GetTweenManager().AddTween(engosdl.NewColorTween("fade", text.GetColor, func(color sdl.Color) { text.SetColor(color) }, sdl.Color{R: 255, A: 255}, 0.1))
+		return true
+	})
+	engine := newTestEngine(t)
+	defer engosdl.GetFontManager().Clear()
+	engine.AddScene(scene)
+	engine.RunEngineFrames(scene, 10)
+	scene.DeleteEntity(entity)
+	engine.DoRunFrames(1)
+	if font := engosdl.GetFontManager().GetFontByFilename(fontFilename); font != nil {
+		t.Errorf("error releasing font used by tweened text")
+	}
+}
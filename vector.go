@@ -32,3 +32,9 @@ func (v *Vector) InRect(rect *Rect) bool {
 	}
 	return sdlPoint.InRect(sdlRect)
 }
+
+// Lerp returns the vector between this vector and the given one for the
+// given factor, where 0 is this vector and 1 is the given one.
+func (v *Vector) Lerp(to *Vector, factor float64) *Vector {
+	return NewVector(v.X+(to.X-v.X)*factor, v.Y+(to.Y-v.Y)*factor)
+}